@@ -6,10 +6,12 @@ require (
 	github.com/Nistagram-Organization/nistagram-shared v0.0.0-20210708132726-61fa4249471d
 	github.com/gin-contrib/cors v1.3.1
 	github.com/gin-gonic/gin v1.7.2
+	github.com/go-sql-driver/mysql v1.6.0
 	github.com/prometheus/client_golang v1.11.0
 	github.com/soheilhy/cmux v0.1.5
 	github.com/stretchr/testify v1.7.0
 	google.golang.org/grpc v1.39.0
+	google.golang.org/protobuf v1.26.0
 	gorm.io/driver/mysql v1.1.1
 	gorm.io/gorm v1.21.11
 )
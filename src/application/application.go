@@ -5,10 +5,53 @@ import (
 	"github.com/Nistagram-Organization/nistagram-posts/src/clients/user_grpc_client"
 	controller "github.com/Nistagram-Organization/nistagram-posts/src/controllers/post"
 	"github.com/Nistagram-Organization/nistagram-posts/src/datasources/mysql"
+	"github.com/Nistagram-Organization/nistagram-posts/src/feed_ranker"
+	"github.com/Nistagram-Organization/nistagram-posts/src/jobs"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/highlight"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/impression"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/large_account"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/pending_fan_out"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/report"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/scheduled_post"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	postsproto "github.com/Nistagram-Organization/nistagram-posts/src/proto"
+	authorrestrictionrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/author_restriction"
+	bannedmediarepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/banned_media"
+	campaignrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/campaign"
+	closefriendrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/close_friend"
 	commentRepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment"
+	commentreviewrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment_review"
 	dislikerepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/dislike"
+	draftrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/draft"
+	feeditemrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/feed_item"
+	highlightrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/highlight"
+	impressionrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/impression"
+	largeaccountrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/large_account"
 	likerepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/like"
+	muterepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/mute"
+	pendingfanoutrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/pending_fan_out"
 	postrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post"
+	postmediarepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_media"
+	postsettingrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_setting"
+	postsimilarityrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_similarity"
+	posttermrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_term"
+	reactionrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/reaction"
+	reportrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/report"
+	scheduledpostrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/scheduled_post"
+	storyrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/story"
 	postservice "github.com/Nistagram-Organization/nistagram-posts/src/services/post"
 	"github.com/Nistagram-Organization/nistagram-posts/src/services/post_grpc_service"
 	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
@@ -29,10 +72,18 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"time"
 )
 
 const (
 	dockerKey = "docker"
+
+	exploreRefreshInterval    = 10 * time.Minute
+	trendingRefreshInterval   = 5 * time.Minute
+	similarityRefreshInterval = time.Hour
+	scheduledPublishInterval  = time.Minute
+	storySweepInterval        = 10 * time.Minute
+	fanOutRetryInterval       = time.Minute
 )
 
 var (
@@ -61,6 +112,32 @@ func setupDatabase() (datasources.DatabaseClient, error) {
 		&comment.Comment{},
 		&user_tag.UserTag{},
 		&post.Post{},
+		&banned_media.BannedMedia{},
+		&banned_media.BannedMediaBand{},
+		&report.Report{},
+		&author_restriction.AuthorRestriction{},
+		&post_setting.PostSetting{},
+		&comment_review.CommentReview{},
+		&feed_item.FeedItem{},
+		&large_account.LargeAccount{},
+		&post_similarity.PostSimilarity{},
+		&impression.Impression{},
+		&impression.ImpressionCount{},
+		&reaction.Reaction{},
+		&campaign.Campaign{},
+		&campaign.CampaignDelivery{},
+		&scheduled_post.ScheduledPost{},
+		&scheduled_post.ScheduledPostMedia{},
+		&draft.Draft{},
+		&post_media.PostMedia{},
+		&story.Story{},
+		&story.StoryView{},
+		&highlight.Highlight{},
+		&highlight.HighlightStory{},
+		&close_friend.CloseFriend{},
+		&mute.Mute{},
+		&pending_fan_out.PendingFanOut{},
+		&post_term.PostTerm{},
 	); err != nil {
 		return nil, err
 	}
@@ -108,27 +185,113 @@ func StartApplication() {
 	dislikeRepo := dislikerepository.NewDislikeRepository(database)
 	likeRepo := likerepository.NewLikeRepository(database)
 	postRepo := postrepository.NewPostRepository(database)
-	postService := postservice.NewPostService(postRepo, likeRepo, dislikeRepo, commentRepo, mediaGrpcClient, userGrpcClient)
+	bannedMediaRepo := bannedmediarepository.NewBannedMediaRepository(database)
+	reportRepo := reportrepository.NewReportRepository(database)
+	restrictionRepo := authorrestrictionrepository.NewAuthorRestrictionRepository(database)
+	settingRepo := postsettingrepository.NewPostSettingRepository(database)
+	commentReviewRepo := commentreviewrepository.NewCommentReviewRepository(database)
+	feedItemRepo := feeditemrepository.NewFeedItemRepository(database)
+	largeAccountRepo := largeaccountrepository.NewLargeAccountRepository(database)
+	postSimilarityRepo := postsimilarityrepository.NewPostSimilarityRepository(database)
+	impressionRepo := impressionrepository.NewImpressionRepository(database)
+	reactionRepo := reactionrepository.NewReactionRepository(database)
+	if err := reactionRepo.Backfill(); err != nil {
+		log.Printf("failed to backfill reactions: %s", err)
+	}
+	campaignRepo := campaignrepository.NewCampaignRepository(database)
+	scheduledPostRepo := scheduledpostrepository.NewScheduledPostRepository(database)
+	draftRepo := draftrepository.NewDraftRepository(database)
+	postMediaRepo := postmediarepository.NewPostMediaRepository(database)
+	storyRepo := storyrepository.NewStoryRepository(database)
+	highlightRepo := highlightrepository.NewHighlightRepository(database)
+	closeFriendRepo := closefriendrepository.NewCloseFriendRepository(database)
+	muteRepo := muterepository.NewMuteRepository(database)
+	pendingFanOutRepo := pendingfanoutrepository.NewPendingFanOutRepository(database)
+	postTermRepo := posttermrepository.NewPostTermRepository(database)
+	postService := postservice.NewPostService(postRepo, likeRepo, dislikeRepo, commentRepo, bannedMediaRepo, reportRepo, restrictionRepo, settingRepo, commentReviewRepo, feedItemRepo, largeAccountRepo, postSimilarityRepo, impressionRepo, reactionRepo, campaignRepo, scheduledPostRepo, draftRepo, postMediaRepo, storyRepo, highlightRepo, closeFriendRepo, muteRepo, pendingFanOutRepo, postTermRepo, feed_ranker.NewFeedRanker(), mediaGrpcClient, userGrpcClient)
 	postGrpcService := post_grpc_service.NewPostGrpcService(postService)
+	postRestrictionGrpcService := post_grpc_service.NewPostRestrictionGrpcService(postService)
+	postFeedGrpcService := post_grpc_service.NewPostFeedGrpcService(postService)
 
 	postController := controller.NewPostController(postService)
 
+	jobs.Schedule("explore", exploreRefreshInterval, postService.RefreshExplore)
+	jobs.Schedule("trending", trendingRefreshInterval, postService.RefreshTrending)
+	jobs.Schedule("similar posts", similarityRefreshInterval, postService.RefreshSimilarPosts)
+	jobs.Schedule("scheduled posts", scheduledPublishInterval, postService.PublishScheduledPosts)
+	jobs.Schedule("expired stories", storySweepInterval, postService.SweepExpiredStories)
+	jobs.Schedule("pending fan-outs", fanOutRetryInterval, postService.RetryFanOuts)
+
 	router.POST("/posts", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.CreatePost)
 	router.POST("/posts/like", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.LikePost)
 	router.DELETE("/posts/like", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UnlikePost)
 	router.POST("/posts/dislike", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.DislikePost)
 	router.DELETE("/posts/dislike", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UndislikePost)
 	router.POST("/posts/report/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.ReportInappropriateContent)
+	router.POST("/posts/impressions", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.RecordImpressions)
 	router.POST("/posts/comment", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.PostComment)
 	router.GET("/posts", postController.GetUsersPosts)
 	router.GET("/posts/inappropriate", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.GetInappropriateContent)
+	router.GET("/posts/moderation/queue", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.GetModerationQueue)
+	router.POST("/posts/moderation/decisions", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.DecideOnContentBulk)
+	router.GET("/posts/restrictions", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.GetAuthorRestrictions)
+	router.POST("/posts/restrictions", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.RestrictAuthor)
+	router.DELETE("/posts/restrictions", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.RemoveAuthorRestriction)
 	router.GET("/posts/feed", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetPostsFeed)
+	router.GET("/posts/explore", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetExplore)
 	router.GET("/posts/search", postController.SearchTags)
+	router.GET("/posts/trending", postController.GetTrending)
+	router.GET("/posts/:id/media", postController.GetPostMedia)
+	router.POST("/posts/:id/archive", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.ArchivePost)
+	router.POST("/posts/:id/unarchive", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UnarchivePost)
+	router.POST("/posts/:id/pin", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.PinPost)
+	router.POST("/posts/:id/unpin", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UnpinPost)
+	router.GET("/posts/archive", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetArchivedPosts)
+	router.GET("/posts/:id/similar", postController.GetSimilarPosts)
+	router.GET("/posts/:id/insights", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"agent"}), postController.GetPostInsights)
+	router.GET("/posts/insights/summary", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"agent"}), postController.GetInsightsSummary)
+	router.POST("/posts/campaigns", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"agent"}), postController.CreateCampaign)
+	router.GET("/posts/campaigns", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"agent"}), postController.GetCampaigns)
+	router.GET("/posts/scheduled", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetScheduledPosts)
+	router.PUT("/posts/scheduled/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.ReschedulePost)
+	router.DELETE("/posts/scheduled/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.CancelScheduledPost)
+	router.POST("/posts/drafts", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.CreateDraft)
+	router.GET("/posts/drafts", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetDrafts)
+	router.GET("/posts/drafts/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetDraft)
+	router.PUT("/posts/drafts/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UpdateDraft)
+	router.DELETE("/posts/drafts/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.DeleteDraft)
+	router.POST("/posts/drafts/:id/publish", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.PublishDraft)
+	router.POST("/posts/stories", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.CreateStory)
+	router.GET("/posts/stories", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetStoriesTray)
+	router.POST("/posts/stories/:id/view", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.ViewStory)
+	router.GET("/posts/stories/:id/viewers", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetStoryViewers)
+	router.POST("/posts/stories/:id/archive", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.ArchiveStory)
+	router.GET("/posts/stories/archive", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetArchivedStories)
+	router.GET("/posts/highlights", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetHighlights)
+	router.POST("/posts/highlights", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.CreateHighlight)
+	router.PUT("/posts/highlights/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UpdateHighlight)
+	router.DELETE("/posts/highlights/:id", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.DeleteHighlight)
+	router.POST("/posts/content-warning", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.SetContentWarning)
+	router.POST("/posts/comment-policy", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.SetCommentPolicy)
+	router.POST("/posts/audience", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.SetAudience)
+	router.GET("/posts/close-friends", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetCloseFriends)
+	router.POST("/posts/close-friends", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.AddCloseFriend)
+	router.DELETE("/posts/close-friends", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.RemoveCloseFriend)
+	router.GET("/posts/mutes", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetMutedUsers)
+	router.POST("/posts/mutes", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.MuteUser)
+	router.DELETE("/posts/mutes", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.UnmuteUser)
+	router.POST("/posts/moderation/content-warning", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.SetContentWarningAsModerator)
+	router.GET("/posts/comments/quarantined", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.GetQuarantinedComments)
+	router.POST("/posts/comments/quarantined", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"user", "agent"}), postController.ReviewQuarantinedComment)
+	router.GET("/posts/moderation/comments", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.GetAllQuarantinedComments)
+	router.POST("/posts/moderation/comments", jwt_utils.GetJwtMiddleware(), jwt_utils.CheckRoles([]string{"admin"}), postController.ReviewQuarantinedCommentAsModerator)
 
 	router.GET("/metrics", prometheus_handler.PrometheusGinHandler())
 
 	grpcS := grpc.NewServer()
 	proto.RegisterPostServiceServer(grpcS, postGrpcService)
+	postsproto.RegisterPostRestrictionServiceServer(grpcS, postRestrictionGrpcService)
+	postsproto.RegisterPostFeedServiceServer(grpcS, postFeedGrpcService)
 
 	httpS := &http.Server{
 		Handler: router,
@@ -3,13 +3,17 @@ package media_grpc_client
 import (
 	"context"
 	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	postsproto "github.com/Nistagram-Organization/nistagram-posts/src/proto"
 	"github.com/Nistagram-Organization/nistagram-shared/src/proto"
 	"google.golang.org/grpc"
+	"io"
 )
 
 type MediaGrpcClient interface {
 	SaveMedia(dtos.SaveMediaRequest) (*uint, error)
 	GetMedia(dtos.GetMediaRequest) (string, error)
+	DeleteMedia(dtos.DeleteMediaRequest) error
+	GetMediaBatch(dtos.GetMediaBatchRequest) (map[uint64]string, error)
 }
 
 type mediaGrpcClient struct {
@@ -81,3 +85,64 @@ func (c *mediaGrpcClient) GetMedia(request dtos.GetMediaRequest) (string, error)
 
 	return r.Image.ImageBase64, nil
 }
+
+// DeleteMedia removes saved media which no post, story or draft refers to
+func (c *mediaGrpcClient) DeleteMedia(request dtos.DeleteMediaRequest) error {
+	conn, err := grpc.Dial(c.address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewMediaStorageServiceClient(conn)
+
+	_, err = client.DeleteMedia(ctx,
+		&postsproto.DeleteMediaRequest{
+			Id: request.ID,
+		},
+	)
+
+	return err
+}
+
+// GetMediaBatch returns content of several media over a single connection, mapped by media id
+func (c *mediaGrpcClient) GetMediaBatch(request dtos.GetMediaBatchRequest) (map[uint64]string, error) {
+	conn, err := grpc.Dial(c.address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewMediaStorageServiceClient(conn)
+
+	stream, err := client.GetMediaBatch(ctx,
+		&postsproto.GetMediaBatchRequest{
+			Ids: request.IDs,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[uint64]string, len(request.IDs))
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		images[resp.Id] = resp.Image
+	}
+
+	return images, nil
+}
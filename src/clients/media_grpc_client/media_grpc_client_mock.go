@@ -18,5 +18,22 @@ func (c *MediaGrpcClientMock) SaveMedia(request dtos.SaveMediaRequest) (*uint, e
 }
 
 func (c *MediaGrpcClientMock) GetMedia(request dtos.GetMediaRequest) (string, error) {
-	panic("implement me")
+	args := c.Called(request)
+	if args.Get(1) == nil {
+		return args.String(0), nil
+	}
+	return "", args.Get(1).(error)
+}
+
+func (c *MediaGrpcClientMock) DeleteMedia(request dtos.DeleteMediaRequest) error {
+	args := c.Called(request)
+	return args.Error(0)
+}
+
+func (c *MediaGrpcClientMock) GetMediaBatch(request dtos.GetMediaBatchRequest) (map[uint64]string, error) {
+	args := c.Called(request)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[uint64]string), nil
+	}
+	return nil, args.Get(1).(error)
 }
@@ -3,6 +3,7 @@ package user_grpc_client
 import (
 	"context"
 	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	postsproto "github.com/Nistagram-Organization/nistagram-posts/src/proto"
 	"github.com/Nistagram-Organization/nistagram-shared/src/proto"
 	"google.golang.org/grpc"
 	"io"
@@ -14,6 +15,11 @@ type UserGrpcClient interface {
 	CheckIfUserIsTaggable(dtos.CheckTaggableRequest) (bool, error)
 	GetFollowingUsers(dtos.GetFollowingUsersRequest) ([]string, error)
 	CheckIfUserIsBlocked(dtos.CheckIfUserIsBlockedRequest) (bool, error)
+	GetFollowers(dtos.GetFollowersRequest) ([]string, error)
+	GetUsernames(dtos.GetUsernamesRequest) (map[string]string, error)
+	CheckIfProfileIsPrivate(dtos.CheckIfProfileIsPrivateRequest) (bool, error)
+	GetBlockedUsers(dtos.GetBlockedUsersRequest) ([]string, error)
+	CheckIfFollowing(dtos.CheckIfFollowingRequest) (bool, error)
 }
 
 type userGrpcClient struct {
@@ -160,7 +166,7 @@ func (u *userGrpcClient) CheckIfUserIsBlocked(request dtos.CheckIfUserIsBlockedR
 
 	r, err := client.CheckIfUserIsBlocked(ctx,
 		&proto.CheckIfUserIsBlockedRequest{
-			User: request.User,
+			User:        request.User,
 			BlockedUser: request.BlockedUser,
 		},
 	)
@@ -170,4 +176,173 @@ func (u *userGrpcClient) CheckIfUserIsBlocked(request dtos.CheckIfUserIsBlockedR
 	}
 
 	return r.Blocked, nil
-}
\ No newline at end of file
+}
+
+func (u *userGrpcClient) GetFollowers(request dtos.GetFollowersRequest) ([]string, error) {
+	conn, err := grpc.Dial(u.address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewUserRelationServiceClient(conn)
+
+	stream, err := client.GetFollowers(ctx,
+		&postsproto.GetFollowersRequest{
+			UserEmail: request.UserEmail,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var followers []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		followers = append(followers, resp.User)
+	}
+
+	return followers, nil
+}
+
+// GetUsernames returns usernames of several users over a single connection, mapped by email
+func (u *userGrpcClient) GetUsernames(request dtos.GetUsernamesRequest) (map[string]string, error) {
+	conn, err := grpc.Dial(u.address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewUserRelationServiceClient(conn)
+
+	stream, err := client.GetUsernames(ctx,
+		&postsproto.GetUsernamesRequest{
+			UserEmails: request.Emails,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[string]string, len(request.Emails))
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		usernames[resp.UserEmail] = resp.Username
+	}
+
+	return usernames, nil
+}
+
+// CheckIfProfileIsPrivate tells whether only followers can see the user's profile
+func (u *userGrpcClient) CheckIfProfileIsPrivate(request dtos.CheckIfProfileIsPrivateRequest) (bool, error) {
+	conn, err := grpc.Dial(u.address, grpc.WithInsecure())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewUserRelationServiceClient(conn)
+
+	response, err := client.CheckIfProfileIsPrivate(ctx,
+		&postsproto.CheckIfProfileIsPrivateRequest{
+			UserEmail: request.UserEmail,
+		},
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	return response.Private, nil
+}
+
+// GetBlockedUsers returns users the user blocked together with users who blocked them
+func (u *userGrpcClient) GetBlockedUsers(request dtos.GetBlockedUsersRequest) ([]string, error) {
+	conn, err := grpc.Dial(u.address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewUserRelationServiceClient(conn)
+
+	stream, err := client.GetBlockedUsers(ctx,
+		&postsproto.GetBlockedUsersRequest{
+			UserEmail: request.UserEmail,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var blocked []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		blocked = append(blocked, resp.User)
+	}
+
+	return blocked, nil
+}
+
+// CheckIfFollowing tells whether the user follows the followed user
+func (u *userGrpcClient) CheckIfFollowing(request dtos.CheckIfFollowingRequest) (bool, error) {
+	conn, err := grpc.Dial(u.address, grpc.WithInsecure())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := postsproto.NewUserRelationServiceClient(conn)
+
+	response, err := client.CheckIfFollowing(ctx,
+		&postsproto.CheckIfFollowingRequest{
+			UserEmail:     request.User,
+			FollowedEmail: request.FollowedUser,
+		},
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	return response.Following, nil
+}
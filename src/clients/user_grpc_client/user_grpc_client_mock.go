@@ -0,0 +1,90 @@
+package user_grpc_client
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserGrpcClientMock struct {
+	mock.Mock
+}
+
+func (u *UserGrpcClientMock) GetUsername(request dtos.GetUsernameRequest) (string, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.String(0), nil
+	}
+	return "", args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) CheckPostIsInFavorites(request dtos.CheckFavoritesRequest) (bool, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) CheckIfUserIsTaggable(request dtos.CheckTaggableRequest) (bool, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) GetFollowingUsers(request dtos.GetFollowingUsersRequest) ([]string, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Get(0).([]string), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) CheckIfUserIsBlocked(request dtos.CheckIfUserIsBlockedRequest) (bool, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) GetFollowers(request dtos.GetFollowersRequest) ([]string, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Get(0).([]string), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) CheckIfProfileIsPrivate(request dtos.CheckIfProfileIsPrivateRequest) (bool, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) GetUsernames(request dtos.GetUsernamesRequest) (map[string]string, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[string]string), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) GetBlockedUsers(request dtos.GetBlockedUsersRequest) ([]string, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Get(0).([]string), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (u *UserGrpcClientMock) CheckIfFollowing(request dtos.CheckIfFollowingRequest) (bool, error) {
+	args := u.Called(request)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(error)
+}
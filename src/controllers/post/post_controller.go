@@ -1,11 +1,17 @@
 package post
 
 import (
+	"fmt"
 	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/Nistagram-Organization/nistagram-posts/src/insights"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
 	"github.com/Nistagram-Organization/nistagram-posts/src/services/post"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/comment"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 	"strconv"
 )
@@ -21,8 +27,60 @@ type PostController interface {
 	CreatePost(*gin.Context)
 	GetUsersPosts(ctx *gin.Context)
 	GetInappropriateContent(*gin.Context)
+	GetModerationQueue(*gin.Context)
+	DecideOnContentBulk(*gin.Context)
 	GetPostsFeed(*gin.Context)
+	GetExplore(*gin.Context)
+	GetTrending(*gin.Context)
+	GetSimilarPosts(*gin.Context)
+	RecordImpressions(*gin.Context)
+	GetPostInsights(*gin.Context)
+	GetInsightsSummary(*gin.Context)
+	CreateCampaign(*gin.Context)
+	GetCampaigns(*gin.Context)
+	GetScheduledPosts(*gin.Context)
+	ReschedulePost(*gin.Context)
+	CancelScheduledPost(*gin.Context)
+	CreateDraft(*gin.Context)
+	GetDrafts(*gin.Context)
+	GetDraft(*gin.Context)
+	UpdateDraft(*gin.Context)
+	DeleteDraft(*gin.Context)
+	PublishDraft(*gin.Context)
+	CreateStory(*gin.Context)
+	GetStoriesTray(*gin.Context)
+	ViewStory(*gin.Context)
+	GetStoryViewers(*gin.Context)
+	ArchiveStory(*gin.Context)
+	GetArchivedStories(*gin.Context)
+	CreateHighlight(*gin.Context)
+	GetHighlights(*gin.Context)
+	UpdateHighlight(*gin.Context)
+	DeleteHighlight(*gin.Context)
+	SetAudience(*gin.Context)
+	GetCloseFriends(*gin.Context)
+	AddCloseFriend(*gin.Context)
+	RemoveCloseFriend(*gin.Context)
+	GetMutedUsers(*gin.Context)
+	MuteUser(*gin.Context)
+	UnmuteUser(*gin.Context)
+	ArchivePost(*gin.Context)
+	UnarchivePost(*gin.Context)
+	GetArchivedPosts(*gin.Context)
+	PinPost(*gin.Context)
+	UnpinPost(*gin.Context)
+	SetCommentPolicy(*gin.Context)
 	SearchTags(*gin.Context)
+	GetAuthorRestrictions(*gin.Context)
+	RestrictAuthor(*gin.Context)
+	RemoveAuthorRestriction(*gin.Context)
+	SetContentWarning(*gin.Context)
+	SetContentWarningAsModerator(*gin.Context)
+	GetPostMedia(*gin.Context)
+	GetQuarantinedComments(*gin.Context)
+	GetAllQuarantinedComments(*gin.Context)
+	ReviewQuarantinedComment(*gin.Context)
+	ReviewQuarantinedCommentAsModerator(*gin.Context)
 }
 
 type postsController struct {
@@ -43,6 +101,26 @@ func getId(idParam string) (uint, rest_error.RestErr) {
 	return uint(id), nil
 }
 
+func getIntQuery(ctx *gin.Context, key string) (int64, rest_error.RestErr) {
+	value := ctx.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		return 0, rest_error.NewBadRequestError(key + " should be a non-negative number")
+	}
+	return number, nil
+}
+
+func getSensitiveContentPreference(ctx *gin.Context) (dtos.SensitiveContentPreference, rest_error.RestErr) {
+	preference, ok := dtos.ParseSensitiveContentPreference(ctx.Query("sensitive"))
+	if !ok {
+		return "", rest_error.NewBadRequestError("sensitive should be blur or exclude")
+	}
+	return preference, nil
+}
+
 func (p *postsController) LikePost(ctx *gin.Context) {
 	var likeRequest dtos.LikeDislikeRequestDTO
 	if err := ctx.ShouldBindJSON(&likeRequest); err != nil {
@@ -116,7 +194,16 @@ func (p *postsController) ReportInappropriateContent(ctx *gin.Context) {
 		return
 	}
 
-	reportErr := p.postsService.ReportInappropriateContent(postId)
+	var reportRequest dtos.ReportRequestDTO
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&reportRequest); err != nil {
+			restErr := rest_error.NewBadRequestError("invalid json body")
+			ctx.JSON(restErr.Status(), restErr)
+			return
+		}
+	}
+
+	reportErr := p.postsService.ReportInappropriateContent(postId, reportRequest.Reason)
 	if reportErr != nil {
 		ctx.JSON(reportErr.Status(), reportErr)
 		return
@@ -180,7 +267,39 @@ func (p *postsController) GetPostsFeed(ctx *gin.Context) {
 	var postsDTOs []dtos.PostDTO
 	var getErr rest_error.RestErr
 
-	postsDTOs, getErr = p.postsService.GetPostsFeed(ctx.Query("user"))
+	sensitiveContent, getErr := getSensitiveContentPreference(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	mode, ok := dtos.ParseFeedMode(ctx.Query("mode"))
+	if !ok {
+		restErr := rest_error.NewBadRequestError("mode should be ranked or chronological")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	postsDTOs, getErr = p.postsService.GetPostsFeed(ctx.Query("user"), sensitiveContent, mode)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, postsDTOs)
+}
+
+func (p *postsController) GetExplore(ctx *gin.Context) {
+	var postsDTOs []dtos.PostDTO
+	var getErr rest_error.RestErr
+
+	sensitiveContent, getErr := getSensitiveContentPreference(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	postsDTOs, getErr = p.postsService.GetExplore(ctx.Query("user"), sensitiveContent)
 	if getErr != nil {
 		ctx.JSON(getErr.Status(), getErr)
 		return
@@ -193,7 +312,13 @@ func (p *postsController) SearchTags(ctx *gin.Context) {
 	var postsDTOs []dtos.PostDTO
 	var getErr rest_error.RestErr
 
-	postsDTOs, getErr = p.postsService.SearchTags(ctx.Query("tag"), ctx.Query("user"))
+	sensitiveContent, getErr := getSensitiveContentPreference(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	postsDTOs, getErr = p.postsService.SearchTags(ctx.Query("tag"), ctx.Query("user"), sensitiveContent)
 	if getErr != nil {
 		ctx.JSON(getErr.Status(), getErr)
 		return
@@ -205,3 +330,845 @@ func (p *postsController) SearchTags(ctx *gin.Context) {
 func (p *postsController) GetInappropriateContent(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, p.postsService.GetInappropriateContent())
 }
+
+func getModerationQueueFilter(ctx *gin.Context) (dtos.ModerationQueueFilter, rest_error.RestErr) {
+	filter := dtos.ModerationQueueFilter{
+		Author: ctx.Query("author"),
+		Reason: ctx.Query("reason"),
+	}
+
+	var page, size int64
+	var queryErr rest_error.RestErr
+	if filter.MinReports, queryErr = getIntQuery(ctx, "min_reports"); queryErr != nil {
+		return filter, queryErr
+	}
+	if filter.MinAge, queryErr = getIntQuery(ctx, "min_age"); queryErr != nil {
+		return filter, queryErr
+	}
+	if filter.MaxAge, queryErr = getIntQuery(ctx, "max_age"); queryErr != nil {
+		return filter, queryErr
+	}
+	if page, queryErr = getIntQuery(ctx, "page"); queryErr != nil {
+		return filter, queryErr
+	}
+	if size, queryErr = getIntQuery(ctx, "size"); queryErr != nil {
+		return filter, queryErr
+	}
+	filter.Page = int(page)
+	filter.Size = int(size)
+
+	return filter, nil
+}
+
+func (p *postsController) GetModerationQueue(ctx *gin.Context) {
+	filter, filterErr := getModerationQueueFilter(ctx)
+	if filterErr != nil {
+		ctx.JSON(filterErr.Status(), filterErr)
+		return
+	}
+
+	queue, getErr := p.postsService.GetModerationQueue(filter)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, queue)
+}
+
+func (p *postsController) DecideOnContentBulk(ctx *gin.Context) {
+	var decisionsRequest dtos.ModerationDecisionsRequestDTO
+	if err := ctx.ShouldBindJSON(&decisionsRequest); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	results, decideErr := p.postsService.DecideOnContentBulk(decisionsRequest.Decisions)
+	if decideErr != nil {
+		ctx.JSON(decideErr.Status(), decideErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (p *postsController) GetAuthorRestrictions(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, p.postsService.GetAuthorRestrictions())
+}
+
+func (p *postsController) RestrictAuthor(ctx *gin.Context) {
+	var restriction author_restriction.AuthorRestriction
+	if err := ctx.ShouldBindJSON(&restriction); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	restrictErr := p.postsService.RestrictAuthor(&restriction)
+	if restrictErr != nil {
+		ctx.JSON(restrictErr.Status(), restrictErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, restrictErr)
+}
+
+func (p *postsController) RemoveAuthorRestriction(ctx *gin.Context) {
+	removeErr := p.postsService.RemoveAuthorRestriction(ctx.Query("user_email"))
+	if removeErr != nil {
+		ctx.JSON(removeErr.Status(), removeErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, removeErr)
+}
+
+func (p *postsController) setContentWarning(ctx *gin.Context, moderator bool) {
+	var contentWarningRequest dtos.ContentWarningRequestDTO
+	if err := ctx.ShouldBindJSON(&contentWarningRequest); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	warningErr := p.postsService.SetContentWarning(&contentWarningRequest, moderator)
+	if warningErr != nil {
+		ctx.JSON(warningErr.Status(), warningErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, warningErr)
+}
+
+func (p *postsController) SetContentWarning(ctx *gin.Context) {
+	p.setContentWarning(ctx, false)
+}
+
+func (p *postsController) SetContentWarningAsModerator(ctx *gin.Context) {
+	p.setContentWarning(ctx, true)
+}
+
+func (p *postsController) GetPostMedia(ctx *gin.Context) {
+	postId, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	media, getErr := p.postsService.GetPostMedia(postId, ctx.Query("logged_in_user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, media)
+}
+
+func (p *postsController) getQuarantinedComments(ctx *gin.Context, moderator bool) {
+	quarantined, getErr := p.postsService.GetQuarantinedComments(ctx.Query("user"), moderator)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quarantined)
+}
+
+func (p *postsController) GetQuarantinedComments(ctx *gin.Context) {
+	p.getQuarantinedComments(ctx, false)
+}
+
+func (p *postsController) GetAllQuarantinedComments(ctx *gin.Context) {
+	p.getQuarantinedComments(ctx, true)
+}
+
+func (p *postsController) reviewQuarantinedComment(ctx *gin.Context, moderator bool) {
+	var decision dtos.CommentReviewDecisionDTO
+	if err := ctx.ShouldBindJSON(&decision); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	reviewErr := p.postsService.ReviewQuarantinedComment(&decision, moderator)
+	if reviewErr != nil {
+		ctx.JSON(reviewErr.Status(), reviewErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reviewErr)
+}
+
+func (p *postsController) ReviewQuarantinedComment(ctx *gin.Context) {
+	p.reviewQuarantinedComment(ctx, false)
+}
+
+func (p *postsController) ReviewQuarantinedCommentAsModerator(ctx *gin.Context) {
+	p.reviewQuarantinedComment(ctx, true)
+}
+
+func (p *postsController) GetTrending(ctx *gin.Context) {
+	window, ok := dtos.ParseTrendingWindow(ctx.Query("window"))
+	if !ok {
+		restErr := rest_error.NewBadRequestError("window should be 1h, 24h or 7d")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, p.postsService.GetTrending(window))
+}
+
+func (p *postsController) GetSimilarPosts(ctx *gin.Context) {
+	postId, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	sensitiveContent, getErr := getSensitiveContentPreference(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	postsDTOs, getErr := p.postsService.GetSimilarPosts(postId, ctx.Query("logged_in_user"), sensitiveContent)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, postsDTOs)
+}
+
+func (p *postsController) RecordImpressions(ctx *gin.Context) {
+	var impressionsRequest dtos.ImpressionsRequestDTO
+	if err := ctx.ShouldBindJSON(&impressionsRequest); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	result, recordErr := p.postsService.RecordImpressions(&impressionsRequest)
+	if recordErr != nil {
+		ctx.JSON(recordErr.Status(), recordErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func getInsightsFilter(ctx *gin.Context) (dtos.InsightsFilter, rest_error.RestErr) {
+	from, err := getIntQuery(ctx, "from")
+	if err != nil {
+		return dtos.InsightsFilter{}, err
+	}
+
+	to, err := getIntQuery(ctx, "to")
+	if err != nil {
+		return dtos.InsightsFilter{}, err
+	}
+
+	bucket, ok := dtos.ParseInsightsBucket(ctx.Query("bucket"))
+	if !ok {
+		return dtos.InsightsFilter{}, rest_error.NewBadRequestError("bucket should be day or week")
+	}
+
+	return dtos.InsightsFilter{
+		From:   from,
+		To:     to,
+		Bucket: bucket,
+	}, nil
+}
+
+func getCSVFormat(ctx *gin.Context) (bool, rest_error.RestErr) {
+	switch ctx.Query("format") {
+	case "", "json":
+		return false, nil
+	case "csv":
+		return true, nil
+	default:
+		return false, rest_error.NewBadRequestError("format should be json or csv")
+	}
+}
+
+func writeCSV(ctx *gin.Context, filename string, write func(io.Writer) error) {
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Status(http.StatusOK)
+
+	if err := write(ctx.Writer); err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
+func (p *postsController) GetPostInsights(ctx *gin.Context) {
+	postId, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	filter, getErr := getInsightsFilter(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	csvFormat, getErr := getCSVFormat(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	postInsights, getErr := p.postsService.GetPostInsights(postId, ctx.Query("user"), filter)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	if csvFormat {
+		writeCSV(ctx, fmt.Sprintf("post-%d-insights.csv", postId), func(w io.Writer) error {
+			return insights.WritePostInsightsCSV(w, postInsights)
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, postInsights)
+}
+
+func (p *postsController) GetInsightsSummary(ctx *gin.Context) {
+	filter, getErr := getInsightsFilter(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	csvFormat, getErr := getCSVFormat(ctx)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	summary, getErr := p.postsService.GetInsightsSummary(ctx.Query("user"), filter)
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	if csvFormat {
+		writeCSV(ctx, "insights-summary.csv", func(w io.Writer) error {
+			return insights.WriteSummaryCSV(w, summary)
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (p *postsController) CreateCampaign(ctx *gin.Context) {
+	var createCampaignDTO dtos.CreateCampaignDTO
+	if err := ctx.ShouldBindJSON(&createCampaignDTO); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	createErr := p.postsService.CreateCampaign(&createCampaignDTO)
+	if createErr != nil {
+		ctx.JSON(createErr.Status(), createErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createErr)
+}
+
+func (p *postsController) GetCampaigns(ctx *gin.Context) {
+	campaigns, getErr := p.postsService.GetCampaigns(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, campaigns)
+}
+
+func (p *postsController) GetScheduledPosts(ctx *gin.Context) {
+	scheduledPosts, getErr := p.postsService.GetScheduledPosts(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scheduledPosts)
+}
+
+func (p *postsController) ReschedulePost(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	var rescheduleRequest dtos.ReschedulePostDTO
+	if err := ctx.ShouldBindJSON(&rescheduleRequest); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	rescheduleErr := p.postsService.ReschedulePost(id, &rescheduleRequest)
+	if rescheduleErr != nil {
+		ctx.JSON(rescheduleErr.Status(), rescheduleErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rescheduleErr)
+}
+
+func (p *postsController) CancelScheduledPost(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	cancelErr := p.postsService.CancelScheduledPost(id, ctx.Query("user"))
+	if cancelErr != nil {
+		ctx.JSON(cancelErr.Status(), cancelErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cancelErr)
+}
+
+func (p *postsController) CreateDraft(ctx *gin.Context) {
+	var draftDTO dtos.CreatePostDTO
+	if err := ctx.ShouldBindJSON(&draftDTO); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	draft, createErr := p.postsService.CreateDraft(&draftDTO)
+	if createErr != nil {
+		ctx.JSON(createErr.Status(), createErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+func (p *postsController) GetDrafts(ctx *gin.Context) {
+	drafts, getErr := p.postsService.GetDrafts(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, drafts)
+}
+
+func (p *postsController) GetDraft(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	draft, getErr := p.postsService.GetDraft(id, ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+func (p *postsController) UpdateDraft(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	var draftDTO dtos.CreatePostDTO
+	if err := ctx.ShouldBindJSON(&draftDTO); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	draft, updateErr := p.postsService.UpdateDraft(id, &draftDTO)
+	if updateErr != nil {
+		ctx.JSON(updateErr.Status(), updateErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+func (p *postsController) DeleteDraft(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	deleteErr := p.postsService.DeleteDraft(id, ctx.Query("user"))
+	if deleteErr != nil {
+		ctx.JSON(deleteErr.Status(), deleteErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deleteErr)
+}
+
+func (p *postsController) PublishDraft(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	publishErr := p.postsService.PublishDraft(id, ctx.Query("user"))
+	if publishErr != nil {
+		ctx.JSON(publishErr.Status(), publishErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, publishErr)
+}
+
+func (p *postsController) CreateStory(ctx *gin.Context) {
+	var storyDTO dtos.CreateStoryDTO
+	if err := ctx.ShouldBindJSON(&storyDTO); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	createErr := p.postsService.CreateStory(&storyDTO)
+	if createErr != nil {
+		ctx.JSON(createErr.Status(), createErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createErr)
+}
+
+func (p *postsController) GetStoriesTray(ctx *gin.Context) {
+	tray, getErr := p.postsService.GetStoriesTray(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tray)
+}
+
+func (p *postsController) ViewStory(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	viewErr := p.postsService.ViewStory(id, ctx.Query("user"))
+	if viewErr != nil {
+		ctx.JSON(viewErr.Status(), viewErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, viewErr)
+}
+
+func (p *postsController) GetStoryViewers(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	viewers, getErr := p.postsService.GetStoryViewers(id, ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, viewers)
+}
+
+func (p *postsController) ArchiveStory(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	archiveErr := p.postsService.ArchiveStory(id, ctx.Query("user"))
+	if archiveErr != nil {
+		ctx.JSON(archiveErr.Status(), archiveErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, archiveErr)
+}
+
+func (p *postsController) GetArchivedStories(ctx *gin.Context) {
+	stories, getErr := p.postsService.GetArchivedStories(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stories)
+}
+
+func (p *postsController) CreateHighlight(ctx *gin.Context) {
+	var highlightDTO dtos.HighlightRequestDTO
+	if err := ctx.ShouldBindJSON(&highlightDTO); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	highlight, createErr := p.postsService.CreateHighlight(&highlightDTO)
+	if createErr != nil {
+		ctx.JSON(createErr.Status(), createErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, highlight)
+}
+
+func (p *postsController) GetHighlights(ctx *gin.Context) {
+	highlights, getErr := p.postsService.GetHighlights(ctx.Query("author"), ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, highlights)
+}
+
+func (p *postsController) UpdateHighlight(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	var highlightDTO dtos.HighlightRequestDTO
+	if err := ctx.ShouldBindJSON(&highlightDTO); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	highlight, updateErr := p.postsService.UpdateHighlight(id, &highlightDTO)
+	if updateErr != nil {
+		ctx.JSON(updateErr.Status(), updateErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, highlight)
+}
+
+func (p *postsController) DeleteHighlight(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	deleteErr := p.postsService.DeleteHighlight(id, ctx.Query("user"))
+	if deleteErr != nil {
+		ctx.JSON(deleteErr.Status(), deleteErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deleteErr)
+}
+
+func (p *postsController) SetAudience(ctx *gin.Context) {
+	var audienceRequest dtos.AudienceRequestDTO
+	if err := ctx.ShouldBindJSON(&audienceRequest); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	audienceErr := p.postsService.SetAudience(&audienceRequest)
+	if audienceErr != nil {
+		ctx.JSON(audienceErr.Status(), audienceErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, audienceErr)
+}
+
+func (p *postsController) GetCloseFriends(ctx *gin.Context) {
+	closeFriends, getErr := p.postsService.GetCloseFriends(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, closeFriends)
+}
+
+func (p *postsController) AddCloseFriend(ctx *gin.Context) {
+	var closeFriend close_friend.CloseFriend
+	if err := ctx.ShouldBindJSON(&closeFriend); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	addErr := p.postsService.AddCloseFriend(&closeFriend)
+	if addErr != nil {
+		ctx.JSON(addErr.Status(), addErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, addErr)
+}
+
+func (p *postsController) RemoveCloseFriend(ctx *gin.Context) {
+	removeErr := p.postsService.RemoveCloseFriend(ctx.Query("user"), ctx.Query("friend"))
+	if removeErr != nil {
+		ctx.JSON(removeErr.Status(), removeErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, removeErr)
+}
+
+func (p *postsController) GetMutedUsers(ctx *gin.Context) {
+	mutes, getErr := p.postsService.GetMutedUsers(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mutes)
+}
+
+func (p *postsController) MuteUser(ctx *gin.Context) {
+	var muteEntity mute.Mute
+	if err := ctx.ShouldBindJSON(&muteEntity); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	muteErr := p.postsService.MuteUser(&muteEntity)
+	if muteErr != nil {
+		ctx.JSON(muteErr.Status(), muteErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, muteErr)
+}
+
+func (p *postsController) UnmuteUser(ctx *gin.Context) {
+	unmuteErr := p.postsService.UnmuteUser(ctx.Query("user"), ctx.Query("muted"))
+	if unmuteErr != nil {
+		ctx.JSON(unmuteErr.Status(), unmuteErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, unmuteErr)
+}
+
+func (p *postsController) ArchivePost(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	archiveErr := p.postsService.ArchivePost(id, ctx.Query("user"))
+	if archiveErr != nil {
+		ctx.JSON(archiveErr.Status(), archiveErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, archiveErr)
+}
+
+func (p *postsController) UnarchivePost(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	unarchiveErr := p.postsService.UnarchivePost(id, ctx.Query("user"))
+	if unarchiveErr != nil {
+		ctx.JSON(unarchiveErr.Status(), unarchiveErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, unarchiveErr)
+}
+
+func (p *postsController) GetArchivedPosts(ctx *gin.Context) {
+	posts, getErr := p.postsService.GetArchivedPosts(ctx.Query("user"))
+	if getErr != nil {
+		ctx.JSON(getErr.Status(), getErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, posts)
+}
+
+func (p *postsController) PinPost(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	pinErr := p.postsService.PinPost(id, ctx.Query("user"))
+	if pinErr != nil {
+		ctx.JSON(pinErr.Status(), pinErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pinErr)
+}
+
+func (p *postsController) UnpinPost(ctx *gin.Context) {
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		ctx.JSON(idErr.Status(), idErr)
+		return
+	}
+
+	unpinErr := p.postsService.UnpinPost(id, ctx.Query("user"))
+	if unpinErr != nil {
+		ctx.JSON(unpinErr.Status(), unpinErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, unpinErr)
+}
+
+func (p *postsController) SetCommentPolicy(ctx *gin.Context) {
+	var commentPolicyRequest dtos.CommentPolicyRequestDTO
+	if err := ctx.ShouldBindJSON(&commentPolicyRequest); err != nil {
+		restErr := rest_error.NewBadRequestError("invalid json body")
+		ctx.JSON(restErr.Status(), restErr)
+		return
+	}
+
+	policyErr := p.postsService.SetCommentPolicy(&commentPolicyRequest)
+	if policyErr != nil {
+		ctx.JSON(policyErr.Status(), policyErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policyErr)
+}
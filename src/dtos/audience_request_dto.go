@@ -0,0 +1,7 @@
+package dtos
+
+type AudienceRequestDTO struct {
+	PostID    uint   `json:"post_id"`
+	UserEmail string `json:"user_email"`
+	Audience  string `json:"audience"`
+}
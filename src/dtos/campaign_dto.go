@@ -0,0 +1,18 @@
+package dtos
+
+type CampaignDTO struct {
+	ID           uint     `json:"id"`
+	PostID       uint     `json:"post_id"`
+	StartDate    int64    `json:"start_date"`
+	EndDate      int64    `json:"end_date"`
+	Audience     string   `json:"audience"`
+	Interests    []string `json:"interests"`
+	FrequencyCap int      `json:"frequency_cap"`
+	Active       bool     `json:"active"`
+	// Total number of times the post was injected into feeds and number of distinct users reached
+	Deliveries  int64 `json:"deliveries"`
+	Reach       int64 `json:"reach"`
+	Likes       int64 `json:"likes"`
+	Comments    int64 `json:"comments"`
+	Impressions int64 `json:"impressions"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type CheckIfFollowingRequest struct {
+	User         string
+	FollowedUser string
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type CheckIfProfileIsPrivateRequest struct {
+	UserEmail string
+}
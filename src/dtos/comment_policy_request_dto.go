@@ -0,0 +1,7 @@
+package dtos
+
+type CommentPolicyRequestDTO struct {
+	PostID        uint   `json:"post_id"`
+	UserEmail     string `json:"user_email"`
+	CommentPolicy string `json:"comment_policy"`
+}
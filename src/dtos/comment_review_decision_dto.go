@@ -0,0 +1,7 @@
+package dtos
+
+type CommentReviewDecisionDTO struct {
+	CommentID uint   `json:"comment_id"`
+	UserEmail string `json:"user_email"`
+	Approve   bool   `json:"approve"`
+}
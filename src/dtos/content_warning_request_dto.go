@@ -0,0 +1,7 @@
+package dtos
+
+type ContentWarningRequestDTO struct {
+	PostID         uint   `json:"post_id"`
+	UserEmail      string `json:"user_email"`
+	ContentWarning string `json:"content_warning"`
+}
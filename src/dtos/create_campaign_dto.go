@@ -0,0 +1,10 @@
+package dtos
+
+type CreateCampaignDTO struct {
+	Post         CreatePostDTO `json:"post"`
+	StartDate    int64         `json:"start_date"`
+	EndDate      int64         `json:"end_date"`
+	Audience     string        `json:"audience"`
+	Interests    []string      `json:"interests"`
+	FrequencyCap int           `json:"frequency_cap"`
+}
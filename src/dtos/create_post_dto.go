@@ -3,5 +3,24 @@ package dtos
 type CreatePostDTO struct {
 	Description string
 	Image       string
-	UserEmail   string
+	// Ordered images of a carousel post, Image is used when there are none
+	Images    []string `json:"images"`
+	UserEmail string
+	// Image or video, all images are of this type and image is used when empty
+	MediaType string `json:"media_type"`
+	// Optional content warning category
+	ContentWarning string
+	// Public, followers or close friends, public is used when empty
+	Audience string `json:"audience"`
+	// Everyone, followers or nobody, everyone can comment when empty
+	CommentPolicy string `json:"comment_policy"`
+	// Optional unix time in the future at which the post is published
+	PublishAt int64 `json:"publish_at"`
+}
+
+func (p *CreatePostDTO) GetImages() []string {
+	if len(p.Images) == 0 && p.Image != "" {
+		return []string{p.Image}
+	}
+	return p.Images
 }
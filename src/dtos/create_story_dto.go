@@ -0,0 +1,8 @@
+package dtos
+
+type CreateStoryDTO struct {
+	Image string `json:"image"`
+	// Image or video, image is used when empty
+	MediaType string `json:"media_type"`
+	UserEmail string `json:"user_email"`
+}
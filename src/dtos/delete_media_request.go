@@ -0,0 +1,5 @@
+package dtos
+
+type DeleteMediaRequest struct {
+	ID uint64
+}
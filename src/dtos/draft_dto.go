@@ -0,0 +1,13 @@
+package dtos
+
+type DraftDTO struct {
+	ID             uint   `json:"id"`
+	Description    string `json:"description"`
+	Image          string `json:"image"`
+	MediaType      string `json:"media_type"`
+	ContentWarning string `json:"content_warning"`
+	Audience       string `json:"audience"`
+	CommentPolicy  string `json:"comment_policy"`
+	Date           int64  `json:"date"`
+	Updated        int64  `json:"updated"`
+}
@@ -0,0 +1,19 @@
+package dtos
+
+type FeedMode string
+
+const (
+	FeedModeRanked        FeedMode = "ranked"
+	FeedModeChronological FeedMode = "chronological"
+)
+
+func ParseFeedMode(value string) (FeedMode, bool) {
+	switch FeedMode(value) {
+	case "", FeedModeRanked:
+		return FeedModeRanked, true
+	case FeedModeChronological:
+		return FeedModeChronological, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type GetBlockedUsersRequest struct {
+	UserEmail string
+}
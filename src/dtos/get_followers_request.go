@@ -0,0 +1,5 @@
+package dtos
+
+type GetFollowersRequest struct {
+	UserEmail string
+}
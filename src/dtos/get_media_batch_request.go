@@ -0,0 +1,5 @@
+package dtos
+
+type GetMediaBatchRequest struct {
+	IDs []uint64
+}
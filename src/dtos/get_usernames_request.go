@@ -0,0 +1,5 @@
+package dtos
+
+type GetUsernamesRequest struct {
+	Emails []string
+}
@@ -0,0 +1,15 @@
+package dtos
+
+type HighlightRequestDTO struct {
+	UserEmail string `json:"user_email"`
+	Name      string `json:"name"`
+	// Stories in the order they are shown, they are archived when added
+	StoryIDs []uint `json:"story_ids"`
+}
+
+type HighlightDTO struct {
+	ID      uint       `json:"id"`
+	Name    string     `json:"name"`
+	Stories []StoryDTO `json:"stories"`
+	Date    int64      `json:"date"`
+}
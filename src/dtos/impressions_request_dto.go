@@ -0,0 +1,6 @@
+package dtos
+
+type ImpressionsRequestDTO struct {
+	UserEmail string `json:"user_email"`
+	PostIDs   []uint `json:"post_ids"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type ImpressionsResultDTO struct {
+	// Number of impressions not recorded before for the viewer on the same day
+	Recorded int64 `json:"recorded"`
+}
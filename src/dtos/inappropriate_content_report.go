@@ -1,8 +1,11 @@
 package dtos
 
 type InappropriateContentReportDTO struct {
-	AuthorEmail string `json:"author_email"`
-	Description string `json:"description"`
-	Image       string `json:"image"`
-	PostID      uint   `json:"post_id"`
+	AuthorEmail string   `json:"author_email"`
+	Description string   `json:"description"`
+	Image       string   `json:"image"`
+	PostID      uint     `json:"post_id"`
+	Date        int64    `json:"date"`
+	Reports     int64    `json:"reports"`
+	Reasons     []string `json:"reasons"`
 }
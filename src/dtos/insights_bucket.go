@@ -0,0 +1,27 @@
+package dtos
+
+type InsightsBucket string
+
+const (
+	InsightsBucketDay  InsightsBucket = "day"
+	InsightsBucketWeek InsightsBucket = "week"
+)
+
+func ParseInsightsBucket(value string) (InsightsBucket, bool) {
+	switch InsightsBucket(value) {
+	case "", InsightsBucketDay:
+		return InsightsBucketDay, true
+	case InsightsBucketWeek:
+		return InsightsBucketWeek, true
+	default:
+		return "", false
+	}
+}
+
+// Seconds returns length of the bucket in seconds
+func (b InsightsBucket) Seconds() int64 {
+	if b == InsightsBucketWeek {
+		return 7 * 24 * 3600
+	}
+	return 24 * 3600
+}
@@ -0,0 +1,44 @@
+package dtos
+
+type InsightsCountsDTO struct {
+	Likes       int64 `json:"likes"`
+	Dislikes    int64 `json:"dislikes"`
+	Comments    int64 `json:"comments"`
+	Impressions int64 `json:"impressions"`
+}
+
+type InsightsBucketDTO struct {
+	Start int64 `json:"start"`
+	InsightsCountsDTO
+}
+
+// InsightsAudienceDTO splits counts between author's followers and everyone else
+type InsightsAudienceDTO struct {
+	Followers    InsightsCountsDTO `json:"followers"`
+	NonFollowers InsightsCountsDTO `json:"non_followers"`
+}
+
+type PostInsightsDTO struct {
+	PostID   uint                `json:"post_id"`
+	From     int64               `json:"from"`
+	To       int64               `json:"to"`
+	Bucket   InsightsBucket      `json:"bucket"`
+	Buckets  []InsightsBucketDTO `json:"buckets"`
+	Total    InsightsCountsDTO   `json:"total"`
+	Audience InsightsAudienceDTO `json:"audience"`
+}
+
+type PostInsightsSummaryDTO struct {
+	PostID uint  `json:"post_id"`
+	Date   int64 `json:"date"`
+	InsightsCountsDTO
+}
+
+type InsightsSummaryDTO struct {
+	UserEmail string                   `json:"user_email"`
+	From      int64                    `json:"from"`
+	To        int64                    `json:"to"`
+	Posts     []PostInsightsSummaryDTO `json:"posts"`
+	Total     InsightsCountsDTO        `json:"total"`
+	Audience  InsightsAudienceDTO      `json:"audience"`
+}
@@ -0,0 +1,8 @@
+package dtos
+
+type InsightsFilter struct {
+	// Unix time period [From, To), zero values fall back to defaults
+	From   int64
+	To     int64
+	Bucket InsightsBucket
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type ModerationDecisionDTO struct {
+	PostID uint `json:"post_id"`
+	Delete bool `json:"delete"`
+}
+
+type ModerationDecisionsRequestDTO struct {
+	Decisions []ModerationDecisionDTO `json:"decisions"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+type ModerationDecisionResultDTO struct {
+	PostID  uint   `json:"post_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
@@ -0,0 +1,8 @@
+package dtos
+
+type ModerationQueueDTO struct {
+	Items []InappropriateContentReportDTO `json:"items"`
+	Page  int                             `json:"page"`
+	Size  int                             `json:"size"`
+	Total int64                           `json:"total"`
+}
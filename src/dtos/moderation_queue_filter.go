@@ -0,0 +1,12 @@
+package dtos
+
+type ModerationQueueFilter struct {
+	Author     string
+	Reason     string
+	MinReports int64
+	// Post age bounds in hours, zero means unbounded
+	MinAge int64
+	MaxAge int64
+	Page   int
+	Size   int
+}
@@ -0,0 +1,7 @@
+package dtos
+
+type MutedUserDTO struct {
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Date     int64  `json:"date"`
+}
@@ -6,11 +6,26 @@ type PostDTO struct {
 	Date        string `json:"date"`
 	Timestamp   int64  `json:"timestamp"`
 	Image       string `json:"image"`
-	Username    string `json:"username"`
-	Liked       bool   `json:"liked"`
-	Disliked    bool   `json:"disliked"`
-	InFavorites bool   `json:"in_favorites"`
-	Likes       uint   `json:"likes"`
-	Dislikes    uint   `json:"dislikes"`
+	// All images of the post in order, Image is the first one
+	Media       []string `json:"media"`
+	MediaType   string   `json:"media_type"`
+	Username    string   `json:"username"`
+	Liked       bool     `json:"liked"`
+	Disliked    bool     `json:"disliked"`
+	InFavorites bool     `json:"in_favorites"`
+	Likes       uint     `json:"likes"`
+	Dislikes    uint     `json:"dislikes"`
 	Comments    []CommentDTO
+	// Image is left out of blurred posts until requested explicitly
+	ContentWarning string `json:"content_warning"`
+	Blurred        bool   `json:"blurred"`
+	Audience       string `json:"audience"`
+	CommentPolicy  string `json:"comment_policy"`
+	Archived       bool   `json:"archived"`
+	// Post is pinned to the top of author's profile
+	Pinned bool `json:"pinned"`
+	// Number of distinct daily viewers, only shown to the author
+	Views *int64 `json:"views,omitempty"`
+	// Post is promoted by an agent's campaign
+	Sponsored bool `json:"sponsored"`
 }
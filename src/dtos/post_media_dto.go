@@ -0,0 +1,7 @@
+package dtos
+
+type PostMediaDTO struct {
+	PostID uint     `json:"post_id"`
+	Image  string   `json:"image"`
+	Media  []string `json:"media"`
+}
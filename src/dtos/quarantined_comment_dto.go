@@ -0,0 +1,11 @@
+package dtos
+
+type QuarantinedCommentDTO struct {
+	CommentID uint     `json:"comment_id"`
+	PostID    uint     `json:"post_id"`
+	Text      string   `json:"text"`
+	UserEmail string   `json:"user_email"`
+	Date      int64    `json:"date"`
+	Score     float64  `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type ReportRequestDTO struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type ReschedulePostDTO struct {
+	UserEmail string `json:"user_email"`
+	PublishAt int64  `json:"publish_at"`
+}
@@ -5,6 +5,7 @@ import (
 )
 
 type SaveMediaRequest struct {
+	// Base64 content of an image or a video
 	Image string
 }
 
@@ -0,0 +1,14 @@
+package dtos
+
+type ScheduledPostDTO struct {
+	ID             uint     `json:"id"`
+	Description    string   `json:"description"`
+	Image          string   `json:"image"`
+	Media          []string `json:"media"`
+	MediaType      string   `json:"media_type"`
+	ContentWarning string   `json:"content_warning"`
+	Audience       string   `json:"audience"`
+	CommentPolicy  string   `json:"comment_policy"`
+	PublishAt      int64    `json:"publish_at"`
+	Date           int64    `json:"date"`
+}
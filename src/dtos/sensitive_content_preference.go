@@ -0,0 +1,21 @@
+package dtos
+
+type SensitiveContentPreference string
+
+const (
+	// Posts with a content warning are returned without image until it is revealed
+	SensitiveContentBlur SensitiveContentPreference = "blur"
+	// Posts with a content warning are left out
+	SensitiveContentExclude SensitiveContentPreference = "exclude"
+)
+
+func ParseSensitiveContentPreference(value string) (SensitiveContentPreference, bool) {
+	switch SensitiveContentPreference(value) {
+	case "", SensitiveContentBlur:
+		return SensitiveContentBlur, true
+	case SensitiveContentExclude:
+		return SensitiveContentExclude, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,24 @@
+package dtos
+
+type StoryDTO struct {
+	ID        uint   `json:"id"`
+	Image     string `json:"image"`
+	MediaType string `json:"media_type"`
+	Date      int64  `json:"date"`
+	ExpiresAt int64  `json:"expires_at"`
+	// Viewer has already seen the story
+	Seen bool `json:"seen"`
+}
+
+// StoryTrayItemDTO groups active stories of an author, oldest first
+type StoryTrayItemDTO struct {
+	Username string     `json:"username"`
+	Stories  []StoryDTO `json:"stories"`
+	// Viewer has seen all author's stories
+	Seen bool `json:"seen"`
+}
+
+type StoryViewerDTO struct {
+	Username string `json:"username"`
+	Date     int64  `json:"date"`
+}
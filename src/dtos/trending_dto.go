@@ -0,0 +1,16 @@
+package dtos
+
+type TrendingTermDTO struct {
+	Term  string `json:"term"`
+	Count int64  `json:"count"`
+	// Average count in windows preceding the current one
+	Baseline float64 `json:"baseline"`
+	Spike    bool    `json:"spike"`
+}
+
+type TrendingDTO struct {
+	Window   TrendingWindow    `json:"window"`
+	Hashtags []TrendingTermDTO `json:"hashtags"`
+	Mentions []TrendingTermDTO `json:"mentions"`
+	Date     int64             `json:"date"`
+}
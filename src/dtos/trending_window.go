@@ -0,0 +1,36 @@
+package dtos
+
+type TrendingWindow string
+
+const (
+	TrendingWindowHour TrendingWindow = "1h"
+	TrendingWindowDay  TrendingWindow = "24h"
+	TrendingWindowWeek TrendingWindow = "7d"
+)
+
+var TrendingWindows = []TrendingWindow{TrendingWindowHour, TrendingWindowDay, TrendingWindowWeek}
+
+func ParseTrendingWindow(value string) (TrendingWindow, bool) {
+	switch TrendingWindow(value) {
+	case "", TrendingWindowDay:
+		return TrendingWindowDay, true
+	case TrendingWindowHour:
+		return TrendingWindowHour, true
+	case TrendingWindowWeek:
+		return TrendingWindowWeek, true
+	default:
+		return "", false
+	}
+}
+
+// Seconds returns length of the window in seconds
+func (w TrendingWindow) Seconds() int64 {
+	switch w {
+	case TrendingWindowHour:
+		return 3600
+	case TrendingWindowWeek:
+		return 7 * 24 * 3600
+	default:
+		return 24 * 3600
+	}
+}
@@ -0,0 +1,65 @@
+package explore
+
+import (
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"math"
+	"sort"
+)
+
+const (
+	// Sliding window in seconds from which explore candidates are taken
+	Window = 3 * 24 * 3600
+	// Hours added to post age so that brand new posts do not dominate with a few engagements
+	ageOffset = 2.0
+	// Number of ranked posts kept in cache
+	cacheSize = 200
+
+	likeWeight    = 1.0
+	commentWeight = 2.0
+)
+
+type Candidate struct {
+	Post     post.Post
+	Likes    int64
+	Comments int64
+}
+
+// Score returns engagement velocity of a candidate, weighted engagements per hour since it was published
+func Score(candidate Candidate, now int64) float64 {
+	ageHours := math.Max(float64(now-candidate.Post.Date), 0) / 3600
+	engagement := likeWeight*float64(candidate.Likes) + commentWeight*float64(candidate.Comments)
+
+	return engagement / (ageHours + ageOffset)
+}
+
+// Rank orders candidates by score descending and keeps the ones with any engagement
+func Rank(candidates []Candidate, now int64) []post.Post {
+	scores := make(map[uint]float64, len(candidates))
+	var engaged []Candidate
+	for _, candidate := range candidates {
+		if candidate.Likes+candidate.Comments == 0 {
+			continue
+		}
+		scores[candidate.Post.ID] = Score(candidate, now)
+		engaged = append(engaged, candidate)
+	}
+
+	sort.SliceStable(engaged, func(i, j int) bool {
+		first := scores[engaged[i].Post.ID]
+		second := scores[engaged[j].Post.ID]
+		if first != second {
+			return first > second
+		}
+		return engaged[i].Post.Date > engaged[j].Post.Date
+	})
+
+	if len(engaged) > cacheSize {
+		engaged = engaged[:cacheSize]
+	}
+
+	ranked := make([]post.Post, 0, len(engaged))
+	for _, candidate := range engaged {
+		ranked = append(ranked, candidate.Post)
+	}
+	return ranked
+}
@@ -0,0 +1,33 @@
+package explore
+
+import (
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"sync"
+)
+
+// ExploreCache holds the latest ranked explore posts computed by the background job
+type ExploreCache interface {
+	Set([]post.Post)
+	Get() []post.Post
+}
+
+type exploreCache struct {
+	mutex sync.RWMutex
+	posts []post.Post
+}
+
+func NewExploreCache() ExploreCache {
+	return &exploreCache{}
+}
+
+func (c *exploreCache) Set(posts []post.Post) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.posts = posts
+}
+
+func (c *exploreCache) Get() []post.Post {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.posts
+}
@@ -0,0 +1,66 @@
+package explore
+
+import (
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const now = int64(1625000000)
+
+type ExploreUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestExploreUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(ExploreUnitTestsSuite))
+}
+
+func ids(posts []post.Post) []uint {
+	var collection []uint
+	for _, postEntity := range posts {
+		collection = append(collection, postEntity.ID)
+	}
+	return collection
+}
+
+func (suite *ExploreUnitTestsSuite) TestExplore_Rank_Velocity() {
+	candidates := []Candidate{
+		{Post: post.Post{ID: 1, Date: now - 48*3600}, Likes: 100},
+		{Post: post.Post{ID: 2, Date: now - 3600}, Likes: 10},
+		{Post: post.Post{ID: 3, Date: now - 24*3600}, Likes: 10, Comments: 10},
+	}
+
+	ranked := Rank(candidates, now)
+
+	assert.Equal(suite.T(), []uint{2, 1, 3}, ids(ranked))
+}
+
+func (suite *ExploreUnitTestsSuite) TestExplore_Rank_SkipsPostsWithoutEngagement() {
+	candidates := []Candidate{
+		{Post: post.Post{ID: 1, Date: now}},
+		{Post: post.Post{ID: 2, Date: now - 3600}, Comments: 1},
+	}
+
+	ranked := Rank(candidates, now)
+
+	assert.Equal(suite.T(), []uint{2}, ids(ranked))
+}
+
+func (suite *ExploreUnitTestsSuite) TestExplore_Rank_TieBrokenByDate() {
+	candidates := []Candidate{
+		{Post: post.Post{ID: 1, Date: now}, Likes: 2},
+		{Post: post.Post{ID: 2, Date: now + 60}, Likes: 2},
+	}
+
+	ranked := Rank(candidates, now)
+
+	assert.Equal(suite.T(), []uint{2, 1}, ids(ranked))
+}
+
+func (suite *ExploreUnitTestsSuite) TestExplore_Score() {
+	candidate := Candidate{Post: post.Post{Date: now - 2*3600}, Likes: 4, Comments: 2}
+
+	assert.Equal(suite.T(), 2.0, Score(candidate, now))
+}
@@ -0,0 +1,95 @@
+package feed_ranker
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"math"
+	"sort"
+)
+
+const (
+	// Age in hours after which recency factor of a post is halved
+	recencyHalfLife = 24.0
+
+	likeWeight     = 1.0
+	commentWeight  = 2.0
+	affinityWeight = 0.5
+)
+
+type FeedItem struct {
+	Post dtos.PostDTO
+	// Number of times viewer liked or commented posts of the author
+	Affinity int64
+}
+
+type FeedRanker interface {
+	Rank([]FeedItem, int64) []dtos.PostDTO
+}
+
+type scoreRanker struct {
+}
+
+type chronologicalRanker struct {
+}
+
+// NewFeedRanker creates default ranker which combines recency decay, engagement and viewer affinity
+func NewFeedRanker() FeedRanker {
+	return &scoreRanker{}
+}
+
+func NewChronologicalRanker() FeedRanker {
+	return &chronologicalRanker{}
+}
+
+func Score(item FeedItem, now int64) float64 {
+	ageHours := math.Max(float64(now-item.Post.Timestamp), 0) / 3600
+	recency := math.Pow(0.5, ageHours/recencyHalfLife)
+
+	engagement := 1 + likeWeight*math.Log1p(float64(item.Post.Likes)) + commentWeight*math.Log1p(float64(len(item.Post.Comments)))
+	affinity := 1 + affinityWeight*math.Log1p(float64(item.Affinity))
+
+	return recency * engagement * affinity
+}
+
+func (r *scoreRanker) Rank(items []FeedItem, now int64) []dtos.PostDTO {
+	scores := make(map[uint]float64, len(items))
+	for _, item := range items {
+		scores[item.Post.ID] = Score(item, now)
+	}
+
+	sorted := make([]FeedItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		first, second := scores[sorted[i].Post.ID], scores[sorted[j].Post.ID]
+		if first != second {
+			return first > second
+		}
+		return newerFirst(sorted[i].Post, sorted[j].Post)
+	})
+
+	return posts(sorted)
+}
+
+func (r *chronologicalRanker) Rank(items []FeedItem, now int64) []dtos.PostDTO {
+	sorted := make([]FeedItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return newerFirst(sorted[i].Post, sorted[j].Post)
+	})
+
+	return posts(sorted)
+}
+
+func newerFirst(first dtos.PostDTO, second dtos.PostDTO) bool {
+	if first.Timestamp != second.Timestamp {
+		return first.Timestamp > second.Timestamp
+	}
+	return first.ID > second.ID
+}
+
+func posts(items []FeedItem) []dtos.PostDTO {
+	var collection []dtos.PostDTO
+	for _, item := range items {
+		collection = append(collection, item.Post)
+	}
+	return collection
+}
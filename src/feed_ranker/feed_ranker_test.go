@@ -0,0 +1,117 @@
+package feed_ranker
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const now = int64(1625000000)
+
+type FeedRankerUnitTestsSuite struct {
+	suite.Suite
+	ranker FeedRanker
+}
+
+func TestFeedRankerUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(FeedRankerUnitTestsSuite))
+}
+
+func (suite *FeedRankerUnitTestsSuite) SetupSuite() {
+	suite.ranker = NewFeedRanker()
+}
+
+func ids(posts []dtos.PostDTO) []uint {
+	var collection []uint
+	for _, post := range posts {
+		collection = append(collection, post.ID)
+	}
+	return collection
+}
+
+func comments(count int) []dtos.CommentDTO {
+	return make([]dtos.CommentDTO, count)
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_Empty() {
+	assert.Nil(suite.T(), suite.ranker.Rank([]FeedItem{}, now))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_RecencyDecay() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now - 48*3600}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now - 3600}},
+		{Post: dtos.PostDTO{ID: 3, Timestamp: now - 24*3600}},
+	}
+
+	ranked := suite.ranker.Rank(items, now)
+
+	assert.Equal(suite.T(), []uint{2, 3, 1}, ids(ranked))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_Engagement() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now - 3600}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now - 6*3600, Likes: 40, Comments: comments(10)}},
+	}
+
+	ranked := suite.ranker.Rank(items, now)
+
+	assert.Equal(suite.T(), []uint{2, 1}, ids(ranked))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_Affinity() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now - 3600, Likes: 3}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now - 3600, Likes: 3}, Affinity: 25},
+	}
+
+	ranked := suite.ranker.Rank(items, now)
+
+	assert.Equal(suite.T(), []uint{2, 1}, ids(ranked))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_OldPostsLoseEngagementAdvantage() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now - 7*24*3600, Likes: 40, Comments: comments(10)}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now - 3600}},
+	}
+
+	ranked := suite.ranker.Rank(items, now)
+
+	assert.Equal(suite.T(), []uint{2, 1}, ids(ranked))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_TiesAreNewestFirst() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now - 3600}},
+		{Post: dtos.PostDTO{ID: 3, Timestamp: now - 3600}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now - 3600}},
+	}
+
+	ranked := suite.ranker.Rank(items, now)
+
+	assert.Equal(suite.T(), []uint{3, 2, 1}, ids(ranked))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestFeedRanker_Rank_FuturePostsAreNotBoosted() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now + 3600}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now}},
+	}
+
+	assert.Equal(suite.T(), Score(items[0], now), Score(items[1], now))
+}
+
+func (suite *FeedRankerUnitTestsSuite) TestChronologicalRanker_Rank() {
+	items := []FeedItem{
+		{Post: dtos.PostDTO{ID: 1, Timestamp: now - 48*3600, Likes: 100}},
+		{Post: dtos.PostDTO{ID: 2, Timestamp: now - 3600}},
+		{Post: dtos.PostDTO{ID: 3, Timestamp: now - 24*3600}, Affinity: 50},
+	}
+
+	ranked := NewChronologicalRanker().Rank(items, now)
+
+	assert.Equal(suite.T(), []uint{2, 3, 1}, ids(ranked))
+}
@@ -0,0 +1,109 @@
+package image_hash
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/Nistagram-Organization/nistagram-posts/src/media_validator"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+)
+
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+// Decode decodes a base64 image without a data URI header, images with too many pixels are not decoded
+func Decode(imageBase64 string) (image.Image, error) {
+	data, err := base64.StdEncoding.DecodeString(imageBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := media_validator.CheckImageDimensions(data); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// Compute calculates the difference hash (dHash) of a base64 image.
+// Image is shrunk to 9x8 grayscale cells and every bit tells whether a cell is brighter than its right neighbour.
+func Compute(imageBase64 string) (uint64, error) {
+	img, err := Decode(imageBase64)
+	if err != nil {
+		return 0, err
+	}
+
+	cells := shrink(img)
+
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			hash <<= 1
+			if cells[y][x] > cells[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash, nil
+}
+
+// Distance returns the number of differing bits between two hashes
+func Distance(first uint64, second uint64) int {
+	return bits.OnesCount64(first ^ second)
+}
+
+// Bands splits a hash into count disjoint bit ranges, the first ones get a bit more when 64 is not divisible by count.
+// Hashes within distance count-1 of each other have at least one equal band, so indexed bands find every such hash.
+func Bands(hash uint64, count int) []uint64 {
+	bands := make([]uint64, 0, count)
+	shift := 64
+	for band := 0; band < count; band++ {
+		size := 64 / count
+		if band < 64%count {
+			size++
+		}
+		shift -= size
+		bands = append(bands, (hash>>uint(shift))&(1<<uint(size)-1))
+	}
+	return bands
+}
+
+func shrink(img image.Image) [hashHeight][hashWidth]float64 {
+	var cells [hashHeight][hashWidth]float64
+	var counts [hashHeight][hashWidth]float64
+
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		cellY := (y - bounds.Min.Y) * hashHeight / height
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			cellX := (x - bounds.Min.X) * hashWidth / width
+			r, g, b, _ := img.At(x, y).RGBA()
+			cells[cellY][cellX] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			counts[cellY][cellX]++
+		}
+	}
+
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth; x++ {
+			if counts[y][x] != 0 {
+				cells[y][x] /= counts[y][x]
+			}
+		}
+	}
+
+	return cells
+}
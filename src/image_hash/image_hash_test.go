@@ -0,0 +1,133 @@
+package image_hash
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+type ImageHashUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestImageHashUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(ImageHashUnitTestsSuite))
+}
+
+func encode(img image.Image) string {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, img)
+	return base64.StdEncoding.EncodeToString(buffer.Bytes())
+}
+
+func gradient(size int, brightness int) string {
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			value := (x*255/size+y*128/size)/2 + brightness
+			if value > 255 {
+				value = 255
+			}
+			img.SetGray(x, y, color.Gray{Y: uint8(value)})
+		}
+	}
+	return encode(img)
+}
+
+func checkerboard(size int) string {
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if (x/(size/8)+y/(size/8))%2 == 0 {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return encode(img)
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Distance() {
+	assert.Equal(suite.T(), 0, Distance(0xF0F0, 0xF0F0))
+	assert.Equal(suite.T(), 4, Distance(0xF0F0, 0xF0FF))
+	assert.Equal(suite.T(), 64, Distance(0, ^uint64(0)))
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Compute_ResizedAndBrightenedImageIsWithinRejectDistance() {
+	original, err := Compute(gradient(64, 0))
+	assert.Nil(suite.T(), err)
+
+	resized, err := Compute(gradient(128, 0))
+	assert.Nil(suite.T(), err)
+	brightened, err := Compute(gradient(64, 20))
+	assert.Nil(suite.T(), err)
+
+	assert.LessOrEqual(suite.T(), Distance(original, resized), 5)
+	assert.LessOrEqual(suite.T(), Distance(original, brightened), 5)
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Compute_DifferentImageIsBeyondFlagDistance() {
+	first, err := Compute(gradient(64, 0))
+	assert.Nil(suite.T(), err)
+
+	second, err := Compute(checkerboard(64))
+	assert.Nil(suite.T(), err)
+
+	assert.Greater(suite.T(), Distance(first, second), 10)
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Compute_InvalidImage() {
+	_, err := Compute(base64.StdEncoding.EncodeToString([]byte("not an image")))
+
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Compute_TooManyPixels() {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 4, 4)))
+	data := buffer.Bytes()
+	binary.BigEndian.PutUint32(data[16:20], 100000)
+	binary.BigEndian.PutUint32(data[20:24], 100000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := Compute(base64.StdEncoding.EncodeToString(data))
+
+	assert.EqualError(suite.T(), err, "image is larger than 50 megapixels")
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Bands_CoverWholeHash() {
+	hash := uint64(0xDEADBEEFCAFEBABE)
+
+	bands := Bands(hash, 11)
+
+	var joined uint64
+	for band, value := range bands {
+		size := 64 / 11
+		if band < 64%11 {
+			size++
+		}
+		joined = joined<<uint(size) | value
+	}
+	assert.Len(suite.T(), bands, 11)
+	assert.Equal(suite.T(), hash, joined)
+}
+
+func (suite *ImageHashUnitTestsSuite) TestImageHash_Bands_SimilarHashesShareBand() {
+	hash := uint64(0xDEADBEEFCAFEBABE)
+	// Ten flipped bits spread over the hash
+	similar := hash ^ 0x8040201008040201 ^ 0x0000000000000100 ^ 0x0100000000000000
+
+	assert.Equal(suite.T(), 10, Distance(hash, similar))
+	shared := false
+	first, second := Bands(hash, 11), Bands(similar, 11)
+	for band := range first {
+		shared = shared || first[band] == second[band]
+	}
+	assert.True(suite.T(), shared)
+}
@@ -0,0 +1,92 @@
+package insights
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+)
+
+type Kind int
+
+const (
+	Like Kind = iota
+	Dislike
+	Comment
+	Impression
+)
+
+// Event is a single engagement or impression of a post
+type Event struct {
+	PostID    uint
+	UserEmail string
+	Kind      Kind
+	Date      int64
+}
+
+func add(counts *dtos.InsightsCountsDTO, kind Kind) {
+	switch kind {
+	case Like:
+		counts.Likes++
+	case Dislike:
+		counts.Dislikes++
+	case Comment:
+		counts.Comments++
+	case Impression:
+		counts.Impressions++
+	}
+}
+
+func sum(counts *dtos.InsightsCountsDTO, other dtos.InsightsCountsDTO) {
+	counts.Likes += other.Likes
+	counts.Dislikes += other.Dislikes
+	counts.Comments += other.Comments
+	counts.Impressions += other.Impressions
+}
+
+// Buckets counts events of the period [from, to) in consecutive buckets of given size starting at from
+func Buckets(events []Event, from int64, to int64, size int64) []dtos.InsightsBucketDTO {
+	count := (to - from + size - 1) / size
+	if count < 0 {
+		count = 0
+	}
+
+	buckets := make([]dtos.InsightsBucketDTO, count)
+	for i := range buckets {
+		buckets[i].Start = from + int64(i)*size
+	}
+
+	for _, event := range events {
+		if event.Date < from || event.Date >= to {
+			continue
+		}
+		add(&buckets[(event.Date-from)/size].InsightsCountsDTO, event.Kind)
+	}
+
+	return buckets
+}
+
+// Totals counts all events and splits them between followers and other users
+func Totals(events []Event, followers map[string]bool) (dtos.InsightsCountsDTO, dtos.InsightsAudienceDTO) {
+	var total dtos.InsightsCountsDTO
+	var audience dtos.InsightsAudienceDTO
+
+	for _, event := range events {
+		add(&total, event.Kind)
+		if followers[event.UserEmail] {
+			add(&audience.Followers, event.Kind)
+		} else {
+			add(&audience.NonFollowers, event.Kind)
+		}
+	}
+
+	return total, audience
+}
+
+// ByPost counts events of every post
+func ByPost(events []Event) map[uint]dtos.InsightsCountsDTO {
+	counts := make(map[uint]dtos.InsightsCountsDTO)
+	for _, event := range events {
+		postCounts := counts[event.PostID]
+		add(&postCounts, event.Kind)
+		counts[event.PostID] = postCounts
+	}
+	return counts
+}
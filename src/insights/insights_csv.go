@@ -0,0 +1,48 @@
+package insights
+
+import (
+	"encoding/csv"
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"io"
+	"strconv"
+)
+
+var countsHeader = []string{"likes", "dislikes", "comments", "impressions"}
+
+func countsRecord(counts dtos.InsightsCountsDTO) []string {
+	return []string{
+		strconv.FormatInt(counts.Likes, 10),
+		strconv.FormatInt(counts.Dislikes, 10),
+		strconv.FormatInt(counts.Comments, 10),
+		strconv.FormatInt(counts.Impressions, 10),
+	}
+}
+
+// WritePostInsightsCSV writes a row per bucket followed by the total row
+func WritePostInsightsCSV(w io.Writer, postInsights *dtos.PostInsightsDTO) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{append([]string{"start"}, countsHeader...)}
+	for _, bucket := range postInsights.Buckets {
+		records = append(records, append([]string{strconv.FormatInt(bucket.Start, 10)}, countsRecord(bucket.InsightsCountsDTO)...))
+	}
+	records = append(records, append([]string{"total"}, countsRecord(postInsights.Total)...))
+
+	return writer.WriteAll(records)
+}
+
+// WriteSummaryCSV writes a row per post followed by the total row
+func WriteSummaryCSV(w io.Writer, summary *dtos.InsightsSummaryDTO) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{append([]string{"post_id", "date"}, countsHeader...)}
+	for _, postSummary := range summary.Posts {
+		records = append(records, append([]string{
+			strconv.FormatUint(uint64(postSummary.PostID), 10),
+			strconv.FormatInt(postSummary.Date, 10),
+		}, countsRecord(postSummary.InsightsCountsDTO)...))
+	}
+	records = append(records, append([]string{"total", ""}, countsRecord(summary.Total)...))
+
+	return writer.WriteAll(records)
+}
@@ -0,0 +1,71 @@
+package insights
+
+import (
+	"bytes"
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const (
+	from = int64(1625011200)
+	day  = int64(24 * 3600)
+)
+
+type InsightsUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestInsightsUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(InsightsUnitTestsSuite))
+}
+
+func events() []Event {
+	return []Event{
+		{PostID: 1, UserEmail: "follower", Kind: Like, Date: from + 60},
+		{PostID: 1, UserEmail: "stranger", Kind: Comment, Date: from + day},
+		{PostID: 2, UserEmail: "stranger", Kind: Impression, Date: from + day},
+		{PostID: 2, UserEmail: "follower", Kind: Dislike, Date: from + 2*day + 1},
+	}
+}
+
+func (suite *InsightsUnitTestsSuite) TestInsights_Buckets() {
+	buckets := Buckets(append(events(), Event{Kind: Like, Date: from - 1}), from, from+2*day+day/2, day)
+
+	assert.Equal(suite.T(), []dtos.InsightsBucketDTO{
+		{Start: from, InsightsCountsDTO: dtos.InsightsCountsDTO{Likes: 1}},
+		{Start: from + day, InsightsCountsDTO: dtos.InsightsCountsDTO{Comments: 1, Impressions: 1}},
+		{Start: from + 2*day, InsightsCountsDTO: dtos.InsightsCountsDTO{Dislikes: 1}},
+	}, buckets)
+}
+
+func (suite *InsightsUnitTestsSuite) TestInsights_Totals() {
+	total, audience := Totals(events(), map[string]bool{"follower": true})
+
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Likes: 1, Dislikes: 1, Comments: 1, Impressions: 1}, total)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Likes: 1, Dislikes: 1}, audience.Followers)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Comments: 1, Impressions: 1}, audience.NonFollowers)
+}
+
+func (suite *InsightsUnitTestsSuite) TestInsights_ByPost() {
+	counts := ByPost(events())
+
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Likes: 1, Comments: 1}, counts[1])
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Dislikes: 1, Impressions: 1}, counts[2])
+}
+
+func (suite *InsightsUnitTestsSuite) TestInsights_WriteSummaryCSV() {
+	summary := dtos.InsightsSummaryDTO{
+		Posts: []dtos.PostInsightsSummaryDTO{
+			{PostID: 1, Date: from, InsightsCountsDTO: dtos.InsightsCountsDTO{Likes: 3, Impressions: 10}},
+		},
+		Total: dtos.InsightsCountsDTO{Likes: 3, Impressions: 10},
+	}
+	var buffer bytes.Buffer
+
+	err := WriteSummaryCSV(&buffer, &summary)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "post_id,date,likes,dislikes,comments,impressions\n1,1625011200,3,0,0,10\ntotal,,3,0,0,10\n", buffer.String())
+}
@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"log"
+	"time"
+)
+
+// Schedule runs the job right away and then every interval in a background goroutine
+func Schedule(name string, interval time.Duration, job func() rest_error.RestErr) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(); err != nil {
+				log.Printf("Job %s failed: %s", name, err.Error())
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,183 @@
+package media_validator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strings"
+)
+
+const (
+	MaxImageSize = 10 << 20
+	MaxVideoSize = 50 << 20
+	// Maximum number of pixels of a decoded image, a small file may declare dimensions taking gigabytes once decoded
+	MaxImagePixels = 50_000_000
+	// Maximum video duration in seconds
+	MaxVideoDuration = 60
+)
+
+var supportedMIMETypes = map[string]string{
+	"image/jpeg": post_setting.MediaTypeImage,
+	"image/png":  post_setting.MediaTypeImage,
+	"image/gif":  post_setting.MediaTypeImage,
+	"video/mp4":  post_setting.MediaTypeVideo,
+}
+
+// Media describes decoded media content
+type Media struct {
+	// Base64 content without the data URI header, media is saved and hashed in this form
+	Content  string
+	Type     string
+	MIMEType string
+	Size     int
+	// Duration in seconds, zero for images
+	Duration float64
+}
+
+// StripDataURI splits an optional data URI header off base64 media, returning the content and the MIME type declared in the header
+func StripDataURI(mediaBase64 string) (string, string) {
+	if i := strings.Index(mediaBase64, ","); i != -1 && strings.HasPrefix(mediaBase64, "data:") {
+		return mediaBase64[i+1:], strings.TrimSuffix(mediaBase64[len("data:"):i], ";base64")
+	}
+	return mediaBase64, ""
+}
+
+// Validate decodes base64 media, optionally prefixed with a data URI header, and checks its content.
+// Type is sniffed from the decoded bytes and has to match the MIME type declared in the header, if any.
+// Media larger than any supported type is rejected before it is decoded.
+func Validate(mediaBase64 string) (*Media, error) {
+	mediaBase64, declaredMIMEType := StripDataURI(mediaBase64)
+
+	if base64.StdEncoding.DecodedLen(len(mediaBase64)) > MaxVideoSize {
+		return nil, fmt.Errorf("media is larger than %d MB", MaxVideoSize>>20)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(mediaBase64)
+	if err != nil {
+		return nil, errors.New("media is not valid base64")
+	}
+
+	mimeType := http.DetectContentType(data)
+	mediaType, ok := supportedMIMETypes[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported media type %s", mimeType)
+	}
+	if declaredMIMEType != "" && declaredMIMEType != mimeType {
+		return nil, fmt.Errorf("media declared as %s is %s", declaredMIMEType, mimeType)
+	}
+
+	media := Media{
+		Content:  mediaBase64,
+		Type:     mediaType,
+		MIMEType: mimeType,
+		Size:     len(data),
+	}
+
+	if mediaType == post_setting.MediaTypeImage {
+		if media.Size > MaxImageSize {
+			return nil, fmt.Errorf("image is larger than %d MB", MaxImageSize>>20)
+		}
+		if err := CheckImageDimensions(data); err != nil {
+			return nil, err
+		}
+		return &media, nil
+	}
+
+	if media.Size > MaxVideoSize {
+		return nil, fmt.Errorf("video is larger than %d MB", MaxVideoSize>>20)
+	}
+
+	if media.Duration, err = mp4Duration(data); err != nil {
+		return nil, err
+	}
+	if media.Duration > MaxVideoDuration {
+		return nil, fmt.Errorf("video is longer than %d seconds", MaxVideoDuration)
+	}
+
+	return &media, nil
+}
+
+// CheckImageDimensions reads only the image header and rejects images with more than MaxImagePixels pixels,
+// it has to pass before an image is decoded
+func CheckImageDimensions(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return errors.New("image is not valid")
+	}
+	if int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return fmt.Errorf("image is larger than %d megapixels", MaxImagePixels/1_000_000)
+	}
+	return nil
+}
+
+// mp4Duration reads duration from the movie header box (moov/mvhd)
+func mp4Duration(data []byte) (float64, error) {
+	moov, ok := findBox(data, "moov")
+	if !ok {
+		return 0, errors.New("video has no movie header")
+	}
+	mvhd, ok := findBox(moov, "mvhd")
+	if !ok || len(mvhd) < 4 {
+		return 0, errors.New("video has no movie header")
+	}
+
+	var timescale uint32
+	var duration uint64
+	switch mvhd[0] {
+	case 0:
+		if len(mvhd) < 20 {
+			return 0, errors.New("video has invalid movie header")
+		}
+		timescale = binary.BigEndian.Uint32(mvhd[12:16])
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+	case 1:
+		if len(mvhd) < 32 {
+			return 0, errors.New("video has invalid movie header")
+		}
+		timescale = binary.BigEndian.Uint32(mvhd[20:24])
+		duration = binary.BigEndian.Uint64(mvhd[24:32])
+	default:
+		return 0, errors.New("video has invalid movie header")
+	}
+
+	if timescale == 0 {
+		return 0, errors.New("video has invalid movie header")
+	}
+
+	return float64(duration) / float64(timescale), nil
+}
+
+// findBox returns content of the first box with the given type among sibling boxes
+func findBox(data []byte, boxType string) ([]byte, bool) {
+	for len(data) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(data[0:4]))
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, false
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			header = 16
+		}
+		if size < header || size > uint64(len(data)) {
+			return nil, false
+		}
+
+		if string(data[4:8]) == boxType {
+			return data[header:size], true
+		}
+		data = data[size:]
+	}
+	return nil, false
+}
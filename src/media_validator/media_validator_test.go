@@ -0,0 +1,101 @@
+package media_validator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+type MediaValidatorUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestMediaValidatorUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(MediaValidatorUnitTestsSuite))
+}
+
+func box(boxType string, content []byte) []byte {
+	data := make([]byte, 8, 8+len(content))
+	binary.BigEndian.PutUint32(data, uint32(8+len(content)))
+	copy(data[4:], boxType)
+	return append(data, content...)
+}
+
+func mp4(timescale uint32, duration uint32) []byte {
+	ftyp := box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2mp41"))
+	mvhd := make([]byte, 20)
+	binary.BigEndian.PutUint32(mvhd[12:16], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:20], duration)
+	return append(ftyp, box("moov", box("mvhd", mvhd))...)
+}
+
+func pngImage() []byte {
+	var buffer bytes.Buffer
+	_ = png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 4, 4)))
+	return buffer.Bytes()
+}
+
+// oversizedPNG returns a tiny PNG whose header declares a 100000x100000 image
+func oversizedPNG() []byte {
+	data := pngImage()
+	// IHDR chunk data starts after the signature, chunk length and type, width and height come first
+	binary.BigEndian.PutUint32(data[16:20], 100000)
+	binary.BigEndian.PutUint32(data[20:24], 100000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_Image() {
+	media, err := Validate("data:image/png;base64," + base64.StdEncoding.EncodeToString(pngImage()))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), post_setting.MediaTypeImage, media.Type)
+	assert.Equal(suite.T(), "image/png", media.MIMEType)
+	assert.Equal(suite.T(), base64.StdEncoding.EncodeToString(pngImage()), media.Content)
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_DeclaredTypeMismatch() {
+	_, err := Validate("data:video/mp4;base64," + base64.StdEncoding.EncodeToString(pngImage()))
+
+	assert.EqualError(suite.T(), err, "media declared as video/mp4 is image/png")
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_Video() {
+	media, err := Validate(base64.StdEncoding.EncodeToString(mp4(1000, 15500)))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), post_setting.MediaTypeVideo, media.Type)
+	assert.Equal(suite.T(), 15.5, media.Duration)
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_VideoTooLong() {
+	_, err := Validate(base64.StdEncoding.EncodeToString(mp4(600, 600*(MaxVideoDuration+1))))
+
+	assert.EqualError(suite.T(), err, "video is longer than 60 seconds")
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_Unsupported() {
+	_, err := Validate(base64.StdEncoding.EncodeToString([]byte("plain text")))
+
+	assert.EqualError(suite.T(), err, "unsupported media type text/plain; charset=utf-8")
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_TooManyPixels() {
+	_, err := Validate(base64.StdEncoding.EncodeToString(oversizedPNG()))
+
+	assert.EqualError(suite.T(), err, "image is larger than 50 megapixels")
+}
+
+func (suite *MediaValidatorUnitTestsSuite) TestValidate_TooLargeBeforeDecoding() {
+	_, err := Validate(strings.Repeat("!", base64.StdEncoding.EncodedLen(MaxVideoSize)+4))
+
+	assert.EqualError(suite.T(), err, "media is larger than 50 MB")
+}
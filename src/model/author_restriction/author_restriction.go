@@ -0,0 +1,15 @@
+package author_restriction
+
+type AuthorRestriction struct {
+	ID               uint   `json:"id"`
+	UserEmail        string `json:"user_email" gorm:"uniqueIndex;size:255"`
+	ShadowBanned     bool   `json:"shadow_banned"`
+	CommentsDisabled bool   `json:"comments_disabled"`
+	// Maximum number of posts per hour, zero means unlimited
+	PostRateLimit uint  `json:"post_rate_limit"`
+	Date          int64 `json:"date"`
+}
+
+func (r *AuthorRestriction) IsEmpty() bool {
+	return !r.ShadowBanned && !r.CommentsDisabled && r.PostRateLimit == 0
+}
@@ -0,0 +1,9 @@
+package banned_media
+
+type BannedMedia struct {
+	ID     uint              `json:"id"`
+	Hash   uint64            `json:"hash"`
+	PostID uint              `json:"post_id"`
+	Date   int64             `json:"date"`
+	Bands  []BannedMediaBand `json:"-" gorm:"foreignKey:BannedMediaID"`
+}
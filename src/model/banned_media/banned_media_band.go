@@ -0,0 +1,20 @@
+package banned_media
+
+// BannedMediaBand is a part of banned media's hash, indexed so that similar hashes are found without reading all of them
+type BannedMediaBand struct {
+	ID            uint   `json:"id"`
+	BannedMediaID uint   `json:"banned_media_id" gorm:"index"`
+	Band          int    `json:"band" gorm:"index:idx_banned_media_band"`
+	Value         uint64 `json:"value" gorm:"index:idx_banned_media_band"`
+}
+
+func NewBands(values []uint64) []BannedMediaBand {
+	bands := make([]BannedMediaBand, 0, len(values))
+	for band, value := range values {
+		bands = append(bands, BannedMediaBand{
+			Band:  band,
+			Value: value,
+		})
+	}
+	return bands
+}
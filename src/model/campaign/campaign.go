@@ -0,0 +1,40 @@
+package campaign
+
+import "strings"
+
+const (
+	AudienceAll          = "all"
+	AudienceFollowers    = "followers"
+	AudienceNonFollowers = "non_followers"
+)
+
+// Campaign promotes an agent's post in feeds of matching users between start and end date
+type Campaign struct {
+	ID         uint   `json:"id"`
+	PostID     uint   `json:"post_id" gorm:"uniqueIndex"`
+	AgentEmail string `json:"agent_email" gorm:"index;size:255"`
+	StartDate  int64  `json:"start_date" gorm:"index"`
+	EndDate    int64  `json:"end_date" gorm:"index"`
+	Audience   string `json:"audience"`
+	// Comma separated hashtags without #, users who liked posts with any of them are targeted
+	Interests string `json:"interests"`
+	// Maximum number of deliveries to the same user per day
+	FrequencyCap int   `json:"frequency_cap"`
+	Date         int64 `json:"date"`
+}
+
+func IsValidAudience(audience string) bool {
+	switch audience {
+	case AudienceAll, AudienceFollowers, AudienceNonFollowers:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Campaign) GetInterests() []string {
+	if c.Interests == "" {
+		return []string{}
+	}
+	return strings.Split(c.Interests, ",")
+}
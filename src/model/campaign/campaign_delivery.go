@@ -0,0 +1,18 @@
+package campaign
+
+// CampaignDelivery counts how many times a campaign was shown to a user on a day
+type CampaignDelivery struct {
+	ID         uint   `json:"id"`
+	CampaignID uint   `json:"campaign_id" gorm:"uniqueIndex:idx_campaign_delivery"`
+	UserEmail  string `json:"user_email" gorm:"uniqueIndex:idx_campaign_delivery;size:255"`
+	// Unix time of the start of the day (UTC)
+	Day   int64 `json:"day" gorm:"uniqueIndex:idx_campaign_delivery"`
+	Count int64 `json:"count"`
+}
+
+// DeliveryStats is the total number of deliveries of a campaign and the number of distinct users it reached
+type DeliveryStats struct {
+	CampaignID uint
+	Deliveries int64
+	Reach      int64
+}
@@ -0,0 +1,9 @@
+package close_friend
+
+// CloseFriend is a user added by an author to the list of users who see author's close friends posts
+type CloseFriend struct {
+	ID          uint   `json:"id"`
+	UserEmail   string `json:"user_email" gorm:"uniqueIndex:idx_close_friend;size:255"`
+	FriendEmail string `json:"friend_email" gorm:"uniqueIndex:idx_close_friend;size:255"`
+	Date        int64  `json:"date"`
+}
@@ -0,0 +1,12 @@
+package comment_review
+
+type CommentReview struct {
+	ID        uint    `json:"id"`
+	CommentID uint    `json:"comment_id" gorm:"uniqueIndex"`
+	PostID    uint    `json:"post_id"`
+	UserEmail string  `json:"user_email"`
+	Score     float64 `json:"score"`
+	// Comma separated spam signals which caused quarantine
+	Reasons string `json:"reasons"`
+	Date    int64  `json:"date"`
+}
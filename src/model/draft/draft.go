@@ -0,0 +1,15 @@
+package draft
+
+// Draft is an unpublished post kept for its author, MediaID is zero until an image is added
+type Draft struct {
+	ID             uint   `json:"id"`
+	Description    string `json:"description"`
+	UserEmail      string `json:"user_email" gorm:"index;size:255"`
+	MediaID        uint   `json:"media_id"`
+	MediaType      string `json:"media_type"`
+	ContentWarning string `json:"content_warning"`
+	Audience       string `json:"audience"`
+	CommentPolicy  string `json:"comment_policy"`
+	Date           int64  `json:"date"`
+	Updated        int64  `json:"updated"`
+}
@@ -0,0 +1,9 @@
+package feed_item
+
+type FeedItem struct {
+	ID          uint   `json:"id"`
+	UserEmail   string `json:"user_email" gorm:"uniqueIndex:idx_feed_item_user_post;size:255"`
+	PostID      uint   `json:"post_id" gorm:"uniqueIndex:idx_feed_item_user_post;index"`
+	AuthorEmail string `json:"author_email"`
+	Date        int64  `json:"date" gorm:"index"`
+}
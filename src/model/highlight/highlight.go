@@ -0,0 +1,9 @@
+package highlight
+
+// Highlight is a named collection of author's archived stories shown on their profile
+type Highlight struct {
+	ID        uint   `json:"id"`
+	UserEmail string `json:"user_email" gorm:"index;size:255"`
+	Name      string `json:"name"`
+	Date      int64  `json:"date"`
+}
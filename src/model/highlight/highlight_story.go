@@ -0,0 +1,8 @@
+package highlight
+
+type HighlightStory struct {
+	ID          uint `json:"id"`
+	HighlightID uint `json:"highlight_id" gorm:"uniqueIndex:idx_highlight_story"`
+	StoryID     uint `json:"story_id" gorm:"uniqueIndex:idx_highlight_story"`
+	Position    int  `json:"position"`
+}
@@ -0,0 +1,10 @@
+package impression
+
+// Impression records that a viewer saw a post on a day, it is kept once per viewer, post and day
+type Impression struct {
+	ID        uint   `json:"id"`
+	PostID    uint   `json:"post_id" gorm:"uniqueIndex:idx_impression"`
+	UserEmail string `json:"user_email" gorm:"uniqueIndex:idx_impression;size:255"`
+	// Unix time of the start of the day (UTC)
+	Day int64 `json:"day" gorm:"uniqueIndex:idx_impression"`
+}
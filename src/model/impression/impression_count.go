@@ -0,0 +1,9 @@
+package impression
+
+// ImpressionCount is the number of distinct viewers of a post on a day
+type ImpressionCount struct {
+	ID     uint  `json:"id"`
+	PostID uint  `json:"post_id" gorm:"uniqueIndex:idx_impression_count"`
+	Day    int64 `json:"day" gorm:"uniqueIndex:idx_impression_count"`
+	Count  int64 `json:"count"`
+}
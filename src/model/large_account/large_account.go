@@ -0,0 +1,10 @@
+package large_account
+
+// LargeAccount marks an author whose posts are not fanned out to followers' feeds,
+// followers read them directly instead
+type LargeAccount struct {
+	ID        uint   `json:"id"`
+	UserEmail string `json:"user_email" gorm:"uniqueIndex;size:255"`
+	Followers int64  `json:"followers"`
+	Date      int64  `json:"date"`
+}
@@ -0,0 +1,9 @@
+package mute
+
+// Mute hides posts and comments of a muted user from the user who muted them, without blocking
+type Mute struct {
+	ID         uint   `json:"id"`
+	UserEmail  string `json:"user_email" gorm:"uniqueIndex:idx_mute;size:255"`
+	MutedEmail string `json:"muted_email" gorm:"uniqueIndex:idx_mute;size:255"`
+	Date       int64  `json:"date"`
+}
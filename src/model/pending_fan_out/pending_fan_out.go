@@ -0,0 +1,9 @@
+package pending_fan_out
+
+// PendingFanOut marks a published post whose fan-out to followers' feeds failed,
+// fan-out is retried until it succeeds
+type PendingFanOut struct {
+	ID     uint  `json:"id"`
+	PostID uint  `json:"post_id" gorm:"uniqueIndex"`
+	Date   int64 `json:"date"`
+}
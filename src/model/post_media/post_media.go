@@ -0,0 +1,9 @@
+package post_media
+
+// PostMedia is an item of a carousel post, the first item is also post's MediaID
+type PostMedia struct {
+	ID       uint `json:"id"`
+	PostID   uint `json:"post_id" gorm:"uniqueIndex:idx_post_media_position"`
+	MediaID  uint `json:"media_id"`
+	Position int  `json:"position" gorm:"uniqueIndex:idx_post_media_position"`
+}
@@ -0,0 +1,117 @@
+package post_setting
+
+const (
+	ContentWarningMedical  = "medical"
+	ContentWarningGraphic  = "graphic"
+	ContentWarningViolence = "violence"
+	ContentWarningNudity   = "nudity"
+
+	MediaTypeImage = "image"
+	MediaTypeVideo = "video"
+
+	AudiencePublic       = "public"
+	AudienceFollowers    = "followers"
+	AudienceCloseFriends = "close_friends"
+
+	CommentPolicyEveryone  = "everyone"
+	CommentPolicyFollowers = "followers"
+	CommentPolicyNobody    = "nobody"
+)
+
+type PostSetting struct {
+	ID             uint   `json:"id"`
+	PostID         uint   `json:"post_id" gorm:"uniqueIndex"`
+	ContentWarning string `json:"content_warning"`
+	// Type of all post's media, empty for images
+	MediaType string `json:"media_type"`
+	// Users who can see the post, empty for public posts
+	Audience string `json:"audience"`
+	// Archived posts are hidden from everyone but their author, keeping their likes and comments
+	Archived bool `json:"archived"`
+	// Unix time at which the author pinned the post to their profile, zero for posts which are not pinned
+	PinnedAt int64 `json:"pinned_at"`
+	// Users who can comment on the post, empty when everyone can
+	CommentPolicy string `json:"comment_policy"`
+}
+
+func IsValidContentWarning(contentWarning string) bool {
+	switch contentWarning {
+	case "", ContentWarningMedical, ContentWarningGraphic, ContentWarningViolence, ContentWarningNudity:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidMediaType(mediaType string) bool {
+	switch mediaType {
+	case "", MediaTypeImage, MediaTypeVideo:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeMediaType returns the media type, posts without one have images
+func NormalizeMediaType(mediaType string) string {
+	if mediaType == "" {
+		return MediaTypeImage
+	}
+	return mediaType
+}
+
+func IsValidAudience(audience string) bool {
+	switch audience {
+	case "", AudiencePublic, AudienceFollowers, AudienceCloseFriends:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeAudience returns the audience, posts without one are public
+func NormalizeAudience(audience string) string {
+	if audience == "" {
+		return AudiencePublic
+	}
+	return audience
+}
+
+func (p *PostSetting) GetAudience() string {
+	return NormalizeAudience(p.Audience)
+}
+
+func IsValidCommentPolicy(commentPolicy string) bool {
+	switch commentPolicy {
+	case "", CommentPolicyEveryone, CommentPolicyFollowers, CommentPolicyNobody:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeCommentPolicy returns the comment policy, everyone can comment on posts without one
+func NormalizeCommentPolicy(commentPolicy string) string {
+	if commentPolicy == "" {
+		return CommentPolicyEveryone
+	}
+	return commentPolicy
+}
+
+func (p *PostSetting) GetCommentPolicy() string {
+	return NormalizeCommentPolicy(p.CommentPolicy)
+}
+
+func (p *PostSetting) GetMediaType() string {
+	return NormalizeMediaType(p.MediaType)
+}
+
+func (p *PostSetting) IsPinned() bool {
+	return p.PinnedAt > 0
+}
+
+// IsDefault tells whether the setting has nothing worth storing
+func (p *PostSetting) IsDefault() bool {
+	return p.ContentWarning == "" && p.GetMediaType() == MediaTypeImage && p.GetAudience() == AudiencePublic && !p.Archived && !p.IsPinned() &&
+		p.GetCommentPolicy() == CommentPolicyEveryone
+}
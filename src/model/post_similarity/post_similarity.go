@@ -0,0 +1,8 @@
+package post_similarity
+
+type PostSimilarity struct {
+	ID            uint    `json:"id"`
+	PostID        uint    `json:"post_id" gorm:"uniqueIndex:idx_post_similarity"`
+	SimilarPostID uint    `json:"similar_post_id" gorm:"uniqueIndex:idx_post_similarity"`
+	Score         float64 `json:"score"`
+}
@@ -0,0 +1,47 @@
+package post_term
+
+const (
+	KindHashtag = "hashtag"
+	KindMention = "mention"
+)
+
+// PostTerm is a hashtag or a mention used in post's description or in one of its comments.
+// Hashtags are stored lowercased with leading #, mentions are stored as lowercased usernames.
+type PostTerm struct {
+	ID     uint   `json:"id"`
+	Kind   string `json:"kind" gorm:"index:idx_post_term_kind_term;size:16"`
+	Term   string `json:"term" gorm:"index:idx_post_term_kind_term;size:255"`
+	PostID uint   `json:"post_id" gorm:"index"`
+	// Zero for terms of post's description
+	CommentID uint   `json:"comment_id"`
+	UserEmail string `json:"user_email" gorm:"size:255"`
+	Date      int64  `json:"date" gorm:"index"`
+}
+
+// TermCount is the number of uses of a term in a window and in the baseline windows preceding it
+type TermCount struct {
+	Term     string
+	Current  int64
+	Baseline int64
+}
+
+func NewPostTerms(hashtags []string, mentions []string, postID uint, commentID uint, userEmail string, date int64) []PostTerm {
+	terms := make([]PostTerm, 0, len(hashtags)+len(mentions))
+	newTerm := func(kind string, term string) PostTerm {
+		return PostTerm{
+			Kind:      kind,
+			Term:      term,
+			PostID:    postID,
+			CommentID: commentID,
+			UserEmail: userEmail,
+			Date:      date,
+		}
+	}
+	for _, hashtag := range hashtags {
+		terms = append(terms, newTerm(KindHashtag, hashtag))
+	}
+	for _, mention := range mentions {
+		terms = append(terms, newTerm(KindMention, mention))
+	}
+	return terms
+}
@@ -0,0 +1,15 @@
+package reaction
+
+const (
+	ReactionLike    = "like"
+	ReactionDislike = "dislike"
+)
+
+// Reaction records when a like or dislike was given, likes and dislikes themselves carry no date
+type Reaction struct {
+	ID        uint   `json:"id"`
+	PostID    uint   `json:"post_id" gorm:"index"`
+	UserEmail string `json:"user_email" gorm:"size:255"`
+	Kind      string `json:"kind" gorm:"size:16"`
+	Date      int64  `json:"date"`
+}
@@ -0,0 +1,8 @@
+package report
+
+type Report struct {
+	ID     uint   `json:"id"`
+	PostID uint   `json:"post_id"`
+	Reason string `json:"reason"`
+	Date   int64  `json:"date"`
+}
@@ -0,0 +1,49 @@
+package scheduled_post
+
+// ScheduledPost is a post whose media is already saved, waiting to be published at PublishAt.
+// It is visible only to its author until the scheduler turns it into a post.
+type ScheduledPost struct {
+	ID          uint   `json:"id"`
+	Description string `json:"description"`
+	UserEmail   string `json:"user_email" gorm:"index;size:255"`
+	MediaID     uint   `json:"media_id"`
+	// Ordered media of a carousel post, empty for posts with a single media
+	Media                 []ScheduledPostMedia `json:"-" gorm:"foreignKey:ScheduledPostID"`
+	MarkedAsInappropriate bool                 `json:"marked_as_inappropriate"`
+	ContentWarning        string               `json:"content_warning"`
+	MediaType             string               `json:"media_type"`
+	Audience              string               `json:"audience"`
+	CommentPolicy         string               `json:"comment_policy"`
+	PublishAt             int64                `json:"publish_at" gorm:"index"`
+	Date                  int64                `json:"date"`
+	// Posts which could not be validated when due are retried from RetryAt on, so they do not hold back other due posts
+	RetryAt        int64 `json:"-" gorm:"index"`
+	FailedAttempts int   `json:"-"`
+}
+
+func (s *ScheduledPost) GetMediaIDs() []uint {
+	if len(s.Media) == 0 {
+		return []uint{s.MediaID}
+	}
+
+	mediaIDs := make([]uint, 0, len(s.Media))
+	for _, media := range s.Media {
+		mediaIDs = append(mediaIDs, media.MediaID)
+	}
+	return mediaIDs
+}
+
+func (s *ScheduledPost) SetMediaIDs(mediaIDs []uint) {
+	s.MediaID = mediaIDs[0]
+	s.Media = nil
+	if len(mediaIDs) == 1 {
+		return
+	}
+
+	for position, mediaID := range mediaIDs {
+		s.Media = append(s.Media, ScheduledPostMedia{
+			MediaID:  mediaID,
+			Position: position,
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package scheduled_post
+
+// ScheduledPostMedia is an item of a scheduled carousel post, it becomes post's media once the post is published
+type ScheduledPostMedia struct {
+	ID              uint `json:"id"`
+	ScheduledPostID uint `json:"scheduled_post_id" gorm:"uniqueIndex:idx_scheduled_post_media_position"`
+	MediaID         uint `json:"media_id"`
+	Position        int  `json:"position" gorm:"uniqueIndex:idx_scheduled_post_media_position"`
+}
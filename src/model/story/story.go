@@ -0,0 +1,13 @@
+package story
+
+// Story is a media visible to author's followers until it expires.
+// Archived stories are kept for their author after expiry so they can be shown in highlights.
+type Story struct {
+	ID        uint   `json:"id"`
+	UserEmail string `json:"user_email" gorm:"index;size:255"`
+	MediaID   uint   `json:"media_id"`
+	MediaType string `json:"media_type"`
+	Date      int64  `json:"date"`
+	ExpiresAt int64  `json:"expires_at" gorm:"index"`
+	Archived  bool   `json:"archived"`
+}
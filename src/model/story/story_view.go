@@ -0,0 +1,9 @@
+package story
+
+// StoryView records the first time a user saw a story
+type StoryView struct {
+	ID        uint   `json:"id"`
+	StoryID   uint   `json:"story_id" gorm:"uniqueIndex:idx_story_view"`
+	UserEmail string `json:"user_email" gorm:"uniqueIndex:idx_story_view;size:255"`
+	Date      int64  `json:"date"`
+}
@@ -0,0 +1,56 @@
+package author_restriction
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type AuthorRestrictionRepository interface {
+	GetAll() []author_restriction.AuthorRestriction
+	GetByUser(string) (*author_restriction.AuthorRestriction, rest_error.RestErr)
+	Save(*author_restriction.AuthorRestriction) rest_error.RestErr
+	Delete(*author_restriction.AuthorRestriction) rest_error.RestErr
+}
+
+type authorRestrictionsRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorRestrictionRepository(databaseClient datasources.DatabaseClient) AuthorRestrictionRepository {
+	return &authorRestrictionsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (a *authorRestrictionsRepository) GetAll() []author_restriction.AuthorRestriction {
+	var collection []author_restriction.AuthorRestriction
+	if err := a.db.Find(&collection).Error; err != nil {
+		return []author_restriction.AuthorRestriction{}
+	}
+	return collection
+}
+
+func (a *authorRestrictionsRepository) GetByUser(userEmail string) (*author_restriction.AuthorRestriction, rest_error.RestErr) {
+	var restriction author_restriction.AuthorRestriction
+	if err := a.db.Where("user_email = ?", userEmail).First(&restriction).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("User %s is not restricted", userEmail))
+	}
+	return &restriction, nil
+}
+
+func (a *authorRestrictionsRepository) Save(restriction *author_restriction.AuthorRestriction) rest_error.RestErr {
+	if err := a.db.Save(restriction).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to save author restriction", err)
+	}
+	return nil
+}
+
+func (a *authorRestrictionsRepository) Delete(restriction *author_restriction.AuthorRestriction) rest_error.RestErr {
+	if err := a.db.Delete(restriction).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to delete author restriction", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package author_restriction
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuthorRestrictionRepositoryMock struct {
+	mock.Mock
+}
+
+func (a *AuthorRestrictionRepositoryMock) GetAll() []author_restriction.AuthorRestriction {
+	args := a.Called()
+	return args.Get(0).([]author_restriction.AuthorRestriction)
+}
+
+func (a *AuthorRestrictionRepositoryMock) GetByUser(userEmail string) (*author_restriction.AuthorRestriction, rest_error.RestErr) {
+	args := a.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).(*author_restriction.AuthorRestriction), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (a *AuthorRestrictionRepositoryMock) Save(restriction *author_restriction.AuthorRestriction) rest_error.RestErr {
+	args := a.Called(restriction)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (a *AuthorRestrictionRepositoryMock) Delete(restriction *author_restriction.AuthorRestriction) rest_error.RestErr {
+	args := a.Called(restriction)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
@@ -0,0 +1,50 @@
+package banned_media
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type BannedMediaRepository interface {
+	Create(*banned_media.BannedMedia) rest_error.RestErr
+	GetByBands([]uint64) ([]banned_media.BannedMedia, rest_error.RestErr)
+}
+
+type bannedMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewBannedMediaRepository(databaseClient datasources.DatabaseClient) BannedMediaRepository {
+	return &bannedMediaRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (b *bannedMediaRepository) Create(bannedMedia *banned_media.BannedMedia) rest_error.RestErr {
+	if err := b.db.Create(bannedMedia).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to ban media", err)
+	}
+	return nil
+}
+
+// GetByBands returns banned media sharing at least one hash band with given bands
+func (b *bannedMediaRepository) GetByBands(bands []uint64) ([]banned_media.BannedMedia, rest_error.RestErr) {
+	var collection []banned_media.BannedMedia
+	if len(bands) == 0 {
+		return collection, nil
+	}
+
+	matching := b.db.Where("band = ? AND value = ?", 0, bands[0])
+	for band := 1; band < len(bands); band++ {
+		matching = matching.Or("band = ? AND value = ?", band, bands[band])
+	}
+	candidates := b.db.Model(&banned_media.BannedMediaBand{}).Select("banned_media_id").Where(matching)
+
+	if err := b.db.Where("id IN (?)", candidates).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get banned media", err)
+	}
+
+	return collection, nil
+}
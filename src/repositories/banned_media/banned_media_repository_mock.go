@@ -0,0 +1,27 @@
+package banned_media
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type BannedMediaRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *BannedMediaRepositoryMock) Create(bannedMedia *banned_media.BannedMedia) rest_error.RestErr {
+	args := b.Called(bannedMedia)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (b *BannedMediaRepositoryMock) GetByBands(bands []uint64) ([]banned_media.BannedMedia, rest_error.RestErr) {
+	args := b.Called(bands)
+	if args.Get(1) == nil {
+		return args.Get(0).([]banned_media.BannedMedia), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,107 @@
+package campaign
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CampaignRepository interface {
+	Get(uint) (*campaign.Campaign, rest_error.RestErr)
+	GetActive(int64) ([]campaign.Campaign, rest_error.RestErr)
+	GetByAgent(string) ([]campaign.Campaign, rest_error.RestErr)
+	CountDeliveries(uint, string, int64) (int64, rest_error.RestErr)
+	RecordDelivery(uint, string, int64) rest_error.RestErr
+	GetDeliveryStats([]uint) (map[uint]campaign.DeliveryStats, rest_error.RestErr)
+}
+
+type campaignsRepository struct {
+	db *gorm.DB
+}
+
+func NewCampaignRepository(databaseClient datasources.DatabaseClient) CampaignRepository {
+	return &campaignsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (c *campaignsRepository) Get(id uint) (*campaign.Campaign, rest_error.RestErr) {
+	var campaignEntity campaign.Campaign
+	if err := c.db.Take(&campaignEntity, id).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get campaign with id %d", id))
+	}
+	return &campaignEntity, nil
+}
+
+func (c *campaignsRepository) GetActive(now int64) ([]campaign.Campaign, rest_error.RestErr) {
+	var collection []campaign.Campaign
+
+	if err := c.db.Where("start_date <= ? AND end_date > ?", now, now).Order("start_date asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get active campaigns", err)
+	}
+
+	return collection, nil
+}
+
+func (c *campaignsRepository) GetByAgent(agentEmail string) ([]campaign.Campaign, rest_error.RestErr) {
+	var collection []campaign.Campaign
+
+	if err := c.db.Where("agent_email = ?", agentEmail).Order("start_date desc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get agent's campaigns", err)
+	}
+
+	return collection, nil
+}
+
+func (c *campaignsRepository) CountDeliveries(campaignID uint, userEmail string, day int64) (int64, rest_error.RestErr) {
+	var delivery campaign.CampaignDelivery
+
+	err := c.db.Where("campaign_id = ? AND user_email = ? AND day = ?", campaignID, userEmail, day).Limit(1).Find(&delivery).Error
+	if err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count campaign deliveries", err)
+	}
+
+	return delivery.Count, nil
+}
+
+func (c *campaignsRepository) RecordDelivery(campaignID uint, userEmail string, day int64) rest_error.RestErr {
+	delivery := campaign.CampaignDelivery{
+		CampaignID: campaignID,
+		UserEmail:  userEmail,
+		Day:        day,
+		Count:      1,
+	}
+
+	if err := c.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "campaign_id"}, {Name: "user_email"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("count + 1")}),
+	}).Create(&delivery).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to record campaign delivery", err)
+	}
+	return nil
+}
+
+// GetDeliveryStats returns delivery stats of given campaigns, campaigns never delivered are left out
+func (c *campaignsRepository) GetDeliveryStats(campaignIDs []uint) (map[uint]campaign.DeliveryStats, rest_error.RestErr) {
+	stats := make(map[uint]campaign.DeliveryStats)
+	if len(campaignIDs) == 0 {
+		return stats, nil
+	}
+
+	var rows []campaign.DeliveryStats
+	if err := c.db.Model(&campaign.CampaignDelivery{}).
+		Select("campaign_id, SUM(count) AS deliveries, COUNT(DISTINCT user_email) AS reach").
+		Where("campaign_id IN ?", campaignIDs).
+		Group("campaign_id").
+		Scan(&rows).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get campaign delivery stats", err)
+	}
+
+	for _, row := range rows {
+		stats[row.CampaignID] = row
+	}
+	return stats, nil
+}
@@ -0,0 +1,55 @@
+package campaign
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type CampaignRepositoryMock struct {
+	mock.Mock
+}
+
+func (c *CampaignRepositoryMock) Get(id uint) (*campaign.Campaign, rest_error.RestErr) {
+	panic("implement me")
+}
+
+func (c *CampaignRepositoryMock) GetActive(now int64) ([]campaign.Campaign, rest_error.RestErr) {
+	args := c.Called(now)
+	if args.Get(1) == nil {
+		return args.Get(0).([]campaign.Campaign), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CampaignRepositoryMock) GetByAgent(agentEmail string) ([]campaign.Campaign, rest_error.RestErr) {
+	args := c.Called(agentEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]campaign.Campaign), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CampaignRepositoryMock) CountDeliveries(campaignID uint, userEmail string, day int64) (int64, rest_error.RestErr) {
+	args := c.Called(campaignID, userEmail, day)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CampaignRepositoryMock) RecordDelivery(campaignID uint, userEmail string, day int64) rest_error.RestErr {
+	args := c.Called(campaignID, userEmail, day)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (c *CampaignRepositoryMock) GetDeliveryStats(campaignIDs []uint) (map[uint]campaign.DeliveryStats, rest_error.RestErr) {
+	args := c.Called(campaignIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[uint]campaign.DeliveryStats), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,60 @@
+package close_friend
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CloseFriendRepository interface {
+	Add(*close_friend.CloseFriend) rest_error.RestErr
+	Remove(string, string) rest_error.RestErr
+	GetByUser(string) ([]close_friend.CloseFriend, rest_error.RestErr)
+	IsCloseFriend(string, string) (bool, rest_error.RestErr)
+}
+
+type closeFriendsRepository struct {
+	db *gorm.DB
+}
+
+func NewCloseFriendRepository(databaseClient datasources.DatabaseClient) CloseFriendRepository {
+	return &closeFriendsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (c *closeFriendsRepository) Add(closeFriend *close_friend.CloseFriend) rest_error.RestErr {
+	if err := c.db.Clauses(clause.OnConflict{DoNothing: true}).Create(closeFriend).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to add a close friend", err)
+	}
+	return nil
+}
+
+func (c *closeFriendsRepository) Remove(userEmail string, friendEmail string) rest_error.RestErr {
+	if err := c.db.Where("user_email = ? AND friend_email = ?", userEmail, friendEmail).Delete(&close_friend.CloseFriend{}).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to remove a close friend", err)
+	}
+	return nil
+}
+
+func (c *closeFriendsRepository) GetByUser(userEmail string) ([]close_friend.CloseFriend, rest_error.RestErr) {
+	var collection []close_friend.CloseFriend
+
+	if err := c.db.Where("user_email = ?", userEmail).Order("date desc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get close friends", err)
+	}
+
+	return collection, nil
+}
+
+func (c *closeFriendsRepository) IsCloseFriend(userEmail string, friendEmail string) (bool, rest_error.RestErr) {
+	var count int64
+
+	if err := c.db.Model(&close_friend.CloseFriend{}).Where("user_email = ? AND friend_email = ?", userEmail, friendEmail).Count(&count).Error; err != nil {
+		return false, rest_error.NewInternalServerError("Error when trying to check close friends", err)
+	}
+
+	return count > 0, nil
+}
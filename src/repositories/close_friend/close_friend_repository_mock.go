@@ -0,0 +1,43 @@
+package close_friend
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type CloseFriendRepositoryMock struct {
+	mock.Mock
+}
+
+func (c *CloseFriendRepositoryMock) Add(closeFriend *close_friend.CloseFriend) rest_error.RestErr {
+	args := c.Called(closeFriend)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (c *CloseFriendRepositoryMock) Remove(userEmail string, friendEmail string) rest_error.RestErr {
+	args := c.Called(userEmail, friendEmail)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (c *CloseFriendRepositoryMock) GetByUser(userEmail string) ([]close_friend.CloseFriend, rest_error.RestErr) {
+	args := c.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]close_friend.CloseFriend), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CloseFriendRepositoryMock) IsCloseFriend(userEmail string, friendEmail string) (bool, rest_error.RestErr) {
+	args := c.Called(userEmail, friendEmail)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(rest_error.RestErr)
+}
@@ -1,6 +1,9 @@
 package comment
 
 import (
+	"database/sql"
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
 	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/comment"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
@@ -10,6 +13,19 @@ import (
 type CommentRepository interface {
 	Create(*comment.Comment) rest_error.RestErr
 	GetComments(uint) ([]comment.Comment, rest_error.RestErr)
+	Get(uint) (*comment.Comment, rest_error.RestErr)
+	Delete(*comment.Comment) rest_error.RestErr
+	CountDuplicates(string, string, uint, int64) (int64, rest_error.RestErr)
+	CountUsersCommentsSince(string, int64) (int64, rest_error.RestErr)
+	GetUsersFirstCommentDate(string) (int64, rest_error.RestErr)
+	CountByUserOnAuthor(string, string) (int64, rest_error.RestErr)
+	CountByPosts([]uint) (map[uint]int64, rest_error.RestErr)
+	GetByPostsBetween([]uint, int64, int64) ([]comment.Comment, rest_error.RestErr)
+}
+
+type postCount struct {
+	PostID uint
+	Count  int64
 }
 
 type commentsRepository struct {
@@ -38,3 +54,104 @@ func (c *commentsRepository) GetComments(postID uint) ([]comment.Comment, rest_e
 
 	return collection, nil
 }
+
+func (c *commentsRepository) Get(id uint) (*comment.Comment, rest_error.RestErr) {
+	commentEntity := comment.Comment{
+		ID: id,
+	}
+	if err := c.db.Take(&commentEntity, commentEntity.ID).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get comment with id %d", commentEntity.ID))
+	}
+	return &commentEntity, nil
+}
+
+func (c *commentsRepository) Delete(comment *comment.Comment) rest_error.RestErr {
+	if err := c.db.Delete(comment).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to delete a comment", err)
+	}
+	return nil
+}
+
+func (c *commentsRepository) CountDuplicates(userEmail string, text string, postID uint, since int64) (int64, rest_error.RestErr) {
+	var count int64
+
+	if err := c.db.Model(&comment.Comment{}).
+		Where("user_email = ? AND text = ? AND post_id <> ? AND date >= ?", userEmail, text, postID, since).
+		Count(&count).Error; err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count duplicate comments", err)
+	}
+
+	return count, nil
+}
+
+func (c *commentsRepository) CountUsersCommentsSince(userEmail string, since int64) (int64, rest_error.RestErr) {
+	var count int64
+
+	if err := c.db.Model(&comment.Comment{}).Where("user_email = ? AND date >= ?", userEmail, since).Count(&count).Error; err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count user's comments", err)
+	}
+
+	return count, nil
+}
+
+func (c *commentsRepository) GetUsersFirstCommentDate(userEmail string) (int64, rest_error.RestErr) {
+	var date sql.NullInt64
+
+	if err := c.db.Model(&comment.Comment{}).Select("MIN(date)").Where("user_email = ?", userEmail).Row().Scan(&date); err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to get user's first comment", err)
+	}
+
+	return date.Int64, nil
+}
+
+func (c *commentsRepository) CountByUserOnAuthor(userEmail string, authorEmail string) (int64, rest_error.RestErr) {
+	var count int64
+
+	if err := c.db.Model(&comment.Comment{}).
+		Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("comments.user_email = ? AND posts.user_email = ?", userEmail, authorEmail).
+		Count(&count).Error; err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count user's comments on author's posts", err)
+	}
+
+	return count, nil
+}
+
+// CountByPosts counts comments of given posts, quarantined comments are not counted
+func (c *commentsRepository) CountByPosts(postIDs []uint) (map[uint]int64, rest_error.RestErr) {
+	counts := make(map[uint]int64)
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []postCount
+	if err := c.db.Model(&comment.Comment{}).
+		Select("post_id, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Where("id NOT IN (?)", c.db.Model(&comment_review.CommentReview{}).Select("comment_id")).
+		Group("post_id").
+		Scan(&rows).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to count posts' comments", err)
+	}
+
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetByPostsBetween returns comments of given posts posted in the period, quarantined comments are left out
+func (c *commentsRepository) GetByPostsBetween(postIDs []uint, from int64, to int64) ([]comment.Comment, rest_error.RestErr) {
+	var collection []comment.Comment
+	if len(postIDs) == 0 {
+		return collection, nil
+	}
+
+	if err := c.db.Where("post_id IN ? AND date >= ? AND date < ?", postIDs, from, to).
+		Where("id NOT IN (?)", c.db.Model(&comment_review.CommentReview{}).Select("comment_id")).
+		Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get comments", err)
+	}
+
+	return collection, nil
+}
@@ -19,5 +19,65 @@ func (c *CommentRepositoryMock) Create(comment *comment.Comment) rest_error.Rest
 }
 
 func (c *CommentRepositoryMock) GetComments(u uint) ([]comment.Comment, rest_error.RestErr) {
+	args := c.Called(u)
+	if args.Get(1) == nil {
+		return args.Get(0).([]comment.Comment), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentRepositoryMock) Get(id uint) (*comment.Comment, rest_error.RestErr) {
+	panic("implement me")
+}
+
+func (c *CommentRepositoryMock) Delete(comment *comment.Comment) rest_error.RestErr {
 	panic("implement me")
 }
+
+func (c *CommentRepositoryMock) CountDuplicates(userEmail string, text string, postID uint, since int64) (int64, rest_error.RestErr) {
+	args := c.Called(userEmail, text, postID, since)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentRepositoryMock) CountUsersCommentsSince(userEmail string, since int64) (int64, rest_error.RestErr) {
+	args := c.Called(userEmail, since)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentRepositoryMock) GetUsersFirstCommentDate(userEmail string) (int64, rest_error.RestErr) {
+	args := c.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentRepositoryMock) CountByUserOnAuthor(userEmail string, authorEmail string) (int64, rest_error.RestErr) {
+	args := c.Called(userEmail, authorEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return 0, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentRepositoryMock) CountByPosts(postIDs []uint) (map[uint]int64, rest_error.RestErr) {
+	args := c.Called(postIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[uint]int64), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentRepositoryMock) GetByPostsBetween(postIDs []uint, from int64, to int64) ([]comment.Comment, rest_error.RestErr) {
+	args := c.Called(postIDs, from, to)
+	if args.Get(1) == nil {
+		return args.Get(0).([]comment.Comment), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,80 @@
+package comment_review
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type CommentReviewRepository interface {
+	Create(*comment_review.CommentReview) rest_error.RestErr
+	GetByComment(uint) (*comment_review.CommentReview, rest_error.RestErr)
+	GetByPost(uint) ([]comment_review.CommentReview, rest_error.RestErr)
+	GetByPostAuthor(string) ([]comment_review.CommentReview, rest_error.RestErr)
+	GetAll() []comment_review.CommentReview
+	Delete(*comment_review.CommentReview) rest_error.RestErr
+}
+
+type commentReviewsRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentReviewRepository(databaseClient datasources.DatabaseClient) CommentReviewRepository {
+	return &commentReviewsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (c *commentReviewsRepository) Create(review *comment_review.CommentReview) rest_error.RestErr {
+	if err := c.db.Create(review).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to quarantine a comment", err)
+	}
+	return nil
+}
+
+func (c *commentReviewsRepository) GetByComment(commentID uint) (*comment_review.CommentReview, rest_error.RestErr) {
+	var review comment_review.CommentReview
+	if err := c.db.Where("comment_id = ?", commentID).First(&review).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Comment with id %d is not quarantined", commentID))
+	}
+	return &review, nil
+}
+
+func (c *commentReviewsRepository) GetByPost(postID uint) ([]comment_review.CommentReview, rest_error.RestErr) {
+	var collection []comment_review.CommentReview
+
+	if err := c.db.Where("post_id = ?", postID).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get post's quarantined comments", err)
+	}
+
+	return collection, nil
+}
+
+func (c *commentReviewsRepository) GetByPostAuthor(userEmail string) ([]comment_review.CommentReview, rest_error.RestErr) {
+	var collection []comment_review.CommentReview
+
+	if err := c.db.Joins("JOIN posts ON posts.id = comment_reviews.post_id").
+		Where("posts.user_email = ?", userEmail).
+		Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get quarantined comments", err)
+	}
+
+	return collection, nil
+}
+
+func (c *commentReviewsRepository) GetAll() []comment_review.CommentReview {
+	var collection []comment_review.CommentReview
+	if err := c.db.Find(&collection).Error; err != nil {
+		return []comment_review.CommentReview{}
+	}
+	return collection
+}
+
+func (c *commentReviewsRepository) Delete(review *comment_review.CommentReview) rest_error.RestErr {
+	if err := c.db.Delete(review).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to release a comment", err)
+	}
+	return nil
+}
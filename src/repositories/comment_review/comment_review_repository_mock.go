@@ -0,0 +1,43 @@
+package comment_review
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type CommentReviewRepositoryMock struct {
+	mock.Mock
+}
+
+func (c *CommentReviewRepositoryMock) Create(review *comment_review.CommentReview) rest_error.RestErr {
+	args := c.Called(review)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (c *CommentReviewRepositoryMock) GetByComment(commentID uint) (*comment_review.CommentReview, rest_error.RestErr) {
+	panic("implement me")
+}
+
+func (c *CommentReviewRepositoryMock) GetByPost(postID uint) ([]comment_review.CommentReview, rest_error.RestErr) {
+	args := c.Called(postID)
+	if args.Get(1) == nil {
+		return args.Get(0).([]comment_review.CommentReview), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (c *CommentReviewRepositoryMock) GetByPostAuthor(userEmail string) ([]comment_review.CommentReview, rest_error.RestErr) {
+	panic("implement me")
+}
+
+func (c *CommentReviewRepositoryMock) GetAll() []comment_review.CommentReview {
+	panic("implement me")
+}
+
+func (c *CommentReviewRepositoryMock) Delete(review *comment_review.CommentReview) rest_error.RestErr {
+	panic("implement me")
+}
@@ -2,6 +2,8 @@ package dislike
 
 import (
 	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/time_utils"
 	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/dislike"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
@@ -36,15 +38,38 @@ func (d *dislikesRepository) GetByUserAndPost(userEmail string, postId uint) (*d
 	return &dislikeEntity, nil
 }
 
-func (d *dislikesRepository) Create(dislike *dislike.Dislike) rest_error.RestErr {
-	if err := d.db.Create(dislike).Error; err != nil {
+// Create stores the dislike together with the reaction recording when it was given
+func (d *dislikesRepository) Create(dislikeEntity *dislike.Dislike) rest_error.RestErr {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dislikeEntity).Error; err != nil {
+			return err
+		}
+
+		reactionEntity := reaction.Reaction{
+			PostID:    dislikeEntity.PostID,
+			UserEmail: dislikeEntity.UserEmail,
+			Kind:      reaction.ReactionDislike,
+			Date:      time_utils.Now(),
+		}
+		return tx.Create(&reactionEntity).Error
+	})
+	if err != nil {
 		return rest_error.NewInternalServerError("Error when trying to dislike a post", err)
 	}
 	return nil
 }
 
-func (d *dislikesRepository) Delete(dislike *dislike.Dislike) rest_error.RestErr {
-	if err := d.db.Where("user_email = ? AND post_id = ?", dislike.UserEmail, dislike.PostID).Delete(dislike).Error; err != nil {
+// Delete removes the dislike together with its reaction
+func (d *dislikesRepository) Delete(dislikeEntity *dislike.Dislike) rest_error.RestErr {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_email = ? AND post_id = ?", dislikeEntity.UserEmail, dislikeEntity.PostID).Delete(dislikeEntity).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("post_id = ? AND user_email = ? AND kind = ?", dislikeEntity.PostID, dislikeEntity.UserEmail, reaction.ReactionDislike).
+			Delete(&reaction.Reaction{}).Error
+	})
+	if err != nil {
 		return rest_error.NewInternalServerError("Error when trying to undislike a post", err)
 	}
 	return nil
@@ -31,5 +31,9 @@ func (d *DislikeRepositoryMock) Delete(d2 *dislike.Dislike) rest_error.RestErr {
 }
 
 func (d *DislikeRepositoryMock) GetNumberOfDislikes(u uint) (int64, rest_error.RestErr) {
-	panic("implement me")
+	args := d.Called(u)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return 0, args.Get(1).(rest_error.RestErr)
 }
\ No newline at end of file
@@ -0,0 +1,66 @@
+package draft
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type DraftRepository interface {
+	Create(*draft.Draft) rest_error.RestErr
+	Get(uint) (*draft.Draft, rest_error.RestErr)
+	GetByUser(string) ([]draft.Draft, rest_error.RestErr)
+	Update(*draft.Draft) rest_error.RestErr
+	Delete(*draft.Draft) rest_error.RestErr
+}
+
+type draftsRepository struct {
+	db *gorm.DB
+}
+
+func NewDraftRepository(databaseClient datasources.DatabaseClient) DraftRepository {
+	return &draftsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (d *draftsRepository) Create(draft *draft.Draft) rest_error.RestErr {
+	if err := d.db.Create(draft).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to create a draft", err)
+	}
+	return nil
+}
+
+func (d *draftsRepository) Get(id uint) (*draft.Draft, rest_error.RestErr) {
+	var draftEntity draft.Draft
+	if err := d.db.Take(&draftEntity, id).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get draft with id %d", id))
+	}
+	return &draftEntity, nil
+}
+
+func (d *draftsRepository) GetByUser(userEmail string) ([]draft.Draft, rest_error.RestErr) {
+	var collection []draft.Draft
+
+	if err := d.db.Where("user_email = ?", userEmail).Order("updated desc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get drafts", err)
+	}
+
+	return collection, nil
+}
+
+func (d *draftsRepository) Update(draft *draft.Draft) rest_error.RestErr {
+	if err := d.db.Save(draft).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to update a draft", err)
+	}
+	return nil
+}
+
+func (d *draftsRepository) Delete(draft *draft.Draft) rest_error.RestErr {
+	if err := d.db.Delete(draft).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to delete a draft", err)
+	}
+	return nil
+}
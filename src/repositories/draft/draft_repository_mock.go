@@ -0,0 +1,51 @@
+package draft
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type DraftRepositoryMock struct {
+	mock.Mock
+}
+
+func (d *DraftRepositoryMock) Create(draft *draft.Draft) rest_error.RestErr {
+	args := d.Called(draft)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (d *DraftRepositoryMock) Get(id uint) (*draft.Draft, rest_error.RestErr) {
+	args := d.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*draft.Draft), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (d *DraftRepositoryMock) GetByUser(userEmail string) ([]draft.Draft, rest_error.RestErr) {
+	args := d.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]draft.Draft), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (d *DraftRepositoryMock) Update(draft *draft.Draft) rest_error.RestErr {
+	args := d.Called(draft)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (d *DraftRepositoryMock) Delete(draft *draft.Draft) rest_error.RestErr {
+	args := d.Called(draft)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
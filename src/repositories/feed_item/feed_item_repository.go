@@ -0,0 +1,47 @@
+package feed_item
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const createBatchSize = 500
+
+type FeedItemRepository interface {
+	CreateMany([]feed_item.FeedItem) rest_error.RestErr
+	GetByUser(string, int) ([]feed_item.FeedItem, rest_error.RestErr)
+}
+
+type feedItemsRepository struct {
+	db *gorm.DB
+}
+
+func NewFeedItemRepository(databaseClient datasources.DatabaseClient) FeedItemRepository {
+	return &feedItemsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (f *feedItemsRepository) CreateMany(items []feed_item.FeedItem) rest_error.RestErr {
+	if len(items) == 0 {
+		return nil
+	}
+	// Items already in user's feed are skipped
+	if err := f.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&items, createBatchSize).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to add posts to feed", err)
+	}
+	return nil
+}
+
+func (f *feedItemsRepository) GetByUser(userEmail string, limit int) ([]feed_item.FeedItem, rest_error.RestErr) {
+	var collection []feed_item.FeedItem
+
+	if err := f.db.Where("user_email = ?", userEmail).Order("date desc").Limit(limit).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get user's feed", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,27 @@
+package feed_item
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type FeedItemRepositoryMock struct {
+	mock.Mock
+}
+
+func (f *FeedItemRepositoryMock) CreateMany(items []feed_item.FeedItem) rest_error.RestErr {
+	args := f.Called(items)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (f *FeedItemRepositoryMock) GetByUser(userEmail string, limit int) ([]feed_item.FeedItem, rest_error.RestErr) {
+	args := f.Called(userEmail, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]feed_item.FeedItem), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
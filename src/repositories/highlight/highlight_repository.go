@@ -0,0 +1,125 @@
+package highlight
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/highlight"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type HighlightRepository interface {
+	Create(*highlight.Highlight, []uint) rest_error.RestErr
+	Get(uint) (*highlight.Highlight, rest_error.RestErr)
+	GetByUser(string) ([]highlight.Highlight, rest_error.RestErr)
+	GetStories([]uint) ([]highlight.HighlightStory, rest_error.RestErr)
+	Update(*highlight.Highlight, []uint) rest_error.RestErr
+	Delete(*highlight.Highlight) rest_error.RestErr
+}
+
+type highlightsRepository struct {
+	db *gorm.DB
+}
+
+func NewHighlightRepository(databaseClient datasources.DatabaseClient) HighlightRepository {
+	return &highlightsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+// setStories replaces highlight's stories and archives them so they outlive their expiry
+func setStories(tx *gorm.DB, highlightID uint, storyIDs []uint) error {
+	if err := tx.Where("highlight_id = ?", highlightID).Delete(&highlight.HighlightStory{}).Error; err != nil {
+		return err
+	}
+
+	items := make([]highlight.HighlightStory, 0, len(storyIDs))
+	for position, storyID := range storyIDs {
+		items = append(items, highlight.HighlightStory{
+			HighlightID: highlightID,
+			StoryID:     storyID,
+			Position:    position,
+		})
+	}
+	if err := tx.Create(&items).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&story.Story{}).Where("id IN ?", storyIDs).Update("archived", true).Error
+}
+
+func (h *highlightsRepository) Create(highlightEntity *highlight.Highlight, storyIDs []uint) rest_error.RestErr {
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(highlightEntity).Error; err != nil {
+			return err
+		}
+		return setStories(tx, highlightEntity.ID, storyIDs)
+	})
+
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to create a highlight", err)
+	}
+	return nil
+}
+
+func (h *highlightsRepository) Get(id uint) (*highlight.Highlight, rest_error.RestErr) {
+	var highlightEntity highlight.Highlight
+	if err := h.db.Take(&highlightEntity, id).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get highlight with id %d", id))
+	}
+	return &highlightEntity, nil
+}
+
+func (h *highlightsRepository) GetByUser(userEmail string) ([]highlight.Highlight, rest_error.RestErr) {
+	var collection []highlight.Highlight
+
+	if err := h.db.Where("user_email = ?", userEmail).Order("date asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get highlights", err)
+	}
+
+	return collection, nil
+}
+
+// GetStories returns stories of given highlights ordered by highlight and position
+func (h *highlightsRepository) GetStories(highlightIDs []uint) ([]highlight.HighlightStory, rest_error.RestErr) {
+	var collection []highlight.HighlightStory
+	if len(highlightIDs) == 0 {
+		return collection, nil
+	}
+
+	if err := h.db.Where("highlight_id IN ?", highlightIDs).Order("highlight_id asc, position asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get highlight's stories", err)
+	}
+
+	return collection, nil
+}
+
+func (h *highlightsRepository) Update(highlightEntity *highlight.Highlight, storyIDs []uint) rest_error.RestErr {
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(highlightEntity).Error; err != nil {
+			return err
+		}
+		return setStories(tx, highlightEntity.ID, storyIDs)
+	})
+
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to update a highlight", err)
+	}
+	return nil
+}
+
+// Delete removes a highlight, its stories stay archived
+func (h *highlightsRepository) Delete(highlightEntity *highlight.Highlight) rest_error.RestErr {
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("highlight_id = ?", highlightEntity.ID).Delete(&highlight.HighlightStory{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(highlightEntity).Error
+	})
+
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to delete a highlight", err)
+	}
+	return nil
+}
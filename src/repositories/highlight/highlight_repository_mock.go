@@ -0,0 +1,59 @@
+package highlight
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/highlight"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type HighlightRepositoryMock struct {
+	mock.Mock
+}
+
+func (h *HighlightRepositoryMock) Create(highlightEntity *highlight.Highlight, storyIDs []uint) rest_error.RestErr {
+	args := h.Called(highlightEntity, storyIDs)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (h *HighlightRepositoryMock) Get(id uint) (*highlight.Highlight, rest_error.RestErr) {
+	args := h.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*highlight.Highlight), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (h *HighlightRepositoryMock) GetByUser(userEmail string) ([]highlight.Highlight, rest_error.RestErr) {
+	args := h.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]highlight.Highlight), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (h *HighlightRepositoryMock) GetStories(highlightIDs []uint) ([]highlight.HighlightStory, rest_error.RestErr) {
+	args := h.Called(highlightIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).([]highlight.HighlightStory), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (h *HighlightRepositoryMock) Update(highlightEntity *highlight.Highlight, storyIDs []uint) rest_error.RestErr {
+	args := h.Called(highlightEntity, storyIDs)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (h *HighlightRepositoryMock) Delete(highlightEntity *highlight.Highlight) rest_error.RestErr {
+	args := h.Called(highlightEntity)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
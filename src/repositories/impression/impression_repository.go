@@ -0,0 +1,113 @@
+package impression
+
+import (
+	"database/sql"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/impression"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImpressionRepository interface {
+	Record([]impression.Impression) (int64, rest_error.RestErr)
+	CountByPost(uint) (int64, rest_error.RestErr)
+	CountByPosts([]uint) (map[uint]int64, rest_error.RestErr)
+	GetByPosts([]uint, int64, int64) ([]impression.Impression, rest_error.RestErr)
+}
+
+type postCount struct {
+	PostID uint
+	Count  int64
+}
+
+type impressionsRepository struct {
+	db *gorm.DB
+}
+
+func NewImpressionRepository(databaseClient datasources.DatabaseClient) ImpressionRepository {
+	return &impressionsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+// Record stores impressions not seen yet on their day and increments daily counters, it returns the number of new impressions
+func (i *impressionsRepository) Record(impressions []impression.Impression) (int64, rest_error.RestErr) {
+	var recorded int64
+
+	err := i.db.Transaction(func(tx *gorm.DB) error {
+		for index := range impressions {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&impressions[index])
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			recorded++
+
+			count := impression.ImpressionCount{
+				PostID: impressions[index].PostID,
+				Day:    impressions[index].Day,
+				Count:  1,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "post_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("count + 1")}),
+			}).Create(&count).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return 0, rest_error.NewInternalServerError("Error when trying to record impressions", err)
+	}
+	return recorded, nil
+}
+
+func (i *impressionsRepository) CountByPost(postID uint) (int64, rest_error.RestErr) {
+	var count sql.NullInt64
+
+	if err := i.db.Model(&impression.ImpressionCount{}).Select("SUM(count)").Where("post_id = ?", postID).Row().Scan(&count); err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count post's views", err)
+	}
+
+	return count.Int64, nil
+}
+
+func (i *impressionsRepository) CountByPosts(postIDs []uint) (map[uint]int64, rest_error.RestErr) {
+	counts := make(map[uint]int64)
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []postCount
+	if err := i.db.Model(&impression.ImpressionCount{}).
+		Select("post_id, SUM(count) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id").
+		Scan(&rows).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to count posts' views", err)
+	}
+
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetByPosts returns impressions of given posts on days which overlap the period
+func (i *impressionsRepository) GetByPosts(postIDs []uint, from int64, to int64) ([]impression.Impression, rest_error.RestErr) {
+	var collection []impression.Impression
+	if len(postIDs) == 0 {
+		return collection, nil
+	}
+
+	if err := i.db.Where("post_id IN ? AND day > ? AND day < ?", postIDs, from-24*3600, to).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get impressions", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,43 @@
+package impression
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/impression"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type ImpressionRepositoryMock struct {
+	mock.Mock
+}
+
+func (i *ImpressionRepositoryMock) Record(impressions []impression.Impression) (int64, rest_error.RestErr) {
+	args := i.Called(impressions)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return 0, args.Get(1).(rest_error.RestErr)
+}
+
+func (i *ImpressionRepositoryMock) CountByPost(postID uint) (int64, rest_error.RestErr) {
+	args := i.Called(postID)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
+func (i *ImpressionRepositoryMock) CountByPosts(postIDs []uint) (map[uint]int64, rest_error.RestErr) {
+	args := i.Called(postIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[uint]int64), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (i *ImpressionRepositoryMock) GetByPosts(postIDs []uint, from int64, to int64) ([]impression.Impression, rest_error.RestErr) {
+	args := i.Called(postIDs, from, to)
+	if args.Get(1) == nil {
+		return args.Get(0).([]impression.Impression), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
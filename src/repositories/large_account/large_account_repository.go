@@ -0,0 +1,47 @@
+package large_account
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/large_account"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LargeAccountRepository interface {
+	Save(*large_account.LargeAccount) rest_error.RestErr
+	GetByUsers([]string) ([]large_account.LargeAccount, rest_error.RestErr)
+}
+
+type largeAccountsRepository struct {
+	db *gorm.DB
+}
+
+func NewLargeAccountRepository(databaseClient datasources.DatabaseClient) LargeAccountRepository {
+	return &largeAccountsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (l *largeAccountsRepository) Save(account *large_account.LargeAccount) rest_error.RestErr {
+	if err := l.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"followers", "date"}),
+	}).Create(account).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to save large account", err)
+	}
+	return nil
+}
+
+func (l *largeAccountsRepository) GetByUsers(userEmails []string) ([]large_account.LargeAccount, rest_error.RestErr) {
+	var collection []large_account.LargeAccount
+	if len(userEmails) == 0 {
+		return collection, nil
+	}
+
+	if err := l.db.Where("user_email IN ?", userEmails).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get large accounts", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,27 @@
+package large_account
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/large_account"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type LargeAccountRepositoryMock struct {
+	mock.Mock
+}
+
+func (l *LargeAccountRepositoryMock) Save(account *large_account.LargeAccount) rest_error.RestErr {
+	args := l.Called(account)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (l *LargeAccountRepositoryMock) GetByUsers(userEmails []string) ([]large_account.LargeAccount, rest_error.RestErr) {
+	args := l.Called(userEmails)
+	if args.Get(1) == nil {
+		return args.Get(0).([]large_account.LargeAccount), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
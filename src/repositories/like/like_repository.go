@@ -2,6 +2,9 @@ package like
 
 import (
 	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/time_utils"
 	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/like"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
@@ -13,6 +16,15 @@ type LikeRepository interface {
 	GetByUserAndPost(string, uint) (*like.Like, rest_error.RestErr)
 	Delete(*like.Like) rest_error.RestErr
 	GetNumberOfLikes(uint) (int64, rest_error.RestErr)
+	CountByUserOnAuthor(string, string) (int64, rest_error.RestErr)
+	CountByPosts([]uint) (map[uint]int64, rest_error.RestErr)
+	GetRecentByPosts([]uint, int) ([]like.Like, rest_error.RestErr)
+	HasLikedHashtags(string, []string) (bool, rest_error.RestErr)
+}
+
+type postCount struct {
+	PostID uint
+	Count  int64
 }
 
 type likesRepository struct {
@@ -28,7 +40,7 @@ func NewLikeRepository(databaseClient datasources.DatabaseClient) LikeRepository
 func (l *likesRepository) GetByUserAndPost(userEmail string, postId uint) (*like.Like, rest_error.RestErr) {
 	likeEntity := like.Like{
 		UserEmail: userEmail,
-		PostID: postId,
+		PostID:    postId,
 	}
 	if err := l.db.Where("user_email = ? AND post_id = ?", userEmail, postId).First(&likeEntity).Error; err != nil {
 		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Post has not been liked by user"))
@@ -36,15 +48,38 @@ func (l *likesRepository) GetByUserAndPost(userEmail string, postId uint) (*like
 	return &likeEntity, nil
 }
 
-func (l *likesRepository) Create(like *like.Like) rest_error.RestErr {
-	if err := l.db.Create(like).Error; err != nil {
+// Create stores the like together with the reaction recording when it was given
+func (l *likesRepository) Create(likeEntity *like.Like) rest_error.RestErr {
+	err := l.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(likeEntity).Error; err != nil {
+			return err
+		}
+
+		reactionEntity := reaction.Reaction{
+			PostID:    likeEntity.PostID,
+			UserEmail: likeEntity.UserEmail,
+			Kind:      reaction.ReactionLike,
+			Date:      time_utils.Now(),
+		}
+		return tx.Create(&reactionEntity).Error
+	})
+	if err != nil {
 		return rest_error.NewInternalServerError("Error when trying to like a post", err)
 	}
 	return nil
 }
 
-func (l *likesRepository) Delete(like *like.Like) rest_error.RestErr {
-	if err := l.db.Where("user_email = ? AND post_id = ?", like.UserEmail, like.PostID).Delete(like).Error; err != nil {
+// Delete removes the like together with its reaction
+func (l *likesRepository) Delete(likeEntity *like.Like) rest_error.RestErr {
+	err := l.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_email = ? AND post_id = ?", likeEntity.UserEmail, likeEntity.PostID).Delete(likeEntity).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("post_id = ? AND user_email = ? AND kind = ?", likeEntity.PostID, likeEntity.UserEmail, reaction.ReactionLike).
+			Delete(&reaction.Reaction{}).Error
+	})
+	if err != nil {
 		return rest_error.NewInternalServerError("Error when trying to unlike a post", err)
 	}
 	return nil
@@ -57,3 +92,74 @@ func (l *likesRepository) GetNumberOfLikes(postID uint) (int64, rest_error.RestE
 	}
 	return numberOfLikes, nil
 }
+
+func (l *likesRepository) CountByUserOnAuthor(userEmail string, authorEmail string) (int64, rest_error.RestErr) {
+	var count int64
+
+	if err := l.db.Model(&like.Like{}).
+		Joins("JOIN posts ON posts.id = likes.post_id").
+		Where("likes.user_email = ? AND posts.user_email = ?", userEmail, authorEmail).
+		Count(&count).Error; err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count user's likes on author's posts", err)
+	}
+
+	return count, nil
+}
+
+func (l *likesRepository) CountByPosts(postIDs []uint) (map[uint]int64, rest_error.RestErr) {
+	counts := make(map[uint]int64)
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []postCount
+	if err := l.db.Model(&like.Like{}).
+		Select("post_id, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id").
+		Scan(&rows).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to count posts' likes", err)
+	}
+
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetRecentByPosts returns at most limit latest likes of given posts, newest first
+func (l *likesRepository) GetRecentByPosts(postIDs []uint, limit int) ([]like.Like, rest_error.RestErr) {
+	var collection []like.Like
+	if len(postIDs) == 0 {
+		return collection, nil
+	}
+
+	if err := l.db.Where("post_id IN ?", postIDs).Order("id desc").Limit(limit).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get posts' likes", err)
+	}
+
+	return collection, nil
+}
+
+// HasLikedHashtags checks whether user liked any post whose description uses one of the hashtags (given lowercased without #)
+func (l *likesRepository) HasLikedHashtags(userEmail string, hashtags []string) (bool, rest_error.RestErr) {
+	if len(hashtags) == 0 {
+		return false, nil
+	}
+
+	terms := make([]string, 0, len(hashtags))
+	for _, hashtag := range hashtags {
+		terms = append(terms, "#"+hashtag)
+	}
+
+	var count int64
+	if err := l.db.Model(&like.Like{}).
+		Joins("JOIN post_terms ON post_terms.post_id = likes.post_id").
+		Where("likes.user_email = ? AND post_terms.comment_id = 0 AND post_terms.kind = ? AND post_terms.term IN ?", userEmail, post_term.KindHashtag, terms).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, rest_error.NewInternalServerError("Error when trying to check user's liked hashtags", err)
+	}
+
+	return count > 0, nil
+}
@@ -31,5 +31,41 @@ func (l *LikeRepositoryMock) Delete(l2 *like.Like) rest_error.RestErr {
 }
 
 func (l *LikeRepositoryMock) GetNumberOfLikes(u uint) (int64, rest_error.RestErr) {
-	panic("implement me")
-}
\ No newline at end of file
+	args := l.Called(u)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return 0, args.Get(1).(rest_error.RestErr)
+}
+
+func (l *LikeRepositoryMock) CountByUserOnAuthor(userEmail string, authorEmail string) (int64, rest_error.RestErr) {
+	args := l.Called(userEmail, authorEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return 0, args.Get(1).(rest_error.RestErr)
+}
+
+func (l *LikeRepositoryMock) CountByPosts(postIDs []uint) (map[uint]int64, rest_error.RestErr) {
+	args := l.Called(postIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[uint]int64), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (l *LikeRepositoryMock) GetRecentByPosts(postIDs []uint, limit int) ([]like.Like, rest_error.RestErr) {
+	args := l.Called(postIDs, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]like.Like), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (l *LikeRepositoryMock) HasLikedHashtags(userEmail string, hashtags []string) (bool, rest_error.RestErr) {
+	args := l.Called(userEmail, hashtags)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(rest_error.RestErr)
+}
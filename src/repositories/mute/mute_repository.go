@@ -0,0 +1,49 @@
+package mute
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MuteRepository interface {
+	Create(*mute.Mute) rest_error.RestErr
+	Delete(string, string) rest_error.RestErr
+	GetByUser(string) ([]mute.Mute, rest_error.RestErr)
+}
+
+type mutesRepository struct {
+	db *gorm.DB
+}
+
+func NewMuteRepository(databaseClient datasources.DatabaseClient) MuteRepository {
+	return &mutesRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (m *mutesRepository) Create(muteEntity *mute.Mute) rest_error.RestErr {
+	if err := m.db.Clauses(clause.OnConflict{DoNothing: true}).Create(muteEntity).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to mute a user", err)
+	}
+	return nil
+}
+
+func (m *mutesRepository) Delete(userEmail string, mutedEmail string) rest_error.RestErr {
+	if err := m.db.Where("user_email = ? AND muted_email = ?", userEmail, mutedEmail).Delete(&mute.Mute{}).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to unmute a user", err)
+	}
+	return nil
+}
+
+func (m *mutesRepository) GetByUser(userEmail string) ([]mute.Mute, rest_error.RestErr) {
+	var collection []mute.Mute
+
+	if err := m.db.Where("user_email = ?", userEmail).Order("date desc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get muted users", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,35 @@
+package mute
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type MuteRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *MuteRepositoryMock) Create(muteEntity *mute.Mute) rest_error.RestErr {
+	args := m.Called(muteEntity)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (m *MuteRepositoryMock) Delete(userEmail string, mutedEmail string) rest_error.RestErr {
+	args := m.Called(userEmail, mutedEmail)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (m *MuteRepositoryMock) GetByUser(userEmail string) ([]mute.Mute, rest_error.RestErr) {
+	args := m.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]mute.Mute), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
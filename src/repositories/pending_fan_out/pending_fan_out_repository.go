@@ -0,0 +1,48 @@
+package pending_fan_out
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/pending_fan_out"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PendingFanOutRepository interface {
+	Save(*pending_fan_out.PendingFanOut) rest_error.RestErr
+	GetAll() ([]pending_fan_out.PendingFanOut, rest_error.RestErr)
+	Delete(*pending_fan_out.PendingFanOut) rest_error.RestErr
+}
+
+type pendingFanOutsRepository struct {
+	db *gorm.DB
+}
+
+func NewPendingFanOutRepository(databaseClient datasources.DatabaseClient) PendingFanOutRepository {
+	return &pendingFanOutsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (p *pendingFanOutsRepository) Save(pendingFanOut *pending_fan_out.PendingFanOut) rest_error.RestErr {
+	// Posts already waiting for fan-out are skipped
+	if err := p.db.Clauses(clause.OnConflict{DoNothing: true}).Create(pendingFanOut).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to save pending fan-out", err)
+	}
+	return nil
+}
+
+func (p *pendingFanOutsRepository) GetAll() ([]pending_fan_out.PendingFanOut, rest_error.RestErr) {
+	var collection []pending_fan_out.PendingFanOut
+	if err := p.db.Order("date asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get pending fan-outs", err)
+	}
+	return collection, nil
+}
+
+func (p *pendingFanOutsRepository) Delete(pendingFanOut *pending_fan_out.PendingFanOut) rest_error.RestErr {
+	if err := p.db.Where("post_id = ?", pendingFanOut.PostID).Delete(&pending_fan_out.PendingFanOut{}).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to delete pending fan-out", err)
+	}
+	return nil
+}
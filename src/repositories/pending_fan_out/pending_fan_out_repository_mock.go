@@ -0,0 +1,35 @@
+package pending_fan_out
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/pending_fan_out"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type PendingFanOutRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *PendingFanOutRepositoryMock) Save(pendingFanOut *pending_fan_out.PendingFanOut) rest_error.RestErr {
+	args := p.Called(pendingFanOut)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (p *PendingFanOutRepositoryMock) GetAll() ([]pending_fan_out.PendingFanOut, rest_error.RestErr) {
+	args := p.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]pending_fan_out.PendingFanOut), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PendingFanOutRepositoryMock) Delete(pendingFanOut *pending_fan_out.PendingFanOut) rest_error.RestErr {
+	args := p.Called(pendingFanOut)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
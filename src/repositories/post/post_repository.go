@@ -1,7 +1,16 @@
 package post
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/report"
 	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
@@ -11,12 +20,46 @@ import (
 type PostRepository interface {
 	GetAll() []post.Post
 	Get(uint) (*post.Post, rest_error.RestErr)
+	GetUnarchivedByIDs([]uint) ([]post.Post, rest_error.RestErr)
 	Update(*post.Post) rest_error.RestErr
 	Create(*post.Post) rest_error.RestErr
+	Publish(*Publication) rest_error.RestErr
 	GetUsersPosts(string) ([]post.Post, rest_error.RestErr)
+	GetProfilePosts(string, bool) ([]post.Post, rest_error.RestErr)
+	CountUsersPostsSince(string, int64) (int64, rest_error.RestErr)
+	GetPublishedSince(int64) ([]post.Post, rest_error.RestErr)
+	GetUsersFirstPostDate(string) (int64, rest_error.RestErr)
 	GetInappropriateContent() []post.Post
 	Delete(*post.Post) rest_error.RestErr
 	SearchByTag(string) ([]post.Post, rest_error.RestErr)
+	GetModerationQueue(ModerationQueueQuery) ([]post.Post, int64, rest_error.RestErr)
+	ApplyModerationDecisions([]post.Post, []post.Post, []banned_media.BannedMedia) rest_error.RestErr
+}
+
+// ModerationQueueQuery filters posts marked as inappropriate, zero values mean the filter is not applied
+type ModerationQueueQuery struct {
+	Author     string
+	Reason     string
+	MinReports int64
+	// Posts must be published before this timestamp
+	PublishedBefore int64
+	// Posts must be published after this timestamp
+	PublishedAfter int64
+	Offset         int
+	Limit          int
+}
+
+// Publication is a new post with rows which are stored or removed together with it
+type Publication struct {
+	Post *post.Post
+	// Ordered media of a carousel post
+	Media []post_media.PostMedia
+	// Settings of a post which does not use the default ones
+	Setting *post_setting.PostSetting
+	// Campaign of a sponsored post
+	Campaign *campaign.Campaign
+	// Draft the post is published from
+	Draft *draft.Draft
 }
 
 type postsRepository struct {
@@ -47,6 +90,29 @@ func (p *postsRepository) Get(id uint) (*post.Post, rest_error.RestErr) {
 	return &postEntity, nil
 }
 
+// GetUnarchivedByIDs returns posts with given ids, leaving out archived ones
+func (p *postsRepository) GetUnarchivedByIDs(ids []uint) ([]post.Post, rest_error.RestErr) {
+	var collection []post.Post
+	if len(ids) == 0 {
+		return collection, nil
+	}
+
+	if err := p.withArchived(false).Where("posts.id IN ?", ids).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get posts", err)
+	}
+
+	return collection, nil
+}
+
+// withArchived joins post settings and keeps either archived posts or the rest, posts without settings are not archived
+func (p *postsRepository) withArchived(archived bool) *gorm.DB {
+	query := p.db.Model(&post.Post{}).Joins("LEFT JOIN post_settings ON post_settings.post_id = posts.id")
+	if archived {
+		return query.Where("post_settings.archived = ?", true)
+	}
+	return query.Where("post_settings.id IS NULL OR post_settings.archived = ?", false)
+}
+
 func (p *postsRepository) GetUsersPosts(userEmail string) ([]post.Post, rest_error.RestErr) {
 	var collection []post.Post
 
@@ -57,6 +123,48 @@ func (p *postsRepository) GetUsersPosts(userEmail string) ([]post.Post, rest_err
 	return collection, nil
 }
 
+// GetProfilePosts returns either user's archived posts or the ones shown on their profile
+func (p *postsRepository) GetProfilePosts(userEmail string, archived bool) ([]post.Post, rest_error.RestErr) {
+	var collection []post.Post
+
+	if err := p.withArchived(archived).Where("posts.user_email = ?", userEmail).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get user's posts", err)
+	}
+
+	return collection, nil
+}
+
+func (p *postsRepository) CountUsersPostsSince(userEmail string, since int64) (int64, rest_error.RestErr) {
+	var count int64
+
+	if err := p.db.Model(&post.Post{}).Where("user_email = ? AND date >= ?", userEmail, since).Count(&count).Error; err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to count user's posts", err)
+	}
+
+	return count, nil
+}
+
+// GetPublishedSince returns posts published after given date which are neither waiting for moderation nor archived
+func (p *postsRepository) GetPublishedSince(since int64) ([]post.Post, rest_error.RestErr) {
+	var collection []post.Post
+
+	if err := p.withArchived(false).Where("posts.date >= ? AND posts.marked_as_inappropriate = ?", since, false).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get published posts", err)
+	}
+
+	return collection, nil
+}
+
+func (p *postsRepository) GetUsersFirstPostDate(userEmail string) (int64, rest_error.RestErr) {
+	var date sql.NullInt64
+
+	if err := p.db.Model(&post.Post{}).Select("MIN(date)").Where("user_email = ?", userEmail).Row().Scan(&date); err != nil {
+		return -1, rest_error.NewInternalServerError("Error when trying to get user's first post", err)
+	}
+
+	return date.Int64, nil
+}
+
 func (p *postsRepository) Create(post *post.Post) rest_error.RestErr {
 	if err := p.db.Create(post).Error; err != nil {
 		return rest_error.NewInternalServerError("Error when trying to create post", err)
@@ -64,6 +172,58 @@ func (p *postsRepository) Create(post *post.Post) rest_error.RestErr {
 	return nil
 }
 
+// Publish stores a new post together with its media, settings and campaign and removes the draft it is published from in one transaction.
+// A draft which is already gone has been published by another request.
+func (p *postsRepository) Publish(publication *Publication) rest_error.RestErr {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(publication.Post).Error; err != nil {
+			return err
+		}
+
+		if len(publication.Media) != 0 {
+			for i := range publication.Media {
+				publication.Media[i].PostID = publication.Post.ID
+			}
+			if err := tx.Create(&publication.Media).Error; err != nil {
+				return err
+			}
+		}
+
+		if publication.Setting != nil {
+			publication.Setting.PostID = publication.Post.ID
+			if err := tx.Create(publication.Setting).Error; err != nil {
+				return err
+			}
+		}
+
+		if publication.Campaign != nil {
+			publication.Campaign.PostID = publication.Post.ID
+			if err := tx.Create(publication.Campaign).Error; err != nil {
+				return err
+			}
+		}
+
+		if publication.Draft != nil {
+			result := tx.Delete(publication.Draft)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return rest_error.NewNotFoundError(fmt.Sprintf("Draft with id %d has already been published", publication.Draft.ID))
+	}
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to publish post", err)
+	}
+	return nil
+}
+
 func (p *postsRepository) Update(post *post.Post) rest_error.RestErr {
 	if err := p.db.Save(post).Error; err != nil {
 		return rest_error.NewInternalServerError("Error when trying to update post", err)
@@ -80,21 +240,123 @@ func (p *postsRepository) GetInappropriateContent() []post.Post {
 }
 
 func (p *postsRepository) Delete(post *post.Post) rest_error.RestErr {
-	if err := p.db.Delete(post).Error; err != nil {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(post).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("post_id = ?", post.ID).Delete(&post_media.PostMedia{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("post_id = ?", post.ID).Delete(&feed_item.FeedItem{}).Error
+	})
+
+	if err != nil {
 		return rest_error.NewInternalServerError("Error when trying to delete a post", err)
 	}
 	return nil
 }
 
+// SearchByTag returns posts mentioning the user, archived posts are left out
 func (p *postsRepository) SearchByTag(tag string) ([]post.Post, rest_error.RestErr) {
 	var posts []post.Post
-	sqlStatement := "select * from posts where description like '%@" + tag + "%'"
 
-	tx := p.db.Raw(sqlStatement).Scan(&posts)
+	tx := p.withArchived(false).Where("posts.description LIKE ?", "%@"+tag+"%").Find(&posts)
 
 	if tx.Error != nil {
 		return nil, rest_error.NewInternalServerError("Error when trying to search by tag", tx.Error)
 	}
 
 	return posts, nil
-}
\ No newline at end of file
+}
+func (p *postsRepository) moderationQueueQuery(filter ModerationQueueQuery) *gorm.DB {
+	query := p.db.Model(&post.Post{}).Where("marked_as_inappropriate = ?", true)
+
+	if filter.Author != "" {
+		query = query.Where("user_email = ?", filter.Author)
+	}
+	if filter.Reason != "" {
+		query = query.Where("id IN (?)", p.db.Model(&report.Report{}).Select("post_id").Where("reason = ?", filter.Reason))
+	}
+	if filter.MinReports > 0 {
+		query = query.Where("(SELECT COUNT(*) FROM reports WHERE reports.post_id = posts.id) >= ?", filter.MinReports)
+	}
+	if filter.PublishedBefore > 0 {
+		query = query.Where("date <= ?", filter.PublishedBefore)
+	}
+	if filter.PublishedAfter > 0 {
+		query = query.Where("date >= ?", filter.PublishedAfter)
+	}
+
+	return query
+}
+
+func (p *postsRepository) GetModerationQueue(filter ModerationQueueQuery) ([]post.Post, int64, rest_error.RestErr) {
+	var total int64
+	if err := p.moderationQueueQuery(filter).Count(&total).Error; err != nil {
+		return nil, 0, rest_error.NewInternalServerError("Error when trying to count moderation queue", err)
+	}
+
+	var collection []post.Post
+	if err := p.moderationQueueQuery(filter).
+		Order("date asc").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&collection).Error; err != nil {
+		return nil, 0, rest_error.NewInternalServerError("Error when trying to get moderation queue", err)
+	}
+
+	return collection, total, nil
+}
+
+func (p *postsRepository) ApplyModerationDecisions(kept []post.Post, deleted []post.Post, bannedMedia []banned_media.BannedMedia) rest_error.RestErr {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var decided []uint
+
+		for i := range kept {
+			kept[i].MarkedAsInappropriate = false
+			if err := tx.Save(&kept[i]).Error; err != nil {
+				return err
+			}
+			decided = append(decided, kept[i].ID)
+		}
+
+		for i := range deleted {
+			if err := tx.Delete(&deleted[i]).Error; err != nil {
+				return err
+			}
+			decided = append(decided, deleted[i].ID)
+		}
+
+		if len(bannedMedia) != 0 {
+			if err := tx.Create(&bannedMedia).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(deleted) != 0 {
+			var deletedIDs []uint
+			for _, deletedPost := range deleted {
+				deletedIDs = append(deletedIDs, deletedPost.ID)
+			}
+			if err := tx.Where("post_id IN ?", deletedIDs).Delete(&feed_item.FeedItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("post_id IN ?", deletedIDs).Delete(&post_media.PostMedia{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(decided) != 0 {
+			if err := tx.Where("post_id IN ?", decided).Delete(&report.Report{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to apply moderation decisions", err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package post
 
 import (
 	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
 	"github.com/stretchr/testify/mock"
@@ -19,6 +20,30 @@ func (p *PostRepositoryMock) Create(postEntity *post.Post) rest_error.RestErr {
 	return args.Get(0).(rest_error.RestErr)
 }
 
+func (p *PostRepositoryMock) Publish(publication *Publication) rest_error.RestErr {
+	args := p.Called(publication)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (p *PostRepositoryMock) GetUnarchivedByIDs(ids []uint) ([]post.Post, rest_error.RestErr) {
+	args := p.Called(ids)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post.Post), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PostRepositoryMock) GetPublishedSince(since int64) ([]post.Post, rest_error.RestErr) {
+	args := p.Called(since)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post.Post), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
 func (p *PostRepositoryMock) GetUsersPosts(userEmail string) ([]post.Post, rest_error.RestErr) {
 	args := p.Called(userEmail)
 	if args.Get(1) == nil {
@@ -27,6 +52,30 @@ func (p *PostRepositoryMock) GetUsersPosts(userEmail string) ([]post.Post, rest_
 	return nil, args.Get(1).(rest_error.RestErr)
 }
 
+func (p *PostRepositoryMock) GetProfilePosts(userEmail string, archived bool) ([]post.Post, rest_error.RestErr) {
+	args := p.Called(userEmail, archived)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post.Post), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PostRepositoryMock) CountUsersPostsSince(userEmail string, since int64) (int64, rest_error.RestErr) {
+	args := p.Called(userEmail, since)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PostRepositoryMock) GetUsersFirstPostDate(userEmail string) (int64, rest_error.RestErr) {
+	args := p.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return -1, args.Get(1).(rest_error.RestErr)
+}
+
 func (p *PostRepositoryMock) Get(u uint) (*post.Post, rest_error.RestErr) {
 	args := p.Called(u)
 	fmt.Println(args.Get(1))
@@ -52,6 +101,26 @@ func (p *PostRepositoryMock) Delete(p2 *post.Post) rest_error.RestErr {
 	panic("implement me")
 }
 
-func (p *PostRepositoryMock) SearchByTag(s string) ([]post.Post, rest_error.RestErr) {
-	panic("implement me")
-}
\ No newline at end of file
+func (p *PostRepositoryMock) SearchByTag(tag string) ([]post.Post, rest_error.RestErr) {
+	args := p.Called(tag)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post.Post), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PostRepositoryMock) GetModerationQueue(filter ModerationQueueQuery) ([]post.Post, int64, rest_error.RestErr) {
+	args := p.Called(filter)
+	if args.Get(2) == nil {
+		return args.Get(0).([]post.Post), args.Get(1).(int64), nil
+	}
+	return nil, 0, args.Get(2).(rest_error.RestErr)
+}
+
+func (p *PostRepositoryMock) ApplyModerationDecisions(kept []post.Post, deleted []post.Post, bannedMedia []banned_media.BannedMedia) rest_error.RestErr {
+	args := p.Called(kept, deleted, bannedMedia)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
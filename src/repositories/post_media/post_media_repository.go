@@ -0,0 +1,36 @@
+package post_media
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type PostMediaRepository interface {
+	GetByPosts([]uint) ([]post_media.PostMedia, rest_error.RestErr)
+}
+
+type postMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewPostMediaRepository(databaseClient datasources.DatabaseClient) PostMediaRepository {
+	return &postMediaRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+// GetByPosts returns media of given posts ordered by post and position
+func (p *postMediaRepository) GetByPosts(postIDs []uint) ([]post_media.PostMedia, rest_error.RestErr) {
+	var collection []post_media.PostMedia
+	if len(postIDs) == 0 {
+		return collection, nil
+	}
+
+	if err := p.db.Where("post_id IN ?", postIDs).Order("post_id asc, position asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get post's media", err)
+	}
+
+	return collection, nil
+}
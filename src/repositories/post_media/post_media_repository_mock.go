@@ -0,0 +1,19 @@
+package post_media
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type PostMediaRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *PostMediaRepositoryMock) GetByPosts(postIDs []uint) ([]post_media.PostMedia, rest_error.RestErr) {
+	args := p.Called(postIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post_media.PostMedia), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,104 @@
+package post_setting
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"net/http"
+)
+
+type PostSettingRepository interface {
+	GetByPost(uint) (*post_setting.PostSetting, rest_error.RestErr)
+	Update(*post_setting.PostSetting, ...string) rest_error.RestErr
+	Pin(*post_setting.PostSetting, string, int64) rest_error.RestErr
+}
+
+// MySQL error number of an insert violating a unique index
+const duplicateEntryError = 1062
+
+var errPinLimitReached = errors.New("pinned posts limit reached")
+
+type postSettingsRepository struct {
+	db *gorm.DB
+}
+
+func NewPostSettingRepository(databaseClient datasources.DatabaseClient) PostSettingRepository {
+	return &postSettingsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (p *postSettingsRepository) GetByPost(postID uint) (*post_setting.PostSetting, rest_error.RestErr) {
+	var setting post_setting.PostSetting
+	if err := p.db.Where("post_id = ?", postID).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, rest_error.NewNotFoundError(fmt.Sprintf("Post with id %d has no settings", postID))
+		}
+		return nil, rest_error.NewInternalServerError("Error when trying to get post settings", err)
+	}
+	return &setting, nil
+}
+
+// Update stores given columns of post's settings, so concurrent changes of other columns are kept.
+// Posts which have no settings yet get them inserted, settings inserted concurrently for the same post are reported as a conflict.
+func (p *postSettingsRepository) Update(setting *post_setting.PostSetting, columns ...string) rest_error.RestErr {
+	var err error
+	if setting.ID == 0 {
+		err = p.db.Create(setting).Error
+	} else {
+		err = p.db.Model(setting).Select(columns).Updates(setting).Error
+	}
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return rest_error.NewRestError("Post settings were changed at the same time, try again", http.StatusConflict, "conflict", nil)
+		}
+		return rest_error.NewInternalServerError("Error when trying to save post settings", err)
+	}
+	return nil
+}
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntryError
+}
+
+// Pin pins a post to its author's profile unless they have already pinned limit posts.
+// Author's posts are locked while pinned posts are counted, so concurrent pins can not go over the limit.
+func (p *postSettingsRepository) Pin(setting *post_setting.PostSetting, userEmail string, limit int64) rest_error.RestErr {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Model(&post.Post{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_email = ?", userEmail).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		var pinned int64
+		if err := tx.Model(&post_setting.PostSetting{}).
+			Where("post_id IN ? AND pinned_at > ?", ids, 0).
+			Count(&pinned).Error; err != nil {
+			return err
+		}
+		if pinned >= limit {
+			return errPinLimitReached
+		}
+
+		// Posts without settings get them inserted, settings inserted meanwhile only get pinned
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"pinned_at"}),
+		}).Create(setting).Error
+	})
+	if errors.Is(err, errPinLimitReached) {
+		return rest_error.NewBadRequestError(fmt.Sprintf("Only %d posts can be pinned", limit))
+	}
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to pin a post", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package post_setting
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type PostSettingRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *PostSettingRepositoryMock) GetByPost(postID uint) (*post_setting.PostSetting, rest_error.RestErr) {
+	args := p.Called(postID)
+	if args.Get(1) == nil {
+		return args.Get(0).(*post_setting.PostSetting), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PostSettingRepositoryMock) Update(setting *post_setting.PostSetting, columns ...string) rest_error.RestErr {
+	args := p.Called(setting, columns)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (p *PostSettingRepositoryMock) Pin(setting *post_setting.PostSetting, userEmail string, limit int64) rest_error.RestErr {
+	args := p.Called(setting, userEmail, limit)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
@@ -0,0 +1,53 @@
+package post_similarity
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+const createBatchSize = 500
+
+type PostSimilarityRepository interface {
+	ReplaceAll([]post_similarity.PostSimilarity) rest_error.RestErr
+	GetByPost(uint, int) ([]post_similarity.PostSimilarity, rest_error.RestErr)
+}
+
+type postSimilaritiesRepository struct {
+	db *gorm.DB
+}
+
+func NewPostSimilarityRepository(databaseClient datasources.DatabaseClient) PostSimilarityRepository {
+	return &postSimilaritiesRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+// ReplaceAll swaps previously computed similarities with new ones in a single transaction
+func (p *postSimilaritiesRepository) ReplaceAll(similarities []post_similarity.PostSimilarity) rest_error.RestErr {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&post_similarity.PostSimilarity{}).Error; err != nil {
+			return err
+		}
+		if len(similarities) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&similarities, createBatchSize).Error
+	})
+
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to save post similarities", err)
+	}
+	return nil
+}
+
+func (p *postSimilaritiesRepository) GetByPost(postID uint, limit int) ([]post_similarity.PostSimilarity, rest_error.RestErr) {
+	var collection []post_similarity.PostSimilarity
+
+	if err := p.db.Where("post_id = ?", postID).Order("score desc").Limit(limit).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get similar posts", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,27 @@
+package post_similarity
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type PostSimilarityRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *PostSimilarityRepositoryMock) ReplaceAll(similarities []post_similarity.PostSimilarity) rest_error.RestErr {
+	args := p.Called(similarities)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (p *PostSimilarityRepositoryMock) GetByPost(postID uint, limit int) ([]post_similarity.PostSimilarity, rest_error.RestErr) {
+	args := p.Called(postID, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post_similarity.PostSimilarity), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,98 @@
+package post_term
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+const createBatchSize = 500
+
+// TermCountQuery selects terms of one kind used between BaselineStart and End,
+// uses after Start are counted as current and earlier ones as baseline
+type TermCountQuery struct {
+	Kind          string
+	BaselineStart int64
+	Start         int64
+	End           int64
+	// Terms used by these users are left out
+	ExcludedUsers []string
+	// Terms used on posts of these authors, in descriptions or comments, are left out
+	ExcludedPostAuthors []string
+	Limit               int
+}
+
+type PostTermRepository interface {
+	CreateMany([]post_term.PostTerm) rest_error.RestErr
+	GetPostAuthorsSince(int64) ([]string, rest_error.RestErr)
+	CountTerms(TermCountQuery) ([]post_term.TermCount, rest_error.RestErr)
+}
+
+type postTermsRepository struct {
+	db *gorm.DB
+}
+
+func NewPostTermRepository(databaseClient datasources.DatabaseClient) PostTermRepository {
+	return &postTermsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (p *postTermsRepository) CreateMany(terms []post_term.PostTerm) rest_error.RestErr {
+	if len(terms) == 0 {
+		return nil
+	}
+	if err := p.db.CreateInBatches(&terms, createBatchSize).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to save post terms", err)
+	}
+	return nil
+}
+
+func (p *postTermsRepository) GetPostAuthorsSince(since int64) ([]string, rest_error.RestErr) {
+	var authors []string
+	if err := p.db.Table("post_terms").
+		Distinct("posts.user_email").
+		Joins("JOIN posts ON posts.id = post_terms.post_id").
+		Where("post_terms.date >= ?", since).
+		Pluck("posts.user_email", &authors).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get authors of post terms", err)
+	}
+	return authors, nil
+}
+
+// CountTerms counts terms of public posts and of their comments, most used terms in current window first.
+// Terms of deleted posts and comments and of quarantined comments are not counted.
+func (p *postTermsRepository) CountTerms(filter TermCountQuery) ([]post_term.TermCount, rest_error.RestErr) {
+	query := p.db.Table("post_terms").
+		Select("post_terms.term AS term, "+
+			"SUM(CASE WHEN post_terms.date >= ? THEN 1 ELSE 0 END) AS current, "+
+			"SUM(CASE WHEN post_terms.date < ? THEN 1 ELSE 0 END) AS baseline", filter.Start, filter.Start).
+		Joins("JOIN posts ON posts.id = post_terms.post_id").
+		Joins("LEFT JOIN post_settings ON post_settings.post_id = post_terms.post_id").
+		Joins("LEFT JOIN comments ON comments.id = post_terms.comment_id").
+		Joins("LEFT JOIN comment_reviews ON comment_reviews.comment_id = post_terms.comment_id").
+		Where("post_terms.kind = ? AND post_terms.date >= ? AND post_terms.date <= ?", filter.Kind, filter.BaselineStart, filter.End).
+		Where("post_settings.id IS NULL OR ((post_settings.audience = '' OR post_settings.audience = ?) AND post_settings.archived = ?)", post_setting.AudiencePublic, false).
+		Where("post_terms.comment_id = 0 OR (comments.id IS NOT NULL AND comment_reviews.id IS NULL)")
+
+	if len(filter.ExcludedUsers) > 0 {
+		query = query.Where("post_terms.user_email NOT IN ?", filter.ExcludedUsers)
+	}
+	if len(filter.ExcludedPostAuthors) > 0 {
+		query = query.Where("posts.user_email NOT IN ?", filter.ExcludedPostAuthors)
+	}
+
+	var counts []post_term.TermCount
+	if err := query.
+		Group("post_terms.term").
+		Having("current > 0").
+		Order("current desc, post_terms.term asc").
+		Limit(filter.Limit).
+		Scan(&counts).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to count post terms", err)
+	}
+
+	return counts, nil
+}
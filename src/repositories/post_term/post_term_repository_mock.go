@@ -0,0 +1,35 @@
+package post_term
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type PostTermRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *PostTermRepositoryMock) CreateMany(terms []post_term.PostTerm) rest_error.RestErr {
+	args := p.Called(terms)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (p *PostTermRepositoryMock) GetPostAuthorsSince(since int64) ([]string, rest_error.RestErr) {
+	args := p.Called(since)
+	if args.Get(1) == nil {
+		return args.Get(0).([]string), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (p *PostTermRepositoryMock) CountTerms(filter TermCountQuery) ([]post_term.TermCount, rest_error.RestErr) {
+	args := p.Called(filter)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post_term.TermCount), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
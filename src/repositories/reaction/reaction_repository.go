@@ -0,0 +1,57 @@
+package reaction
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type ReactionRepository interface {
+	Backfill() rest_error.RestErr
+	GetByPosts([]uint, int64, int64) ([]reaction.Reaction, rest_error.RestErr)
+}
+
+type reactionsRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(databaseClient datasources.DatabaseClient) ReactionRepository {
+	return &reactionsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+// Backfill records reactions of likes and dislikes given before reactions were recorded.
+// Their date is unknown so they are dated by their post.
+func (r *reactionsRepository) Backfill() rest_error.RestErr {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for table, kind := range map[string]string{"likes": reaction.ReactionLike, "dislikes": reaction.ReactionDislike} {
+			if err := tx.Exec("INSERT INTO reactions (post_id, user_email, kind, date) "+
+				"SELECT "+table+".post_id, "+table+".user_email, ?, posts.date FROM "+table+" "+
+				"JOIN posts ON posts.id = "+table+".post_id "+
+				"WHERE NOT EXISTS (SELECT 1 FROM reactions WHERE reactions.post_id = "+table+".post_id "+
+				"AND reactions.user_email = "+table+".user_email AND reactions.kind = ?)", kind, kind).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to backfill reactions", err)
+	}
+	return nil
+}
+
+func (r *reactionsRepository) GetByPosts(postIDs []uint, from int64, to int64) ([]reaction.Reaction, rest_error.RestErr) {
+	var collection []reaction.Reaction
+	if len(postIDs) == 0 {
+		return collection, nil
+	}
+
+	if err := r.db.Where("post_id IN ? AND date >= ? AND date < ?", postIDs, from, to).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get reactions", err)
+	}
+
+	return collection, nil
+}
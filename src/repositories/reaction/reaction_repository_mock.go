@@ -0,0 +1,27 @@
+package reaction
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReactionRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ReactionRepositoryMock) Backfill() rest_error.RestErr {
+	args := r.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (r *ReactionRepositoryMock) GetByPosts(postIDs []uint, from int64, to int64) ([]reaction.Reaction, rest_error.RestErr) {
+	args := r.Called(postIDs, from, to)
+	if args.Get(1) == nil {
+		return args.Get(0).([]reaction.Reaction), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,40 @@
+package report
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/report"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+)
+
+type ReportRepository interface {
+	Create(*report.Report) rest_error.RestErr
+	GetByPost(uint) ([]report.Report, rest_error.RestErr)
+}
+
+type reportsRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(databaseClient datasources.DatabaseClient) ReportRepository {
+	return &reportsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (r *reportsRepository) Create(report *report.Report) rest_error.RestErr {
+	if err := r.db.Create(report).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to report a post", err)
+	}
+	return nil
+}
+
+func (r *reportsRepository) GetByPost(postID uint) ([]report.Report, rest_error.RestErr) {
+	var collection []report.Report
+
+	if err := r.db.Where("post_id = ?", postID).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get post's reports", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,27 @@
+package report
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/report"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ReportRepositoryMock) Create(report *report.Report) rest_error.RestErr {
+	args := r.Called(report)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (r *ReportRepositoryMock) GetByPost(postID uint) ([]report.Report, rest_error.RestErr) {
+	args := r.Called(postID)
+	if args.Get(1) == nil {
+		return args.Get(0).([]report.Report), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -0,0 +1,209 @@
+package scheduled_post
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/scheduled_post"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduledPostRepository interface {
+	Create(*scheduled_post.ScheduledPost) rest_error.RestErr
+	Get(uint) (*scheduled_post.ScheduledPost, rest_error.RestErr)
+	GetByUser(string) ([]scheduled_post.ScheduledPost, rest_error.RestErr)
+	Reschedule(uint, int64) rest_error.RestErr
+	Delete(*scheduled_post.ScheduledPost) rest_error.RestErr
+	GetDue(int64, int) ([]scheduled_post.ScheduledPost, rest_error.RestErr)
+	PublishDue(int64, map[uint]bool) ([]post.Post, rest_error.RestErr)
+	Postpone(uint, int64) rest_error.RestErr
+}
+
+type scheduledPostsRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduledPostRepository(databaseClient datasources.DatabaseClient) ScheduledPostRepository {
+	return &scheduledPostsRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+// orderedMedia preloads carousel media of scheduled posts in their order
+func orderedMedia(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc")
+}
+
+// Create stores a scheduled post together with its carousel media
+func (s *scheduledPostsRepository) Create(scheduledPost *scheduled_post.ScheduledPost) rest_error.RestErr {
+	if err := s.db.Create(scheduledPost).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to schedule a post", err)
+	}
+	return nil
+}
+
+func (s *scheduledPostsRepository) Get(id uint) (*scheduled_post.ScheduledPost, rest_error.RestErr) {
+	var scheduledPost scheduled_post.ScheduledPost
+	if err := s.db.Take(&scheduledPost, id).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get scheduled post with id %d", id))
+	}
+	return &scheduledPost, nil
+}
+
+func (s *scheduledPostsRepository) GetByUser(userEmail string) ([]scheduled_post.ScheduledPost, rest_error.RestErr) {
+	var collection []scheduled_post.ScheduledPost
+
+	if err := s.db.Preload("Media", orderedMedia).Where("user_email = ?", userEmail).Order("publish_at asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get scheduled posts", err)
+	}
+
+	return collection, nil
+}
+
+// Reschedule changes publish time of a post which has not been published yet
+func (s *scheduledPostsRepository) Reschedule(id uint, publishAt int64) rest_error.RestErr {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var scheduledPost scheduled_post.ScheduledPost
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&scheduledPost, id).Error; err != nil {
+			return err
+		}
+		return tx.Model(&scheduledPost).Updates(map[string]interface{}{
+			"publish_at":      publishAt,
+			"retry_at":        0,
+			"failed_attempts": 0,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return rest_error.NewNotFoundError(fmt.Sprintf("Scheduled post with id %d has already been published", id))
+	}
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to reschedule a post", err)
+	}
+	return nil
+}
+
+func (s *scheduledPostsRepository) Delete(scheduledPost *scheduled_post.ScheduledPost) rest_error.RestErr {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&scheduled_post.ScheduledPost{}, scheduledPost.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("scheduled_post_id = ?", scheduledPost.ID).Delete(&scheduled_post.ScheduledPostMedia{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(scheduledPost).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return rest_error.NewNotFoundError(fmt.Sprintf("Scheduled post with id %d has already been published", scheduledPost.ID))
+	}
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to cancel a scheduled post", err)
+	}
+	return nil
+}
+
+// GetDue returns at most limit posts scheduled until now, the earliest first, leaving out failed posts waiting for a retry
+func (s *scheduledPostsRepository) GetDue(now int64, limit int) ([]scheduled_post.ScheduledPost, rest_error.RestErr) {
+	var collection []scheduled_post.ScheduledPost
+
+	if err := s.db.Preload("Media", orderedMedia).Where("publish_at <= ? AND retry_at <= ?", now, now).Order("publish_at asc").Limit(limit).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get due scheduled posts", err)
+	}
+
+	return collection, nil
+}
+
+// PublishDue turns validated posts scheduled until now into published posts, flagging the ones mapped to true.
+// Due rows are locked and locked rows skipped, so replicas running the scheduler never publish the same post twice.
+func (s *scheduledPostsRepository) PublishDue(now int64, flagged map[uint]bool) ([]post.Post, rest_error.RestErr) {
+	var published []post.Post
+	if len(flagged) == 0 {
+		return published, nil
+	}
+
+	ids := make([]uint, 0, len(flagged))
+	for id := range flagged {
+		ids = append(ids, id)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var due []scheduled_post.ScheduledPost
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Media", orderedMedia).
+			Where("id IN ? AND publish_at <= ?", ids, now).
+			Order("publish_at asc").
+			Find(&due).Error; err != nil {
+			return err
+		}
+
+		for i := range due {
+			postEntity := post.Post{
+				Description:           due[i].Description,
+				UserEmail:             due[i].UserEmail,
+				MarkedAsInappropriate: flagged[due[i].ID],
+				Date:                  due[i].PublishAt,
+				MediaID:               due[i].MediaID,
+			}
+			if err := tx.Create(&postEntity).Error; err != nil {
+				return err
+			}
+
+			if mediaIDs := due[i].GetMediaIDs(); len(mediaIDs) > 1 {
+				media := make([]post_media.PostMedia, 0, len(mediaIDs))
+				for position, mediaID := range mediaIDs {
+					media = append(media, post_media.PostMedia{
+						PostID:   postEntity.ID,
+						MediaID:  mediaID,
+						Position: position,
+					})
+				}
+				if err := tx.Create(&media).Error; err != nil {
+					return err
+				}
+			}
+
+			setting := post_setting.PostSetting{
+				PostID:         postEntity.ID,
+				ContentWarning: due[i].ContentWarning,
+				MediaType:      due[i].MediaType,
+				Audience:       due[i].Audience,
+				CommentPolicy:  due[i].CommentPolicy,
+			}
+			if !setting.IsDefault() {
+				if err := tx.Create(&setting).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Where("scheduled_post_id = ?", due[i].ID).Delete(&scheduled_post.ScheduledPostMedia{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&due[i]).Error; err != nil {
+				return err
+			}
+			published = append(published, postEntity)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to publish scheduled posts", err)
+	}
+	return published, nil
+}
+
+// Postpone records a failed attempt to publish a due post and moves it out of the due posts until retryAt
+func (s *scheduledPostsRepository) Postpone(id uint, retryAt int64) rest_error.RestErr {
+	if err := s.db.Model(&scheduled_post.ScheduledPost{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"retry_at":        retryAt,
+		"failed_attempts": gorm.Expr("failed_attempts + ?", 1),
+	}).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to postpone a scheduled post", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package scheduled_post
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/scheduled_post"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type ScheduledPostRepositoryMock struct {
+	mock.Mock
+}
+
+func (s *ScheduledPostRepositoryMock) Create(scheduledPost *scheduled_post.ScheduledPost) rest_error.RestErr {
+	args := s.Called(scheduledPost)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) Get(id uint) (*scheduled_post.ScheduledPost, rest_error.RestErr) {
+	args := s.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*scheduled_post.ScheduledPost), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) GetByUser(userEmail string) ([]scheduled_post.ScheduledPost, rest_error.RestErr) {
+	args := s.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]scheduled_post.ScheduledPost), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) Reschedule(id uint, publishAt int64) rest_error.RestErr {
+	args := s.Called(id, publishAt)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) Delete(scheduledPost *scheduled_post.ScheduledPost) rest_error.RestErr {
+	args := s.Called(scheduledPost)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) GetDue(now int64, limit int) ([]scheduled_post.ScheduledPost, rest_error.RestErr) {
+	args := s.Called(now, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]scheduled_post.ScheduledPost), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) PublishDue(now int64, flagged map[uint]bool) ([]post.Post, rest_error.RestErr) {
+	args := s.Called(now, flagged)
+	if args.Get(1) == nil {
+		return args.Get(0).([]post.Post), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *ScheduledPostRepositoryMock) Postpone(id uint, retryAt int64) rest_error.RestErr {
+	args := s.Called(id, retryAt)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
@@ -0,0 +1,168 @@
+package story
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	"github.com/Nistagram-Organization/nistagram-shared/src/datasources"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StoryRepository interface {
+	Create(*story.Story) rest_error.RestErr
+	Get(uint) (*story.Story, rest_error.RestErr)
+	GetActiveByUsers([]string, int64) ([]story.Story, rest_error.RestErr)
+	RecordView(*story.StoryView) rest_error.RestErr
+	GetViews(uint) ([]story.StoryView, rest_error.RestErr)
+	GetViewedStories(string, []uint) (map[uint]bool, rest_error.RestErr)
+	GetExpired(int64) ([]story.Story, rest_error.RestErr)
+	DeleteExpired([]uint) rest_error.RestErr
+	GetByIDs([]uint) ([]story.Story, rest_error.RestErr)
+	Archive(uint) rest_error.RestErr
+	GetArchived(string) ([]story.Story, rest_error.RestErr)
+}
+
+type storiesRepository struct {
+	db *gorm.DB
+}
+
+func NewStoryRepository(databaseClient datasources.DatabaseClient) StoryRepository {
+	return &storiesRepository{
+		databaseClient.GetClient(),
+	}
+}
+
+func (s *storiesRepository) Create(story *story.Story) rest_error.RestErr {
+	if err := s.db.Create(story).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to create a story", err)
+	}
+	return nil
+}
+
+func (s *storiesRepository) Get(id uint) (*story.Story, rest_error.RestErr) {
+	var storyEntity story.Story
+	if err := s.db.Take(&storyEntity, id).Error; err != nil {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get story with id %d", id))
+	}
+	return &storyEntity, nil
+}
+
+// GetActiveByUsers returns stories of given users which have not expired, oldest first
+func (s *storiesRepository) GetActiveByUsers(userEmails []string, now int64) ([]story.Story, rest_error.RestErr) {
+	var collection []story.Story
+	if len(userEmails) == 0 {
+		return collection, nil
+	}
+
+	if err := s.db.Where("user_email IN ? AND expires_at > ?", userEmails, now).Order("date asc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get stories", err)
+	}
+
+	return collection, nil
+}
+
+func (s *storiesRepository) RecordView(view *story.StoryView) rest_error.RestErr {
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(view).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to record story view", err)
+	}
+	return nil
+}
+
+func (s *storiesRepository) GetViews(storyID uint) ([]story.StoryView, rest_error.RestErr) {
+	var collection []story.StoryView
+
+	if err := s.db.Where("story_id = ?", storyID).Order("date desc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get story views", err)
+	}
+
+	return collection, nil
+}
+
+func (s *storiesRepository) GetViewedStories(userEmail string, storyIDs []uint) (map[uint]bool, rest_error.RestErr) {
+	viewed := make(map[uint]bool)
+	if len(storyIDs) == 0 {
+		return viewed, nil
+	}
+
+	var ids []uint
+	if err := s.db.Model(&story.StoryView{}).Where("user_email = ? AND story_id IN ?", userEmail, storyIDs).Pluck("story_id", &ids).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get viewed stories", err)
+	}
+
+	for _, id := range ids {
+		viewed[id] = true
+	}
+	return viewed, nil
+}
+
+// GetExpired returns expired stories which are not archived
+func (s *storiesRepository) GetExpired(now int64) ([]story.Story, rest_error.RestErr) {
+	var collection []story.Story
+
+	if err := s.db.Where("expires_at <= ? AND archived = ?", now, false).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get expired stories", err)
+	}
+
+	return collection, nil
+}
+
+// DeleteExpired removes given expired stories with their views, stories archived in the meantime are kept
+func (s *storiesRepository) DeleteExpired(ids []uint) rest_error.RestErr {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var expired []uint
+		if err := tx.Model(&story.Story{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND archived = ?", ids, false).
+			Pluck("id", &expired).Error; err != nil {
+			return err
+		}
+		if len(expired) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("story_id IN ?", expired).Delete(&story.StoryView{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", expired).Delete(&story.Story{}).Error
+	})
+
+	if err != nil {
+		return rest_error.NewInternalServerError("Error when trying to delete expired stories", err)
+	}
+	return nil
+}
+
+func (s *storiesRepository) GetByIDs(ids []uint) ([]story.Story, rest_error.RestErr) {
+	var collection []story.Story
+	if len(ids) == 0 {
+		return collection, nil
+	}
+
+	if err := s.db.Where("id IN ?", ids).Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get stories", err)
+	}
+
+	return collection, nil
+}
+
+func (s *storiesRepository) Archive(id uint) rest_error.RestErr {
+	if err := s.db.Model(&story.Story{}).Where("id = ?", id).Update("archived", true).Error; err != nil {
+		return rest_error.NewInternalServerError("Error when trying to archive a story", err)
+	}
+	return nil
+}
+
+func (s *storiesRepository) GetArchived(userEmail string) ([]story.Story, rest_error.RestErr) {
+	var collection []story.Story
+
+	if err := s.db.Where("user_email = ? AND archived = ?", userEmail, true).Order("date desc").Find(&collection).Error; err != nil {
+		return nil, rest_error.NewInternalServerError("Error when trying to get archived stories", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,99 @@
+package story
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"github.com/stretchr/testify/mock"
+)
+
+type StoryRepositoryMock struct {
+	mock.Mock
+}
+
+func (s *StoryRepositoryMock) Create(story *story.Story) rest_error.RestErr {
+	args := s.Called(story)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) Get(id uint) (*story.Story, rest_error.RestErr) {
+	args := s.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*story.Story), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) GetActiveByUsers(userEmails []string, now int64) ([]story.Story, rest_error.RestErr) {
+	args := s.Called(userEmails, now)
+	if args.Get(1) == nil {
+		return args.Get(0).([]story.Story), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) RecordView(view *story.StoryView) rest_error.RestErr {
+	args := s.Called(view)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) GetViews(storyID uint) ([]story.StoryView, rest_error.RestErr) {
+	args := s.Called(storyID)
+	if args.Get(1) == nil {
+		return args.Get(0).([]story.StoryView), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) GetViewedStories(userEmail string, storyIDs []uint) (map[uint]bool, rest_error.RestErr) {
+	args := s.Called(userEmail, storyIDs)
+	if args.Get(1) == nil {
+		return args.Get(0).(map[uint]bool), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) GetExpired(now int64) ([]story.Story, rest_error.RestErr) {
+	args := s.Called(now)
+	if args.Get(1) == nil {
+		return args.Get(0).([]story.Story), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) DeleteExpired(ids []uint) rest_error.RestErr {
+	args := s.Called(ids)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) GetByIDs(ids []uint) ([]story.Story, rest_error.RestErr) {
+	args := s.Called(ids)
+	if args.Get(1) == nil {
+		return args.Get(0).([]story.Story), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) Archive(id uint) rest_error.RestErr {
+	args := s.Called(id)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(rest_error.RestErr)
+}
+
+func (s *StoryRepositoryMock) GetArchived(userEmail string) ([]story.Story, rest_error.RestErr) {
+	args := s.Called(userEmail)
+	if args.Get(1) == nil {
+		return args.Get(0).([]story.Story), nil
+	}
+	return nil, args.Get(1).(rest_error.RestErr)
+}
@@ -1,59 +1,272 @@
 package post
 
 import (
+	"fmt"
 	"github.com/Nistagram-Organization/nistagram-posts/src/clients/media_grpc_client"
 	"github.com/Nistagram-Organization/nistagram-posts/src/clients/user_grpc_client"
 	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/Nistagram-Organization/nistagram-posts/src/explore"
+	"github.com/Nistagram-Organization/nistagram-posts/src/feed_ranker"
+	"github.com/Nistagram-Organization/nistagram-posts/src/image_hash"
+	"github.com/Nistagram-Organization/nistagram-posts/src/insights"
+	"github.com/Nistagram-Organization/nistagram-posts/src/media_validator"
+	modelAuthorRestriction "github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	modelBannedMedia "github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	modelCampaign "github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	modelCloseFriend "github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	modelCommentReview "github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
+	modelDraft "github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	modelFeedItem "github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	modelHighlight "github.com/Nistagram-Organization/nistagram-posts/src/model/highlight"
+	modelImpression "github.com/Nistagram-Organization/nistagram-posts/src/model/impression"
+	modelLargeAccount "github.com/Nistagram-Organization/nistagram-posts/src/model/large_account"
+	modelMute "github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
+	modelPendingFanOut "github.com/Nistagram-Organization/nistagram-posts/src/model/pending_fan_out"
+	modelPostMedia "github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	modelPostSetting "github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	modelPostTerm "github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	modelReaction "github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	modelReport "github.com/Nistagram-Organization/nistagram-posts/src/model/report"
+	modelScheduledPost "github.com/Nistagram-Organization/nistagram-posts/src/model/scheduled_post"
+	modelStory "github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/banned_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/campaign"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/close_friend"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment_review"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/dislike"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/draft"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/feed_item"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/highlight"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/impression"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/large_account"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/like"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/mute"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/pending_fan_out"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_term"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/reaction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/report"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/scheduled_post"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/story"
+	"github.com/Nistagram-Organization/nistagram-posts/src/similarity"
+	"github.com/Nistagram-Organization/nistagram-posts/src/spam_scorer"
 	"github.com/Nistagram-Organization/nistagram-posts/src/time_utils"
+	"github.com/Nistagram-Organization/nistagram-posts/src/trending"
 	modelComment "github.com/Nistagram-Organization/nistagram-shared/src/model/comment"
 	modelDislike "github.com/Nistagram-Organization/nistagram-shared/src/model/dislike"
 	modelLike "github.com/Nistagram-Organization/nistagram-shared/src/model/like"
 	modelPost "github.com/Nistagram-Organization/nistagram-shared/src/model/post"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
+	"log"
+	"net/http"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 )
 
+const (
+	// Maximum hash distance from removed media for which an upload is rejected
+	bannedMediaRejectDistance = 5
+	// Maximum hash distance from removed media for which a post is flagged for moderation
+	bannedMediaFlagDistance = 10
+	// Number of indexed hash bands, any hash within flag distance shares at least one band with the banned one
+	bannedMediaBands = bannedMediaFlagDistance + 1
+	// Moderation queue page size bounds
+	defaultModerationPageSize = 20
+	maxModerationPageSize     = 100
+	// Maximum number of decisions in a single bulk moderation request
+	maxModerationDecisions = 100
+	// Window in seconds in which author's post rate limit is applied
+	postRateLimitWindow = 3600
+	// Authors with more followers are read on request instead of being fanned out to followers' feeds
+	fanOutFollowerLimit = 5000
+	// Number of materialized feed items read per request
+	feedSize = 500
+	// Number of author's latest posts copied to a new follower's feed
+	feedBackfillSize = 50
+	// Number of explore posts returned per request
+	explorePageSize = 50
+	// Number of similar posts returned per request
+	similarPostsSize = 12
+	// Maximum number of impressions accepted in a single batch
+	maxImpressionsBatchSize = 100
+	secondsInDay            = 24 * 3600
+	// Insights period used when none is given and the longest one allowed
+	defaultInsightsPeriod = 30 * secondsInDay
+	maxInsightsPeriod     = 366 * secondsInDay
+	// Sponsored posts injected into a single feed response, one after every sponsoredInterval-1 organic posts
+	maxSponsoredPerFeed = 2
+	sponsoredInterval   = 5
+	// Upper bound of campaign's daily deliveries to the same user
+	maxFrequencyCap = 10
+	// Furthest a post can be scheduled ahead and number of due posts published in a single transaction
+	maxScheduleAhead          = 180 * secondsInDay
+	scheduledPublishBatchSize = 100
+	// Delay before a scheduled post which failed validation is retried, growing with every failed attempt
+	scheduledRetryDelay = 15 * 60
+	// Maximum number of images of a carousel post
+	maxPostMedia = 10
+	// Time in seconds after which a story expires
+	storyLifetime = secondsInDay
+	// Highlights limits
+	maxHighlightNameLength = 30
+	maxHighlightStories    = 100
+	maxPinnedPosts         = 3
+)
+
 type PostService interface {
 	GetAll() []modelPost.Post
 	LikePost(*dtos.LikeDislikeRequestDTO) rest_error.RestErr
 	UnlikePost(string, uint) rest_error.RestErr
 	DislikePost(d *dtos.LikeDislikeRequestDTO) rest_error.RestErr
 	UndislikePost(string, uint) rest_error.RestErr
-	ReportInappropriateContent(uint) rest_error.RestErr
+	ReportInappropriateContent(uint, string) rest_error.RestErr
 	PostComment(*modelComment.Comment) rest_error.RestErr
 	CreatePost(*dtos.CreatePostDTO) rest_error.RestErr
 	GetUsersPosts(string, string) ([]dtos.PostDTO, rest_error.RestErr)
 	GetInappropriateContent() []dtos.InappropriateContentReportDTO
 	DecideOnContent(uint, bool) rest_error.RestErr
-	GetPostsFeed(string) ([]dtos.PostDTO, rest_error.RestErr)
-	SearchTags(string, string) ([]dtos.PostDTO, rest_error.RestErr)
+	GetModerationQueue(dtos.ModerationQueueFilter) (*dtos.ModerationQueueDTO, rest_error.RestErr)
+	DecideOnContentBulk([]dtos.ModerationDecisionDTO) ([]dtos.ModerationDecisionResultDTO, rest_error.RestErr)
+	GetAuthorRestrictions() []modelAuthorRestriction.AuthorRestriction
+	RestrictAuthor(*modelAuthorRestriction.AuthorRestriction) rest_error.RestErr
+	RemoveAuthorRestriction(string) rest_error.RestErr
+	SetContentWarning(*dtos.ContentWarningRequestDTO, bool) rest_error.RestErr
+	GetPostMedia(uint, string) (*dtos.PostMediaDTO, rest_error.RestErr)
+	GetQuarantinedComments(string, bool) ([]dtos.QuarantinedCommentDTO, rest_error.RestErr)
+	ReviewQuarantinedComment(*dtos.CommentReviewDecisionDTO, bool) rest_error.RestErr
+	GetPostsFeed(string, dtos.SensitiveContentPreference, dtos.FeedMode) ([]dtos.PostDTO, rest_error.RestErr)
+	BackfillFeed(string, string) rest_error.RestErr
+	RefreshExplore() rest_error.RestErr
+	GetExplore(string, dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr)
+	RefreshTrending() rest_error.RestErr
+	GetTrending(dtos.TrendingWindow) *dtos.TrendingDTO
+	RefreshSimilarPosts() rest_error.RestErr
+	GetSimilarPosts(uint, string, dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr)
+	RecordImpressions(*dtos.ImpressionsRequestDTO) (*dtos.ImpressionsResultDTO, rest_error.RestErr)
+	GetPostInsights(uint, string, dtos.InsightsFilter) (*dtos.PostInsightsDTO, rest_error.RestErr)
+	GetInsightsSummary(string, dtos.InsightsFilter) (*dtos.InsightsSummaryDTO, rest_error.RestErr)
+	CreateCampaign(*dtos.CreateCampaignDTO) rest_error.RestErr
+	GetCampaigns(string) ([]dtos.CampaignDTO, rest_error.RestErr)
+	GetScheduledPosts(string) ([]dtos.ScheduledPostDTO, rest_error.RestErr)
+	ReschedulePost(uint, *dtos.ReschedulePostDTO) rest_error.RestErr
+	CancelScheduledPost(uint, string) rest_error.RestErr
+	PublishScheduledPosts() rest_error.RestErr
+	CreateDraft(*dtos.CreatePostDTO) (*dtos.DraftDTO, rest_error.RestErr)
+	GetDrafts(string) ([]dtos.DraftDTO, rest_error.RestErr)
+	GetDraft(uint, string) (*dtos.DraftDTO, rest_error.RestErr)
+	UpdateDraft(uint, *dtos.CreatePostDTO) (*dtos.DraftDTO, rest_error.RestErr)
+	DeleteDraft(uint, string) rest_error.RestErr
+	PublishDraft(uint, string) rest_error.RestErr
+	CreateStory(*dtos.CreateStoryDTO) rest_error.RestErr
+	GetStoriesTray(string) ([]dtos.StoryTrayItemDTO, rest_error.RestErr)
+	ViewStory(uint, string) rest_error.RestErr
+	GetStoryViewers(uint, string) ([]dtos.StoryViewerDTO, rest_error.RestErr)
+	SweepExpiredStories() rest_error.RestErr
+	ArchiveStory(uint, string) rest_error.RestErr
+	GetArchivedStories(string) ([]dtos.StoryDTO, rest_error.RestErr)
+	CreateHighlight(*dtos.HighlightRequestDTO) (*dtos.HighlightDTO, rest_error.RestErr)
+	GetHighlights(string, string) ([]dtos.HighlightDTO, rest_error.RestErr)
+	UpdateHighlight(uint, *dtos.HighlightRequestDTO) (*dtos.HighlightDTO, rest_error.RestErr)
+	DeleteHighlight(uint, string) rest_error.RestErr
+	SetAudience(*dtos.AudienceRequestDTO) rest_error.RestErr
+	GetCloseFriends(string) ([]modelCloseFriend.CloseFriend, rest_error.RestErr)
+	AddCloseFriend(*modelCloseFriend.CloseFriend) rest_error.RestErr
+	RemoveCloseFriend(string, string) rest_error.RestErr
+	GetMutedUsers(string) ([]dtos.MutedUserDTO, rest_error.RestErr)
+	MuteUser(*modelMute.Mute) rest_error.RestErr
+	UnmuteUser(string, string) rest_error.RestErr
+	ArchivePost(uint, string) rest_error.RestErr
+	UnarchivePost(uint, string) rest_error.RestErr
+	GetArchivedPosts(string) ([]dtos.PostDTO, rest_error.RestErr)
+	PinPost(uint, string) rest_error.RestErr
+	UnpinPost(uint, string) rest_error.RestErr
+	SetCommentPolicy(*dtos.CommentPolicyRequestDTO) rest_error.RestErr
+	SearchTags(string, string, dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr)
+	RetryFanOuts() rest_error.RestErr
 }
 
 type postsService struct {
-	postsRepository    post.PostRepository
-	likesRepository    like.LikeRepository
-	dislikesRepository dislike.DislikeRepository
-	commentsRepository comment.CommentRepository
-	mediaGrpcClient    media_grpc_client.MediaGrpcClient
-	userGrpcClient     user_grpc_client.UserGrpcClient
+	postsRepository          post.PostRepository
+	likesRepository          like.LikeRepository
+	dislikesRepository       dislike.DislikeRepository
+	commentsRepository       comment.CommentRepository
+	bannedMediaRepository    banned_media.BannedMediaRepository
+	reportsRepository        report.ReportRepository
+	restrictionsRepository   author_restriction.AuthorRestrictionRepository
+	settingsRepository       post_setting.PostSettingRepository
+	commentReviewsRepository comment_review.CommentReviewRepository
+	feedItemsRepository      feed_item.FeedItemRepository
+	largeAccountsRepository  large_account.LargeAccountRepository
+	similaritiesRepository   post_similarity.PostSimilarityRepository
+	impressionsRepository    impression.ImpressionRepository
+	reactionsRepository      reaction.ReactionRepository
+	campaignsRepository      campaign.CampaignRepository
+	scheduledPostsRepository scheduled_post.ScheduledPostRepository
+	draftsRepository         draft.DraftRepository
+	postMediaRepository      post_media.PostMediaRepository
+	storiesRepository        story.StoryRepository
+	highlightsRepository     highlight.HighlightRepository
+	closeFriendsRepository   close_friend.CloseFriendRepository
+	mutesRepository          mute.MuteRepository
+	pendingFanOutsRepository pending_fan_out.PendingFanOutRepository
+	postTermsRepository      post_term.PostTermRepository
+	spamScorer               spam_scorer.SpamScorer
+	feedRanker               feed_ranker.FeedRanker
+	exploreCache             explore.ExploreCache
+	trendingCache            trending.TrendingCache
+	mediaGrpcClient          media_grpc_client.MediaGrpcClient
+	userGrpcClient           user_grpc_client.UserGrpcClient
 }
 
 func NewPostService(postsRepository post.PostRepository, likesRepository like.LikeRepository, dislikesRepository dislike.DislikeRepository,
-	commentsRepository comment.CommentRepository, mediaGrpcClient media_grpc_client.MediaGrpcClient, userGrpcClient user_grpc_client.UserGrpcClient) PostService {
+	commentsRepository comment.CommentRepository, bannedMediaRepository banned_media.BannedMediaRepository, reportsRepository report.ReportRepository,
+	restrictionsRepository author_restriction.AuthorRestrictionRepository, settingsRepository post_setting.PostSettingRepository,
+	commentReviewsRepository comment_review.CommentReviewRepository, feedItemsRepository feed_item.FeedItemRepository,
+	largeAccountsRepository large_account.LargeAccountRepository, similaritiesRepository post_similarity.PostSimilarityRepository,
+	impressionsRepository impression.ImpressionRepository, reactionsRepository reaction.ReactionRepository,
+	campaignsRepository campaign.CampaignRepository, scheduledPostsRepository scheduled_post.ScheduledPostRepository,
+	draftsRepository draft.DraftRepository, postMediaRepository post_media.PostMediaRepository,
+	storiesRepository story.StoryRepository, highlightsRepository highlight.HighlightRepository,
+	closeFriendsRepository close_friend.CloseFriendRepository, mutesRepository mute.MuteRepository,
+	pendingFanOutsRepository pending_fan_out.PendingFanOutRepository, postTermsRepository post_term.PostTermRepository, feedRanker feed_ranker.FeedRanker, mediaGrpcClient media_grpc_client.MediaGrpcClient, userGrpcClient user_grpc_client.UserGrpcClient) PostService {
 	return &postsService{
-		postsRepository:    postsRepository,
-		likesRepository:    likesRepository,
-		dislikesRepository: dislikesRepository,
-		commentsRepository: commentsRepository,
-		mediaGrpcClient:    mediaGrpcClient,
-		userGrpcClient:     userGrpcClient,
+		postsRepository:          postsRepository,
+		likesRepository:          likesRepository,
+		dislikesRepository:       dislikesRepository,
+		commentsRepository:       commentsRepository,
+		bannedMediaRepository:    bannedMediaRepository,
+		reportsRepository:        reportsRepository,
+		restrictionsRepository:   restrictionsRepository,
+		settingsRepository:       settingsRepository,
+		commentReviewsRepository: commentReviewsRepository,
+		feedItemsRepository:      feedItemsRepository,
+		largeAccountsRepository:  largeAccountsRepository,
+		similaritiesRepository:   similaritiesRepository,
+		impressionsRepository:    impressionsRepository,
+		reactionsRepository:      reactionsRepository,
+		campaignsRepository:      campaignsRepository,
+		scheduledPostsRepository: scheduledPostsRepository,
+		draftsRepository:         draftsRepository,
+		postMediaRepository:      postMediaRepository,
+		storiesRepository:        storiesRepository,
+		highlightsRepository:     highlightsRepository,
+		closeFriendsRepository:   closeFriendsRepository,
+		mutesRepository:          mutesRepository,
+		pendingFanOutsRepository: pendingFanOutsRepository,
+		postTermsRepository:      postTermsRepository,
+		spamScorer:               spam_scorer.NewSpamScorer(),
+		feedRanker:               feedRanker,
+		exploreCache:             explore.NewExploreCache(),
+		trendingCache:            trending.NewTrendingCache(),
+		mediaGrpcClient:          mediaGrpcClient,
+		userGrpcClient:           userGrpcClient,
 	}
 }
 
@@ -146,7 +359,7 @@ func (s *postsService) UndislikePost(userEmail string, postId uint) rest_error.R
 	return s.dislikesRepository.Delete(&dislikeEntity)
 }
 
-func (s *postsService) ReportInappropriateContent(postId uint) rest_error.RestErr {
+func (s *postsService) ReportInappropriateContent(postId uint, reason string) rest_error.RestErr {
 	postEntity, err := s.postsRepository.Get(postId)
 	if err != nil {
 		return err
@@ -154,254 +367,3283 @@ func (s *postsService) ReportInappropriateContent(postId uint) rest_error.RestEr
 
 	if !postEntity.MarkedAsInappropriate {
 		postEntity.MarkedAsInappropriate = true
-		return s.postsRepository.Update(postEntity)
-	} else {
+		if err := s.postsRepository.Update(postEntity); err != nil {
+			return err
+		}
+	}
+
+	reportEntity := modelReport.Report{
+		PostID: postId,
+		Reason: reason,
+		Date:   time_utils.Now(),
+	}
+
+	return s.reportsRepository.Create(&reportEntity)
+}
+
+func (s *postsService) getAuthorRestriction(userEmail string) *modelAuthorRestriction.AuthorRestriction {
+	restriction, err := s.restrictionsRepository.GetByUser(userEmail)
+	if err != nil {
+		return &modelAuthorRestriction.AuthorRestriction{UserEmail: userEmail}
+	}
+	return restriction
+}
+
+func (s *postsService) isShadowBanned(userEmail string, checked map[string]bool) bool {
+	shadowBanned, ok := checked[userEmail]
+	if !ok {
+		shadowBanned = s.getAuthorRestriction(userEmail).ShadowBanned
+		checked[userEmail] = shadowBanned
+	}
+	return shadowBanned
+}
+
+func (s *postsService) checkPostRateLimit(userEmail string) rest_error.RestErr {
+	restriction := s.getAuthorRestriction(userEmail)
+	if restriction.PostRateLimit == 0 {
 		return nil
 	}
+
+	count, err := s.postsRepository.CountUsersPostsSince(userEmail, time_utils.Now()-postRateLimitWindow)
+	if err != nil {
+		return err
+	}
+
+	if count >= int64(restriction.PostRateLimit) {
+		return rest_error.NewRestError("Post rate limit exceeded", http.StatusTooManyRequests, "too_many_requests", nil)
+	}
+
+	return nil
+}
+
+func (s *postsService) GetAuthorRestrictions() []modelAuthorRestriction.AuthorRestriction {
+	return s.restrictionsRepository.GetAll()
+}
+
+func (s *postsService) RestrictAuthor(restriction *modelAuthorRestriction.AuthorRestriction) rest_error.RestErr {
+	if restriction.UserEmail == "" {
+		return rest_error.NewBadRequestError("User email is required")
+	}
+
+	if restriction.IsEmpty() {
+		return s.RemoveAuthorRestriction(restriction.UserEmail)
+	}
+
+	existing, getErr := s.restrictionsRepository.GetByUser(restriction.UserEmail)
+	if getErr == nil {
+		restriction.ID = existing.ID
+	}
+	restriction.Date = time_utils.Now()
+
+	return s.restrictionsRepository.Save(restriction)
+}
+
+func (s *postsService) RemoveAuthorRestriction(userEmail string) rest_error.RestErr {
+	if userEmail == "" {
+		return rest_error.NewBadRequestError("User email is required")
+	}
+
+	existing, err := s.restrictionsRepository.GetByUser(userEmail)
+	if err != nil {
+		return err
+	}
+
+	return s.restrictionsRepository.Delete(existing)
 }
 
 func (s *postsService) PostComment(commentEntity *modelComment.Comment) rest_error.RestErr {
-	if err := s.checkIfPostExists(commentEntity.PostID); err != nil {
+	postEntity, err := s.postsRepository.Get(commentEntity.PostID)
+	if err != nil {
+		return err
+	}
+
+	if s.getAuthorRestriction(commentEntity.UserEmail).CommentsDisabled {
+		return rest_error.NewRestError("Commenting is disabled for this user", http.StatusForbidden, "forbidden", nil)
+	}
+
+	if err := s.checkCommentPolicy(postEntity, commentEntity.UserEmail); err != nil {
 		return err
 	}
 	commentEntity.Date = time_utils.Now()
 
-	return s.commentsRepository.Create(commentEntity)
-}
+	spamResult, err := s.scoreComment(commentEntity)
+	if err != nil {
+		return err
+	}
 
-func (s *postsService) CreatePost(postDTO *dtos.CreatePostDTO) rest_error.RestErr {
-	saveMediaRequest := dtos.SaveMediaRequest{
-		Image: postDTO.Image,
+	if err := s.commentsRepository.Create(commentEntity); err != nil {
+		return err
 	}
+	s.savePostTerms(commentEntity.Text, commentEntity.PostID, commentEntity.ID, commentEntity.UserEmail, commentEntity.Date)
 
-	var mediaID *uint
-	var err error
+	if !spamResult.Quarantined() {
+		return nil
+	}
 
-	if mediaID, err = s.mediaGrpcClient.SaveMedia(saveMediaRequest); err != nil {
-		return rest_error.NewInternalServerError("user grpc client error when saving media", err)
+	// Quarantined comments are visible only to their author until reviewed
+	review := modelCommentReview.CommentReview{
+		CommentID: commentEntity.ID,
+		PostID:    commentEntity.PostID,
+		UserEmail: commentEntity.UserEmail,
+		Score:     spamResult.Score,
+		Reasons:   strings.Join(spamResult.Reasons, ","),
+		Date:      commentEntity.Date,
 	}
 
-	postEntity := modelPost.Post{
-		Description:           postDTO.Description,
-		UserEmail:             postDTO.UserEmail,
-		MarkedAsInappropriate: false,
-		Date:                  time_utils.Now(),
-		MediaID:               *mediaID,
+	return s.commentReviewsRepository.Create(&review)
+}
+
+// checkCommentPolicy returns forbidden error when post's comment policy does not let the user comment.
+// Authors can comment on their posts unless comments are turned off.
+func (s *postsService) checkCommentPolicy(postEntity *modelPost.Post, userEmail string) rest_error.RestErr {
+	setting, err := s.getPostSetting(postEntity.ID)
+	if err != nil {
+		return err
 	}
 
-	return s.postsRepository.Create(&postEntity)
+	switch setting.GetCommentPolicy() {
+	case modelPostSetting.CommentPolicyNobody:
+		return rest_error.NewRestError("Comments are turned off for this post", http.StatusForbidden, "forbidden", nil)
+	case modelPostSetting.CommentPolicyFollowers:
+		if postEntity.UserEmail == userEmail {
+			return nil
+		}
+		// Users whose follow can not be checked are not let through
+		following, err := s.userGrpcClient.CheckIfFollowing(dtos.CheckIfFollowingRequest{User: userEmail, FollowedUser: postEntity.UserEmail})
+		if err != nil {
+			log.Printf("failed to check if %s follows %s: %s", userEmail, postEntity.UserEmail, err)
+		}
+		if err != nil || !following {
+			return rest_error.NewRestError("Only followers can comment on this post", http.StatusForbidden, "forbidden", nil)
+		}
+	}
+	return nil
 }
 
-func (s *postsService) GetUsersPosts(userEmail string, loggedInUserEmail string) ([]dtos.PostDTO, rest_error.RestErr) {
-	var posts []modelPost.Post
-	var postErr rest_error.RestErr
+func (s *postsService) SetCommentPolicy(commentPolicyRequest *dtos.CommentPolicyRequestDTO) rest_error.RestErr {
+	setting, err := s.getOwnPostSetting(commentPolicyRequest.PostID, commentPolicyRequest.UserEmail, "Only author can set comment policy")
+	if err != nil {
+		return err
+	}
 
-	// Get all users posts
-	if posts, postErr = s.postsRepository.GetUsersPosts(userEmail); postErr != nil {
-		return nil, postErr
+	if !modelPostSetting.IsValidCommentPolicy(commentPolicyRequest.CommentPolicy) {
+		return rest_error.NewBadRequestError("Invalid comment policy")
 	}
 
-	return s.GetPostsDTOs(posts, loggedInUserEmail)
+	setting.CommentPolicy = commentPolicyRequest.CommentPolicy
+	return s.settingsRepository.Update(setting, "comment_policy")
 }
 
-func (s *postsService) GetPostsDTOs(posts []modelPost.Post, loggedInUserEmail string) ([]dtos.PostDTO, rest_error.RestErr) {
-	var postsDTOs []dtos.PostDTO
-	var postErr rest_error.RestErr
+func (s *postsService) scoreComment(commentEntity *modelComment.Comment) (*spam_scorer.Result, rest_error.RestErr) {
+	signals := spam_scorer.Signals{
+		Text:       commentEntity.Text,
+		AccountAge: -1,
+	}
+	var err rest_error.RestErr
 
-	layout := "02.01.2006. 03:04"
-	for _, postEntity := range posts {
-		description := s.ProcessTags(postEntity.Description)
-		// Convert time to format dd.MM.yyyy. HH:mm
-		t := time.Unix(postEntity.Date, 0)
-		date := t.Format(layout)
+	if signals.Duplicates, err = s.commentsRepository.CountDuplicates(commentEntity.UserEmail, commentEntity.Text,
+		commentEntity.PostID, commentEntity.Date-spam_scorer.DuplicateWindow); err != nil {
+		return nil, err
+	}
 
-		// GRPC call media service to get post's image
-		var image string
-		var err error
-		getMediaRequest := dtos.GetMediaRequest{
-			ID: uint64(postEntity.MediaID),
-		}
-		if image, err = s.mediaGrpcClient.GetMedia(getMediaRequest); err != nil {
-			return nil, rest_error.NewInternalServerError("user grpc client error when getting media", err)
-		}
+	if signals.RecentComments, err = s.commentsRepository.CountUsersCommentsSince(commentEntity.UserEmail,
+		commentEntity.Date-spam_scorer.VelocityWindow); err != nil {
+		return nil, err
+	}
 
-		// GRPC CALL TO USER SERVICE FOR USERNAME
-		var username string
-		if username, err = s.userGrpcClient.GetUsername(dtos.GetUsernameRequest{Email: postEntity.UserEmail}); err != nil {
-			return nil, rest_error.NewInternalServerError("user grpc client error when getting username", err)
+	// Users service does not expose registration date, so first activity in posts service is used instead
+	var firstComment, firstPost int64
+	if firstComment, err = s.commentsRepository.GetUsersFirstCommentDate(commentEntity.UserEmail); err != nil {
+		return nil, err
+	}
+	if firstPost, err = s.postsRepository.GetUsersFirstPostDate(commentEntity.UserEmail); err != nil {
+		return nil, err
+	}
+	for _, firstActivity := range []int64{firstComment, firstPost} {
+		if firstActivity != 0 && commentEntity.Date-firstActivity > signals.AccountAge {
+			signals.AccountAge = commentEntity.Date - firstActivity
 		}
+	}
 
-		// Check if logged user liked, disliked or added post to favorites
-		liked := false
-		disliked := false
-		inFavorites := false
-		if loggedInUserEmail != "" {
-			if _, postErr = s.likesRepository.GetByUserAndPost(loggedInUserEmail, postEntity.ID); postErr == nil {
-				liked = true
-			}
+	result := s.spamScorer.Score(signals)
+	return &result, nil
+}
 
-			if _, postErr = s.dislikesRepository.GetByUserAndPost(loggedInUserEmail, postEntity.ID); postErr == nil {
-				disliked = true
-			}
+func (s *postsService) GetQuarantinedComments(userEmail string, moderator bool) ([]dtos.QuarantinedCommentDTO, rest_error.RestErr) {
+	var reviews []modelCommentReview.CommentReview
+	var err rest_error.RestErr
 
-			// GRPC CALL TO USER SERVICE TO CHECK IF POST IS IN USER'S FAVORITES
-			checkFavoritesRequest := dtos.CheckFavoritesRequest{
-				Email:  loggedInUserEmail,
-				PostID: postEntity.ID,
-			}
-			if inFavorites, err = s.userGrpcClient.CheckPostIsInFavorites(checkFavoritesRequest); err != nil {
-				return nil, rest_error.NewInternalServerError("user grpc client error when checking favorites", err)
-			}
-		}
+	if moderator {
+		reviews = s.commentReviewsRepository.GetAll()
+	} else if reviews, err = s.commentReviewsRepository.GetByPostAuthor(userEmail); err != nil {
+		return nil, err
+	}
 
-		// Calculate number of post's likes and dislikes
-		var numberOfLikes int64
-		if numberOfLikes, postErr = s.likesRepository.GetNumberOfLikes(postEntity.ID); postErr != nil {
-			return nil, postErr
+	quarantined := make([]dtos.QuarantinedCommentDTO, 0)
+	for _, review := range reviews {
+		commentEntity, getErr := s.commentsRepository.Get(review.CommentID)
+		if getErr != nil {
+			continue
 		}
 
-		var numberOfDislikes int64
-		if numberOfDislikes, postErr = s.dislikesRepository.GetNumberOfDislikes(postEntity.ID); postErr != nil {
-			return nil, postErr
-		}
+		quarantined = append(quarantined, dtos.QuarantinedCommentDTO{
+			CommentID: commentEntity.ID,
+			PostID:    commentEntity.PostID,
+			Text:      commentEntity.Text,
+			UserEmail: commentEntity.UserEmail,
+			Date:      commentEntity.Date,
+			Score:     review.Score,
+			Reasons:   strings.Split(review.Reasons, ","),
+		})
+	}
 
-		// Get posts's comments
-		var commentsDTOs = make([]dtos.CommentDTO, 0)
-		var comments []modelComment.Comment
-		if comments, postErr = s.commentsRepository.GetComments(postEntity.ID); postErr != nil {
-			return nil, postErr
+	return quarantined, nil
+}
+
+func (s *postsService) ReviewQuarantinedComment(decision *dtos.CommentReviewDecisionDTO, moderator bool) rest_error.RestErr {
+	review, err := s.commentReviewsRepository.GetByComment(decision.CommentID)
+	if err != nil {
+		return err
+	}
+
+	if !moderator {
+		postEntity, err := s.postsRepository.Get(review.PostID)
+		if err != nil {
+			return err
 		}
-		var commUsername string
-		for _, commentEntity := range comments {
-			if commUsername, err = s.userGrpcClient.GetUsername(dtos.GetUsernameRequest{Email: commentEntity.UserEmail}); err != nil {
-				return nil, rest_error.NewInternalServerError("user grpc client error when getting username", err)
-			}
-			commentsDTOs = append(commentsDTOs, dtos.CommentDTO{
-				Text:     s.ProcessTags(commentEntity.Text),
-				Date:     time.Unix(commentEntity.Date, 0).Format(layout),
-				Username: commUsername,
-			})
+		if postEntity.UserEmail != decision.UserEmail {
+			return rest_error.NewRestError("Only post author can review comments", http.StatusForbidden, "forbidden", nil)
 		}
+	}
 
-		// CREATE POST DTO
-		postsDTOs = append(postsDTOs, dtos.PostDTO{
-			ID:          postEntity.ID,
-			Description: description,
-			Date:        date,
-			Timestamp:   postEntity.Date,
-			Image:       image,
-			Username:    username,
-			Liked:       liked,
-			Disliked:    disliked,
-			InFavorites: inFavorites,
-			Likes:       uint(numberOfLikes),
-			Dislikes:    uint(numberOfDislikes),
-			Comments:    commentsDTOs,
-		})
+	if !decision.Approve {
+		commentEntity, err := s.commentsRepository.Get(decision.CommentID)
+		if err != nil {
+			return err
+		}
+		if err := s.commentsRepository.Delete(commentEntity); err != nil {
+			return err
+		}
 	}
 
-	return postsDTOs, nil
+	return s.commentReviewsRepository.Delete(review)
 }
 
-func (s *postsService) ProcessTags(text string) string {
-	r := regexp.MustCompile(`@[A-Za-z0-9_.]+`)
-	matches := r.FindAllString(text, -1)
+func (s *postsService) checkBannedMedia(imageBase64 string) (bool, rest_error.RestErr) {
+	hash, err := image_hash.Compute(imageBase64)
+	if err != nil {
+		// Media service is responsible for rejecting unsupported images
+		return false, nil
+	}
 
-	var link string
-	var taggable bool
-	checkTaggableRequest := dtos.CheckTaggableRequest{
-		Username: "",
+	var bannedMedia []modelBannedMedia.BannedMedia
+	var getErr rest_error.RestErr
+	if bannedMedia, getErr = s.bannedMediaRepository.GetByBands(image_hash.Bands(hash, bannedMediaBands)); getErr != nil {
+		return false, getErr
 	}
 
-	for _, tag := range matches {
-		checkTaggableRequest.Username = tag[1:]
-		if taggable, _ = s.userGrpcClient.CheckIfUserIsTaggable(checkTaggableRequest); !taggable {
-			continue
+	flagged := false
+	for _, bannedMediaEntity := range bannedMedia {
+		distance := image_hash.Distance(hash, bannedMediaEntity.Hash)
+		if distance <= bannedMediaRejectDistance {
+			return false, rest_error.NewBadRequestError("Image matches previously removed content")
+		}
+		if distance <= bannedMediaFlagDistance {
+			flagged = true
 		}
-
-		link = "<a href='/users/" + tag[1:] + "' >" + tag + "</a>"
-		text = strings.ReplaceAll(text, tag, link)
 	}
 
-	return text
+	return flagged, nil
 }
 
-func (s *postsService) GetInappropriateContent() []dtos.InappropriateContentReportDTO {
-	markedAsInappropriate := s.postsRepository.GetInappropriateContent()
+// getPostsMediaIDs returns ordered media ids of every post, posts which are not carousels have only their MediaID
+func (s *postsService) getPostsMediaIDs(posts []modelPost.Post) (map[uint][]uint, rest_error.RestErr) {
+	mediaIDs := make(map[uint][]uint)
+	if len(posts) == 0 {
+		return mediaIDs, nil
+	}
 
-	if len(markedAsInappropriate) == 0 {
-		return []dtos.InappropriateContentReportDTO{}
+	postIDs := make([]uint, 0, len(posts))
+	for _, postEntity := range posts {
+		postIDs = append(postIDs, postEntity.ID)
 	}
 
-	var collection []dtos.InappropriateContentReportDTO
-	for i := 0; i < len(markedAsInappropriate); i++ {
-		getMediaRequest := dtos.GetMediaRequest{
-			ID: uint64(markedAsInappropriate[i].MediaID),
-		}
-		media, _ := s.mediaGrpcClient.GetMedia(getMediaRequest)
+	media, err := s.postMediaRepository.GetByPosts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, postMedia := range media {
+		mediaIDs[postMedia.PostID] = append(mediaIDs[postMedia.PostID], postMedia.MediaID)
+	}
 
-		inappropriateContentReport := dtos.InappropriateContentReportDTO{
-			Description: markedAsInappropriate[i].Description,
-			AuthorEmail: markedAsInappropriate[i].UserEmail,
-			Image:       media,
-			PostID:      markedAsInappropriate[i].ID,
+	for _, postEntity := range posts {
+		if _, ok := mediaIDs[postEntity.ID]; !ok {
+			mediaIDs[postEntity.ID] = []uint{postEntity.MediaID}
 		}
-		collection = append(collection, inappropriateContentReport)
 	}
 
-	return collection
+	return mediaIDs, nil
 }
 
-func (s *postsService) DecideOnContent(id uint, delete bool) rest_error.RestErr {
-	postEntity, err := s.postsRepository.Get(id)
+func (s *postsService) getImages(mediaIDs []uint) ([]string, rest_error.RestErr) {
+	images := make([]string, 0, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		getMediaRequest := dtos.GetMediaRequest{
+			ID: uint64(mediaID),
+		}
+		image, err := s.mediaGrpcClient.GetMedia(getMediaRequest)
+		if err != nil {
+			return nil, rest_error.NewInternalServerError("media grpc client error when getting media", err)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// getBannedMedia hashes every image of a removed post, images which can not be hashed are skipped.
+// Ids of post's media are returned as well, so the media can be deleted once the post is removed.
+func (s *postsService) getBannedMedia(postEntity *modelPost.Post) ([]modelBannedMedia.BannedMedia, []uint, rest_error.RestErr) {
+	mediaIDs, err := s.getPostsMediaIDs([]modelPost.Post{*postEntity})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if delete {
-		if err := s.postsRepository.Delete(postEntity); err != nil {
-			return err
+	images, err := s.getImages(mediaIDs[postEntity.ID])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bannedMedia []modelBannedMedia.BannedMedia
+	for _, image := range images {
+		// Media saved before uploads were stored without data URI headers may still have one
+		image, _ = media_validator.StripDataURI(image)
+		hash, hashErr := image_hash.Compute(image)
+		if hashErr != nil {
+			continue
 		}
-	} else {
-		postEntity.MarkedAsInappropriate = false
-		if err := s.postsRepository.Update(postEntity); err != nil {
-			return err
+
+		bannedMedia = append(bannedMedia, modelBannedMedia.BannedMedia{
+			Hash:   hash,
+			PostID: postEntity.ID,
+			Date:   time_utils.Now(),
+			Bands:  modelBannedMedia.NewBands(image_hash.Bands(hash, bannedMediaBands)),
+		})
+	}
+
+	return bannedMedia, mediaIDs[postEntity.ID], nil
+}
+
+// getPostSetting returns post's settings, posts without stored settings use the default ones
+func (s *postsService) getPostSetting(postID uint) (*modelPostSetting.PostSetting, rest_error.RestErr) {
+	setting, err := s.settingsRepository.GetByPost(postID)
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return &modelPostSetting.PostSetting{PostID: postID}, nil
 		}
+		return nil, err
 	}
+	return setting, nil
+}
 
-	return nil
+// audienceCache holds lookups deciding which authors' posts and comments a single viewer can see,
+// users they follow, users they muted and users blocked either way are loaded on first use
+type audienceCache struct {
+	following     map[string]bool
+	muted         map[string]bool
+	blocked       map[string]bool
+	closeFriendOf map[string]bool
+	profileAccess map[string]rest_error.RestErr
 }
 
-func (s *postsService) GetPostsFeed(user string) ([]dtos.PostDTO, rest_error.RestErr) {
-	getFollowingUsersRequest := dtos.GetFollowingUsersRequest{
-		UserEmail: user,
+func newAudienceCache() *audienceCache {
+	return &audienceCache{
+		closeFriendOf: make(map[string]bool),
+		profileAccess: make(map[string]rest_error.RestErr),
 	}
-	var followedUsers []string
-	var err error
+}
 
-	if followedUsers, err = s.userGrpcClient.GetFollowingUsers(getFollowingUsersRequest); err != nil {
-		return nil, rest_error.NewInternalServerError("user grpc client error when getting following users", err)
+func (s *postsService) getMutedUsers(user string) (map[string]bool, rest_error.RestErr) {
+	mutes, err := s.mutesRepository.GetByUser(user)
+	if err != nil {
+		return nil, err
 	}
 
-	var feedPosts []dtos.PostDTO
-	var posts []dtos.PostDTO
-	var restErr rest_error.RestErr
+	muted := make(map[string]bool, len(mutes))
+	for _, muteEntity := range mutes {
+		muted[muteEntity.MutedEmail] = true
+	}
+	return muted, nil
+}
 
-	for _, u := range followedUsers {
-		if posts, restErr = s.GetUsersPosts(u, user); restErr != nil {
-			return nil, restErr
+// isHidden tells whether user's comments are hidden from the viewer, because of a block in either direction or a mute
+func (s *postsService) isHidden(user string, viewer string, cache *audienceCache) (bool, rest_error.RestErr) {
+	if user == viewer || viewer == "" {
+		return false, nil
+	}
+
+	if cache.muted == nil {
+		muted, err := s.getMutedUsers(viewer)
+		if err != nil {
+			return false, err
 		}
-		feedPosts = append(feedPosts, posts...)
+		cache.muted = muted
+	}
+	if cache.muted[user] {
+		return true, nil
 	}
 
-	sort.Slice(feedPosts, func(i, j int) bool {
-		return feedPosts[i].Timestamp > feedPosts[j].Timestamp
-	})
+	if cache.blocked == nil {
+		blockedUsers, err := s.userGrpcClient.GetBlockedUsers(dtos.GetBlockedUsersRequest{UserEmail: viewer})
+		if err != nil {
+			return false, rest_error.NewInternalServerError("user grpc client error when getting blocked users", err)
+		}
+		cache.blocked = make(map[string]bool, len(blockedUsers))
+		for _, blockedUser := range blockedUsers {
+			cache.blocked[blockedUser] = true
+		}
+	}
+	return cache.blocked[user], nil
+}
 
-	return feedPosts, nil
+func (s *postsService) isFollowing(viewer string, author string, cache *audienceCache) (bool, rest_error.RestErr) {
+	if cache.following == nil {
+		getFollowingUsersRequest := dtos.GetFollowingUsersRequest{
+			UserEmail: viewer,
+		}
+		followedUsers, err := s.userGrpcClient.GetFollowingUsers(getFollowingUsersRequest)
+		if err != nil {
+			return false, rest_error.NewInternalServerError("user grpc client error when getting following users", err)
+		}
+		cache.following = make(map[string]bool, len(followedUsers))
+		for _, followedUser := range followedUsers {
+			cache.following[followedUser] = true
+		}
+	}
+	return cache.following[author], nil
+}
+
+func (s *postsService) isBlocked(user string, blockedUser string) (bool, rest_error.RestErr) {
+	checkIfUserIsBlockedRequest := dtos.CheckIfUserIsBlockedRequest{
+		User:        user,
+		BlockedUser: blockedUser,
+	}
+	blocked, err := s.userGrpcClient.CheckIfUserIsBlocked(checkIfUserIsBlockedRequest)
+	if err != nil {
+		return false, rest_error.NewInternalServerError("user grpc client error when checking blocked users", err)
+	}
+	return blocked, nil
+}
+
+// isBlockedEitherWay tells whether either of the users blocked the other
+func (s *postsService) isBlockedEitherWay(first string, second string) (bool, rest_error.RestErr) {
+	for _, users := range [][2]string{{first, second}, {second, first}} {
+		blocked, err := s.isBlocked(users[0], users[1])
+		if err != nil {
+			return false, err
+		}
+		if blocked {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkProfileAccess returns forbidden error when the viewer can not read author's profile, posts and comments.
+// A block in either direction hides the profile and private profiles are shown only to their followers.
+// Profiles whose access can not be checked are hidden too, the result is kept per author for the rest of the request.
+func (s *postsService) checkProfileAccess(author string, viewer string, cache *audienceCache) rest_error.RestErr {
+	if author == viewer {
+		return nil
+	}
+
+	if accessErr, ok := cache.profileAccess[author]; ok {
+		return accessErr
+	}
+
+	accessErr := s.getProfileAccess(author, viewer, cache)
+	if accessErr != nil && accessErr.Status() != http.StatusForbidden {
+		log.Printf("failed to check access of %s to profile of %s: %s", viewer, author, accessErr)
+		accessErr = rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+	}
+
+	cache.profileAccess[author] = accessErr
+	return accessErr
+}
+
+func (s *postsService) getProfileAccess(author string, viewer string, cache *audienceCache) rest_error.RestErr {
+	if viewer != "" {
+		blocked, err := s.isBlockedEitherWay(viewer, author)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+		}
+	}
+
+	private, err := s.userGrpcClient.CheckIfProfileIsPrivate(dtos.CheckIfProfileIsPrivateRequest{UserEmail: author})
+	if err != nil {
+		return rest_error.NewInternalServerError("user grpc client error when checking private profile", err)
+	}
+	if !private {
+		return nil
+	}
+
+	if viewer != "" {
+		following, restErr := s.isFollowing(viewer, author, cache)
+		if restErr != nil {
+			return restErr
+		}
+		if following {
+			return nil
+		}
+	}
+
+	return rest_error.NewRestError("Profile is private", http.StatusForbidden, "forbidden", nil)
+}
+
+// isInAudience tells whether the viewer can see a post of the author with given setting, authors always see their posts
+func (s *postsService) isInAudience(author string, setting *modelPostSetting.PostSetting, viewer string, cache *audienceCache) (bool, rest_error.RestErr) {
+	if author == viewer {
+		return true, nil
+	}
+
+	switch setting.GetAudience() {
+	case modelPostSetting.AudienceFollowers:
+		if viewer == "" {
+			return false, nil
+		}
+		return s.isFollowing(viewer, author, cache)
+	case modelPostSetting.AudienceCloseFriends:
+		if viewer == "" {
+			return false, nil
+		}
+		closeFriend, ok := cache.closeFriendOf[author]
+		if !ok {
+			var err rest_error.RestErr
+			if closeFriend, err = s.closeFriendsRepository.IsCloseFriend(author, viewer); err != nil {
+				return false, err
+			}
+			cache.closeFriendOf[author] = closeFriend
+		}
+		return closeFriend, nil
+	default:
+		return true, nil
+	}
+}
+
+// checkPostAccess returns forbidden error when the viewer can not read author's profile or is not in post's audience,
+// archived posts are not found by anyone but their author
+func (s *postsService) checkPostAccess(postEntity *modelPost.Post, viewer string, cache *audienceCache) rest_error.RestErr {
+	if err := s.checkProfileAccess(postEntity.UserEmail, viewer, cache); err != nil {
+		return err
+	}
+
+	setting, err := s.getPostSetting(postEntity.ID)
+	if err != nil {
+		return err
+	}
+	if setting.Archived && postEntity.UserEmail != viewer {
+		return rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get post with id %d", postEntity.ID))
+	}
+
+	visible, err := s.isInAudience(postEntity.UserEmail, setting, viewer, cache)
+	if err != nil {
+		return err
+	}
+
+	if !visible {
+		return rest_error.NewRestError("Post is visible only to its audience", http.StatusForbidden, "forbidden", nil)
+	}
+	return nil
+}
+
+func (s *postsService) SetAudience(audienceRequest *dtos.AudienceRequestDTO) rest_error.RestErr {
+	postEntity, err := s.postsRepository.Get(audienceRequest.PostID)
+	if err != nil {
+		return err
+	}
+
+	if postEntity.UserEmail != audienceRequest.UserEmail {
+		return rest_error.NewRestError("Only author can set post audience", http.StatusForbidden, "forbidden", nil)
+	}
+
+	if !modelPostSetting.IsValidAudience(audienceRequest.Audience) {
+		return rest_error.NewBadRequestError("Invalid audience")
+	}
+
+	setting, err := s.getPostSetting(postEntity.ID)
+	if err != nil {
+		return err
+	}
+	setting.Audience = audienceRequest.Audience
+
+	return s.settingsRepository.Update(setting, "audience")
+}
+
+func (s *postsService) GetCloseFriends(userEmail string) ([]modelCloseFriend.CloseFriend, rest_error.RestErr) {
+	return s.closeFriendsRepository.GetByUser(userEmail)
+}
+
+func (s *postsService) AddCloseFriend(closeFriend *modelCloseFriend.CloseFriend) rest_error.RestErr {
+	if closeFriend.UserEmail == "" || closeFriend.FriendEmail == "" {
+		return rest_error.NewBadRequestError("User and friend are required")
+	}
+
+	if closeFriend.UserEmail == closeFriend.FriendEmail {
+		return rest_error.NewBadRequestError("Users can not add themselves to close friends")
+	}
+
+	closeFriend.ID = 0
+	closeFriend.Date = time_utils.Now()
+
+	return s.closeFriendsRepository.Add(closeFriend)
+}
+
+func (s *postsService) RemoveCloseFriend(userEmail string, friendEmail string) rest_error.RestErr {
+	return s.closeFriendsRepository.Remove(userEmail, friendEmail)
+}
+
+func (s *postsService) GetMutedUsers(userEmail string) ([]dtos.MutedUserDTO, rest_error.RestErr) {
+	mutes, err := s.mutesRepository.GetByUser(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, 0, len(mutes))
+	for _, muteEntity := range mutes {
+		emails = append(emails, muteEntity.MutedEmail)
+	}
+	usernames, err := s.getUsernames(emails)
+	if err != nil {
+		return nil, err
+	}
+
+	mutedUsers := make([]dtos.MutedUserDTO, 0, len(mutes))
+	for _, muteEntity := range mutes {
+		mutedUsers = append(mutedUsers, dtos.MutedUserDTO{
+			Email:    muteEntity.MutedEmail,
+			Username: usernames[muteEntity.MutedEmail],
+			Date:     muteEntity.Date,
+		})
+	}
+
+	return mutedUsers, nil
+}
+
+func (s *postsService) MuteUser(muteEntity *modelMute.Mute) rest_error.RestErr {
+	if muteEntity.UserEmail == "" || muteEntity.MutedEmail == "" {
+		return rest_error.NewBadRequestError("User and muted user are required")
+	}
+
+	if muteEntity.UserEmail == muteEntity.MutedEmail {
+		return rest_error.NewBadRequestError("Users can not mute themselves")
+	}
+
+	muteEntity.ID = 0
+	muteEntity.Date = time_utils.Now()
+
+	return s.mutesRepository.Create(muteEntity)
+}
+
+func (s *postsService) UnmuteUser(userEmail string, mutedEmail string) rest_error.RestErr {
+	return s.mutesRepository.Delete(userEmail, mutedEmail)
+}
+
+func (s *postsService) SetContentWarning(contentWarningRequest *dtos.ContentWarningRequestDTO, moderator bool) rest_error.RestErr {
+	postEntity, err := s.postsRepository.Get(contentWarningRequest.PostID)
+	if err != nil {
+		return err
+	}
+
+	if !moderator && postEntity.UserEmail != contentWarningRequest.UserEmail {
+		return rest_error.NewRestError("Only author can set content warning", http.StatusForbidden, "forbidden", nil)
+	}
+
+	if !modelPostSetting.IsValidContentWarning(contentWarningRequest.ContentWarning) {
+		return rest_error.NewBadRequestError("Invalid content warning")
+	}
+
+	setting, err := s.getPostSetting(postEntity.ID)
+	if err != nil {
+		return err
+	}
+	setting.ContentWarning = contentWarningRequest.ContentWarning
+
+	return s.settingsRepository.Update(setting, "content_warning")
+}
+
+func (s *postsService) GetPostMedia(postID uint, loggedInUserEmail string) (*dtos.PostMediaDTO, rest_error.RestErr) {
+	postEntity, err := s.postsRepository.Get(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if postEntity.UserEmail != loggedInUserEmail && s.getAuthorRestriction(postEntity.UserEmail).ShadowBanned {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get post with id %d", postID))
+	}
+
+	if err := s.checkPostAccess(postEntity, loggedInUserEmail, newAudienceCache()); err != nil {
+		return nil, err
+	}
+
+	mediaIDs, err := s.getPostsMediaIDs([]modelPost.Post{*postEntity})
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := s.getImages(mediaIDs[postEntity.ID])
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.PostMediaDTO{
+		PostID: postEntity.ID,
+		Image:  images[0],
+		Media:  images,
+	}, nil
+}
+
+func (s *postsService) CreatePost(postDTO *dtos.CreatePostDTO) rest_error.RestErr {
+	if postDTO.PublishAt > time_utils.Now() {
+		return s.schedulePost(postDTO)
+	}
+
+	_, err := s.createPost(postDTO, nil)
+	return err
+}
+
+// validatePost checks whether a new post can be published, returning its images without data URI headers and whether it should be moderated
+func (s *postsService) validatePost(postDTO *dtos.CreatePostDTO) ([]string, bool, rest_error.RestErr) {
+	if !modelPostSetting.IsValidContentWarning(postDTO.ContentWarning) {
+		return nil, false, rest_error.NewBadRequestError("Invalid content warning")
+	}
+
+	if !modelPostSetting.IsValidMediaType(postDTO.MediaType) {
+		return nil, false, rest_error.NewBadRequestError("Invalid media type")
+	}
+
+	if !modelPostSetting.IsValidAudience(postDTO.Audience) {
+		return nil, false, rest_error.NewBadRequestError("Invalid audience")
+	}
+
+	if !modelPostSetting.IsValidCommentPolicy(postDTO.CommentPolicy) {
+		return nil, false, rest_error.NewBadRequestError("Invalid comment policy")
+	}
+
+	images := postDTO.GetImages()
+	if len(images) == 0 || len(images) > maxPostMedia {
+		return nil, false, rest_error.NewBadRequestError(fmt.Sprintf("Post must have between 1 and %d images", maxPostMedia))
+	}
+	for _, image := range images {
+		if image == "" {
+			return nil, false, rest_error.NewBadRequestError("Post images must not be empty")
+		}
+	}
+
+	if err := s.checkPostRateLimit(postDTO.UserEmail); err != nil {
+		return nil, false, err
+	}
+
+	flagged := false
+	contents := make([]string, 0, len(images))
+	for _, image := range images {
+		content, err := checkMedia(image, postDTO.MediaType)
+		if err != nil {
+			return nil, false, err
+		}
+		contents = append(contents, content)
+		if postDTO.MediaType == modelPostSetting.MediaTypeVideo {
+			continue
+		}
+
+		imageFlagged, bannedErr := s.checkBannedMedia(content)
+		if bannedErr != nil {
+			return nil, false, bannedErr
+		}
+		flagged = flagged || imageFlagged
+	}
+
+	return contents, flagged, nil
+}
+
+// checkMedia sniffs decoded media and checks it against the declared media type and its limits,
+// returning media without its data URI header which is the form media is saved and hashed in
+func checkMedia(mediaBase64 string, mediaType string) (string, rest_error.RestErr) {
+	mediaType = modelPostSetting.NormalizeMediaType(mediaType)
+
+	media, err := media_validator.Validate(mediaBase64)
+	if err != nil {
+		return "", rest_error.NewBadRequestError(fmt.Sprintf("Invalid media: %s", err))
+	}
+
+	if media.Type != mediaType {
+		return "", rest_error.NewBadRequestError(fmt.Sprintf("Media declared as %s is %s", mediaType, media.Type))
+	}
+
+	return media.Content, nil
+}
+
+// savePostMedia validates a new post and saves its media, returning media ids in order and whether post should be moderated.
+// If any image can not be saved, the ones saved before it are deleted.
+func (s *postsService) savePostMedia(postDTO *dtos.CreatePostDTO) ([]uint, bool, rest_error.RestErr) {
+	images, flagged, validationErr := s.validatePost(postDTO)
+	if validationErr != nil {
+		return nil, false, validationErr
+	}
+
+	mediaIDs := make([]uint, 0, len(images))
+	for _, image := range images {
+		saveMediaRequest := dtos.SaveMediaRequest{
+			Image: image,
+		}
+
+		mediaID, err := s.mediaGrpcClient.SaveMedia(saveMediaRequest)
+		if err != nil {
+			s.discardMedia(mediaIDs)
+			return nil, false, rest_error.NewInternalServerError("user grpc client error when saving media", err)
+		}
+		mediaIDs = append(mediaIDs, *mediaID)
+	}
+
+	return mediaIDs, flagged, nil
+}
+
+// deleteMedia deletes saved media which nothing refers to, every media is tried before the first failure is returned
+func (s *postsService) deleteMedia(mediaIDs []uint) rest_error.RestErr {
+	var deleteErr rest_error.RestErr
+	for _, mediaID := range mediaIDs {
+		deleteMediaRequest := dtos.DeleteMediaRequest{
+			ID: uint64(mediaID),
+		}
+		if err := s.mediaGrpcClient.DeleteMedia(deleteMediaRequest); err != nil && deleteErr == nil {
+			deleteErr = rest_error.NewInternalServerError(fmt.Sprintf("media grpc client error when deleting media %d", mediaID), err)
+		}
+	}
+	return deleteErr
+}
+
+// discardMedia deletes media a request leaves behind, the request's outcome does not depend on it so a failed deletion is logged
+func (s *postsService) discardMedia(mediaIDs []uint) {
+	if err := s.deleteMedia(mediaIDs); err != nil {
+		log.Printf("failed to discard media %v: %s", mediaIDs, err)
+	}
+}
+
+func (s *postsService) createPost(postDTO *dtos.CreatePostDTO, campaignEntity *modelCampaign.Campaign) (*modelPost.Post, rest_error.RestErr) {
+	mediaIDs, flagged, err := s.savePostMedia(postDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	postEntity, err := s.publishPost(postDTO, mediaIDs, flagged, post.Publication{Campaign: campaignEntity})
+	if err != nil {
+		s.discardMedia(mediaIDs)
+		return nil, err
+	}
+
+	return postEntity, nil
+}
+
+// publishPost stores a validated post whose media is already saved and adds it to followers' feeds.
+// Post's media, settings and campaign of a sponsored post are stored together with it and a post published from a draft replaces it.
+func (s *postsService) publishPost(postDTO *dtos.CreatePostDTO, mediaIDs []uint, flagged bool, publication post.Publication) (*modelPost.Post, rest_error.RestErr) {
+	postEntity := modelPost.Post{
+		Description:           postDTO.Description,
+		UserEmail:             postDTO.UserEmail,
+		MarkedAsInappropriate: flagged,
+		Date:                  time_utils.Now(),
+		MediaID:               mediaIDs[0],
+	}
+	publication.Post = &postEntity
+
+	if len(mediaIDs) > 1 {
+		for position, mediaID := range mediaIDs {
+			publication.Media = append(publication.Media, modelPostMedia.PostMedia{
+				MediaID:  mediaID,
+				Position: position,
+			})
+		}
+	}
+
+	setting := modelPostSetting.PostSetting{
+		ContentWarning: postDTO.ContentWarning,
+		MediaType:      postDTO.MediaType,
+		Audience:       postDTO.Audience,
+		CommentPolicy:  postDTO.CommentPolicy,
+	}
+	if !setting.IsDefault() {
+		publication.Setting = &setting
+	}
+
+	if publication.Campaign != nil {
+		publication.Campaign.AgentEmail = postEntity.UserEmail
+		publication.Campaign.Date = postEntity.Date
+	}
+	if err := s.postsRepository.Publish(&publication); err != nil {
+		return nil, err
+	}
+
+	s.savePostTerms(postEntity.Description, postEntity.ID, 0, postEntity.UserEmail, postEntity.Date)
+
+	// The post is stored already, failed fan-out is retried instead of failing the request
+	if err := s.fanOutPost(&postEntity); err != nil {
+		s.deferFanOut(&postEntity, err)
+	}
+
+	return &postEntity, nil
+}
+
+func checkPublishAt(publishAt int64) rest_error.RestErr {
+	now := time_utils.Now()
+	if publishAt <= now || publishAt > now+maxScheduleAhead {
+		return rest_error.NewBadRequestError(fmt.Sprintf("Publish time must be in the next %d days", maxScheduleAhead/secondsInDay))
+	}
+	return nil
+}
+
+func (s *postsService) schedulePost(postDTO *dtos.CreatePostDTO) rest_error.RestErr {
+	if err := checkPublishAt(postDTO.PublishAt); err != nil {
+		return err
+	}
+
+	mediaIDs, flagged, err := s.savePostMedia(postDTO)
+	if err != nil {
+		return err
+	}
+
+	scheduledPost := modelScheduledPost.ScheduledPost{
+		Description:           postDTO.Description,
+		UserEmail:             postDTO.UserEmail,
+		MarkedAsInappropriate: flagged,
+		ContentWarning:        postDTO.ContentWarning,
+		MediaType:             postDTO.MediaType,
+		Audience:              postDTO.Audience,
+		CommentPolicy:         postDTO.CommentPolicy,
+		PublishAt:             postDTO.PublishAt,
+		Date:                  time_utils.Now(),
+	}
+	scheduledPost.SetMediaIDs(mediaIDs)
+
+	if err := s.scheduledPostsRepository.Create(&scheduledPost); err != nil {
+		s.discardMedia(mediaIDs)
+		return err
+	}
+
+	return nil
+}
+
+func (s *postsService) GetScheduledPosts(userEmail string) ([]dtos.ScheduledPostDTO, rest_error.RestErr) {
+	scheduledPosts, err := s.scheduledPostsRepository.GetByUser(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledPostDTOs := make([]dtos.ScheduledPostDTO, 0, len(scheduledPosts))
+	for _, scheduledPost := range scheduledPosts {
+		images, err := s.getImages(scheduledPost.GetMediaIDs())
+		if err != nil {
+			return nil, err
+		}
+
+		scheduledPostDTOs = append(scheduledPostDTOs, dtos.ScheduledPostDTO{
+			ID:             scheduledPost.ID,
+			Description:    scheduledPost.Description,
+			Image:          images[0],
+			Media:          images,
+			MediaType:      modelPostSetting.NormalizeMediaType(scheduledPost.MediaType),
+			ContentWarning: scheduledPost.ContentWarning,
+			Audience:       modelPostSetting.NormalizeAudience(scheduledPost.Audience),
+			CommentPolicy:  modelPostSetting.NormalizeCommentPolicy(scheduledPost.CommentPolicy),
+			PublishAt:      scheduledPost.PublishAt,
+			Date:           scheduledPost.Date,
+		})
+	}
+
+	return scheduledPostDTOs, nil
+}
+
+func (s *postsService) getOwnScheduledPost(id uint, userEmail string) (*modelScheduledPost.ScheduledPost, rest_error.RestErr) {
+	scheduledPost, err := s.scheduledPostsRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledPost.UserEmail != userEmail {
+		return nil, rest_error.NewRestError("Only author can change a scheduled post", http.StatusForbidden, "forbidden", nil)
+	}
+
+	return scheduledPost, nil
+}
+
+func (s *postsService) ReschedulePost(id uint, rescheduleRequest *dtos.ReschedulePostDTO) rest_error.RestErr {
+	if err := checkPublishAt(rescheduleRequest.PublishAt); err != nil {
+		return err
+	}
+
+	if _, err := s.getOwnScheduledPost(id, rescheduleRequest.UserEmail); err != nil {
+		return err
+	}
+
+	return s.scheduledPostsRepository.Reschedule(id, rescheduleRequest.PublishAt)
+}
+
+func (s *postsService) CancelScheduledPost(id uint, userEmail string) rest_error.RestErr {
+	scheduledPost, err := s.getOwnScheduledPost(id, userEmail)
+	if err != nil {
+		return err
+	}
+
+	return s.scheduledPostsRepository.Delete(scheduledPost)
+}
+
+// checkScheduledPost validates media of a due scheduled post against media banned since it was scheduled,
+// returning whether the post should be moderated. Images matching removed content are moderated as the author is not around.
+func (s *postsService) checkScheduledPost(scheduledPost *modelScheduledPost.ScheduledPost) (bool, rest_error.RestErr) {
+	if modelPostSetting.NormalizeMediaType(scheduledPost.MediaType) == modelPostSetting.MediaTypeVideo {
+		return scheduledPost.MarkedAsInappropriate, nil
+	}
+
+	images, err := s.getImages(scheduledPost.GetMediaIDs())
+	if err != nil {
+		return false, err
+	}
+
+	flagged := false
+	for _, image := range images {
+		image, _ = media_validator.StripDataURI(image)
+		imageFlagged, bannedErr := s.checkBannedMedia(image)
+		if bannedErr != nil {
+			if bannedErr.Status() != http.StatusBadRequest {
+				return false, bannedErr
+			}
+			imageFlagged = true
+		}
+		flagged = flagged || imageFlagged
+	}
+
+	return flagged, nil
+}
+
+// PublishScheduledPosts publishes due scheduled posts in batches and fans them out to followers' feeds.
+// Posts which can not be validated stay scheduled and are postponed, so they do not hold back posts due after them.
+func (s *postsService) PublishScheduledPosts() rest_error.RestErr {
+	for {
+		now := time_utils.Now()
+		due, err := s.scheduledPostsRepository.GetDue(now, scheduledPublishBatchSize)
+		if err != nil {
+			return err
+		}
+
+		flagged := make(map[uint]bool, len(due))
+		postponed := 0
+		for i := range due {
+			postFlagged, err := s.checkScheduledPost(&due[i])
+			if err != nil {
+				log.Printf("failed to validate scheduled post %d: %s", due[i].ID, err)
+				retryAt := now + scheduledRetryDelay*int64(due[i].FailedAttempts+1)
+				if err := s.scheduledPostsRepository.Postpone(due[i].ID, retryAt); err != nil {
+					return err
+				}
+				postponed++
+				continue
+			}
+			flagged[due[i].ID] = postFlagged
+		}
+
+		published, err := s.scheduledPostsRepository.PublishDue(now, flagged)
+		if err != nil {
+			return err
+		}
+
+		for i := range published {
+			s.savePostTerms(published[i].Description, published[i].ID, 0, published[i].UserEmail, published[i].Date)
+			if err := s.fanOutPost(&published[i]); err != nil {
+				s.deferFanOut(&published[i], err)
+			}
+		}
+
+		// Posts left due were taken by another replica, they are not waited for
+		if len(due) < scheduledPublishBatchSize || len(published)+postponed == 0 {
+			return nil
+		}
+	}
+}
+
+func (s *postsService) getDraftDTO(draftEntity *modelDraft.Draft) (*dtos.DraftDTO, rest_error.RestErr) {
+	var image string
+	if draftEntity.MediaID != 0 {
+		getMediaRequest := dtos.GetMediaRequest{
+			ID: uint64(draftEntity.MediaID),
+		}
+		var mediaErr error
+		if image, mediaErr = s.mediaGrpcClient.GetMedia(getMediaRequest); mediaErr != nil {
+			return nil, rest_error.NewInternalServerError("media grpc client error when getting media", mediaErr)
+		}
+	}
+
+	return &dtos.DraftDTO{
+		ID:             draftEntity.ID,
+		Description:    draftEntity.Description,
+		Image:          image,
+		MediaType:      modelPostSetting.NormalizeMediaType(draftEntity.MediaType),
+		ContentWarning: draftEntity.ContentWarning,
+		Audience:       modelPostSetting.NormalizeAudience(draftEntity.Audience),
+		CommentPolicy:  modelPostSetting.NormalizeCommentPolicy(draftEntity.CommentPolicy),
+		Date:           draftEntity.Date,
+		Updated:        draftEntity.Updated,
+	}, nil
+}
+
+// setDraftContent copies draft's content from request, a new image is saved right away while an empty one keeps the current image
+func (s *postsService) setDraftContent(draftEntity *modelDraft.Draft, draftDTO *dtos.CreatePostDTO) rest_error.RestErr {
+	if !modelPostSetting.IsValidContentWarning(draftDTO.ContentWarning) {
+		return rest_error.NewBadRequestError("Invalid content warning")
+	}
+
+	if !modelPostSetting.IsValidMediaType(draftDTO.MediaType) {
+		return rest_error.NewBadRequestError("Invalid media type")
+	}
+
+	if !modelPostSetting.IsValidAudience(draftDTO.Audience) {
+		return rest_error.NewBadRequestError("Invalid audience")
+	}
+
+	if !modelPostSetting.IsValidCommentPolicy(draftDTO.CommentPolicy) {
+		return rest_error.NewBadRequestError("Invalid comment policy")
+	}
+
+	if draftDTO.Image != "" {
+		image, mediaErr := checkMedia(draftDTO.Image, draftDTO.MediaType)
+		if mediaErr != nil {
+			return mediaErr
+		}
+
+		saveMediaRequest := dtos.SaveMediaRequest{
+			Image: image,
+		}
+		mediaID, err := s.mediaGrpcClient.SaveMedia(saveMediaRequest)
+		if err != nil {
+			return rest_error.NewInternalServerError("media grpc client error when saving media", err)
+		}
+		draftEntity.MediaID = *mediaID
+	}
+
+	draftEntity.Description = draftDTO.Description
+	draftEntity.ContentWarning = draftDTO.ContentWarning
+	draftEntity.MediaType = draftDTO.MediaType
+	draftEntity.Audience = draftDTO.Audience
+	draftEntity.CommentPolicy = draftDTO.CommentPolicy
+	draftEntity.Updated = time_utils.Now()
+	return nil
+}
+
+func (s *postsService) CreateDraft(draftDTO *dtos.CreatePostDTO) (*dtos.DraftDTO, rest_error.RestErr) {
+	draftEntity := modelDraft.Draft{
+		UserEmail: draftDTO.UserEmail,
+		Date:      time_utils.Now(),
+	}
+
+	if err := s.setDraftContent(&draftEntity, draftDTO); err != nil {
+		return nil, err
+	}
+
+	if err := s.draftsRepository.Create(&draftEntity); err != nil {
+		s.deleteDraftMedia(draftEntity.MediaID)
+		return nil, err
+	}
+
+	return &dtos.DraftDTO{
+		ID:             draftEntity.ID,
+		Description:    draftEntity.Description,
+		Image:          draftDTO.Image,
+		MediaType:      modelPostSetting.NormalizeMediaType(draftEntity.MediaType),
+		ContentWarning: draftEntity.ContentWarning,
+		Audience:       modelPostSetting.NormalizeAudience(draftEntity.Audience),
+		CommentPolicy:  modelPostSetting.NormalizeCommentPolicy(draftEntity.CommentPolicy),
+		Date:           draftEntity.Date,
+		Updated:        draftEntity.Updated,
+	}, nil
+}
+
+func (s *postsService) GetDrafts(userEmail string) ([]dtos.DraftDTO, rest_error.RestErr) {
+	drafts, err := s.draftsRepository.GetByUser(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	draftDTOs := make([]dtos.DraftDTO, 0, len(drafts))
+	for i := range drafts {
+		draftDTO, err := s.getDraftDTO(&drafts[i])
+		if err != nil {
+			return nil, err
+		}
+		draftDTOs = append(draftDTOs, *draftDTO)
+	}
+
+	return draftDTOs, nil
+}
+
+func (s *postsService) getOwnDraft(id uint, userEmail string) (*modelDraft.Draft, rest_error.RestErr) {
+	draftEntity, err := s.draftsRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if draftEntity.UserEmail != userEmail {
+		return nil, rest_error.NewRestError("Only author can access a draft", http.StatusForbidden, "forbidden", nil)
+	}
+
+	return draftEntity, nil
+}
+
+func (s *postsService) GetDraft(id uint, userEmail string) (*dtos.DraftDTO, rest_error.RestErr) {
+	draftEntity, err := s.getOwnDraft(id, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDraftDTO(draftEntity)
+}
+
+// deleteDraftMedia deletes media no draft refers to anymore, drafts without an image have none
+func (s *postsService) deleteDraftMedia(mediaID uint) {
+	if mediaID != 0 {
+		s.discardMedia([]uint{mediaID})
+	}
+}
+
+// UpdateDraft replaces draft's content, a replaced image is deleted once the draft refers to the new one
+func (s *postsService) UpdateDraft(id uint, draftDTO *dtos.CreatePostDTO) (*dtos.DraftDTO, rest_error.RestErr) {
+	draftEntity, err := s.getOwnDraft(id, draftDTO.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	previousMediaID := draftEntity.MediaID
+	if err := s.setDraftContent(draftEntity, draftDTO); err != nil {
+		return nil, err
+	}
+
+	replaced := draftEntity.MediaID != previousMediaID
+	if err := s.draftsRepository.Update(draftEntity); err != nil {
+		if replaced {
+			s.deleteDraftMedia(draftEntity.MediaID)
+		}
+		return nil, err
+	}
+
+	if replaced {
+		s.deleteDraftMedia(previousMediaID)
+	}
+
+	return s.getDraftDTO(draftEntity)
+}
+
+func (s *postsService) DeleteDraft(id uint, userEmail string) rest_error.RestErr {
+	draftEntity, err := s.getOwnDraft(id, userEmail)
+	if err != nil {
+		return err
+	}
+
+	if err := s.draftsRepository.Delete(draftEntity); err != nil {
+		return err
+	}
+
+	s.deleteDraftMedia(draftEntity.MediaID)
+	return nil
+}
+
+// PublishDraft validates a draft like a new post and publishes it reusing the already saved media
+func (s *postsService) PublishDraft(id uint, userEmail string) rest_error.RestErr {
+	draftEntity, err := s.getOwnDraft(id, userEmail)
+	if err != nil {
+		return err
+	}
+
+	if draftEntity.MediaID == 0 {
+		return rest_error.NewBadRequestError("Draft has no image")
+	}
+
+	draftDTO, err := s.getDraftDTO(draftEntity)
+	if err != nil {
+		return err
+	}
+
+	postDTO := dtos.CreatePostDTO{
+		Description:    draftEntity.Description,
+		Image:          draftDTO.Image,
+		UserEmail:      draftEntity.UserEmail,
+		ContentWarning: draftEntity.ContentWarning,
+		MediaType:      draftEntity.MediaType,
+		Audience:       draftEntity.Audience,
+		CommentPolicy:  draftEntity.CommentPolicy,
+	}
+
+	_, flagged, err := s.validatePost(&postDTO)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.publishPost(&postDTO, []uint{draftEntity.MediaID}, flagged, post.Publication{Draft: draftEntity})
+	return err
+}
+
+func (s *postsService) CreateStory(storyDTO *dtos.CreateStoryDTO) rest_error.RestErr {
+	if !modelPostSetting.IsValidMediaType(storyDTO.MediaType) {
+		return rest_error.NewBadRequestError("Invalid media type")
+	}
+
+	image, mediaErr := checkMedia(storyDTO.Image, storyDTO.MediaType)
+	if mediaErr != nil {
+		return mediaErr
+	}
+
+	// Stories are not moderated, so images which would flag a post are rejected
+	if storyDTO.MediaType != modelPostSetting.MediaTypeVideo {
+		flagged, err := s.checkBannedMedia(image)
+		if err != nil {
+			return err
+		}
+		if flagged {
+			return rest_error.NewBadRequestError("Image matches previously removed content")
+		}
+	}
+
+	saveMediaRequest := dtos.SaveMediaRequest{
+		Image: image,
+	}
+	mediaID, err := s.mediaGrpcClient.SaveMedia(saveMediaRequest)
+	if err != nil {
+		return rest_error.NewInternalServerError("media grpc client error when saving media", err)
+	}
+
+	now := time_utils.Now()
+	storyEntity := modelStory.Story{
+		UserEmail: storyDTO.UserEmail,
+		MediaID:   *mediaID,
+		MediaType: modelPostSetting.NormalizeMediaType(storyDTO.MediaType),
+		Date:      now,
+		ExpiresAt: now + storyLifetime,
+	}
+
+	if err := s.storiesRepository.Create(&storyEntity); err != nil {
+		s.discardMedia([]uint{storyEntity.MediaID})
+		return err
+	}
+
+	return nil
+}
+
+// GetStoriesTray returns active stories of the user and users they follow grouped by author.
+// User's own stories come first, followed by authors with unseen stories and then the rest, most recently active first.
+// Stories of authors blocked in either direction are left out, media and usernames are loaded in one call each.
+func (s *postsService) GetStoriesTray(user string) ([]dtos.StoryTrayItemDTO, rest_error.RestErr) {
+	getFollowingUsersRequest := dtos.GetFollowingUsersRequest{
+		UserEmail: user,
+	}
+	followedUsers, err := s.userGrpcClient.GetFollowingUsers(getFollowingUsersRequest)
+	if err != nil {
+		return nil, rest_error.NewInternalServerError("user grpc client error when getting following users", err)
+	}
+
+	stories, restErr := s.storiesRepository.GetActiveByUsers(append([]string{user}, followedUsers...), time_utils.Now())
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	shadowBanned := make(map[string]bool)
+	hidden := make(map[string]bool)
+	listed := make(map[string]bool)
+	var authors []string
+	var visibleStories []modelStory.Story
+	for _, storyEntity := range stories {
+		author := storyEntity.UserEmail
+		if author != user {
+			authorHidden, ok := hidden[author]
+			if !ok {
+				authorHidden = s.isShadowBanned(author, shadowBanned)
+				if !authorHidden {
+					if authorHidden, restErr = s.isBlockedEitherWay(user, author); restErr != nil {
+						return nil, restErr
+					}
+				}
+				hidden[author] = authorHidden
+			}
+			if authorHidden {
+				continue
+			}
+		}
+
+		if !listed[author] {
+			listed[author] = true
+			authors = append(authors, author)
+		}
+		visibleStories = append(visibleStories, storyEntity)
+	}
+
+	storyIDs := make([]uint, 0, len(visibleStories))
+	mediaIDs := make([]uint, 0, len(visibleStories))
+	for _, storyEntity := range visibleStories {
+		storyIDs = append(storyIDs, storyEntity.ID)
+		mediaIDs = append(mediaIDs, storyEntity.MediaID)
+	}
+	viewed, restErr := s.storiesRepository.GetViewedStories(user, storyIDs)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	images, restErr := s.getMediaBatch(mediaIDs)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	usernames, restErr := s.getUsernames(authors)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	authorStories := make(map[string][]dtos.StoryDTO)
+	latest := make(map[string]int64)
+	for _, storyEntity := range visibleStories {
+		authorStories[storyEntity.UserEmail] = append(authorStories[storyEntity.UserEmail], dtos.StoryDTO{
+			ID:        storyEntity.ID,
+			Image:     images[storyEntity.MediaID],
+			MediaType: storyEntity.MediaType,
+			Date:      storyEntity.Date,
+			ExpiresAt: storyEntity.ExpiresAt,
+			Seen:      storyEntity.UserEmail == user || viewed[storyEntity.ID],
+		})
+		latest[storyEntity.UserEmail] = storyEntity.Date
+	}
+
+	type trayItem struct {
+		author string
+		dto    dtos.StoryTrayItemDTO
+	}
+	items := make([]trayItem, 0, len(authors))
+	for _, author := range authors {
+		seen := true
+		for _, storyDTO := range authorStories[author] {
+			seen = seen && storyDTO.Seen
+		}
+
+		items = append(items, trayItem{
+			author: author,
+			dto: dtos.StoryTrayItemDTO{
+				Username: usernames[author],
+				Stories:  authorStories[author],
+				Seen:     seen,
+			},
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if (items[i].author == user) != (items[j].author == user) {
+			return items[i].author == user
+		}
+		if items[i].dto.Seen != items[j].dto.Seen {
+			return !items[i].dto.Seen
+		}
+		return latest[items[i].author] > latest[items[j].author]
+	})
+
+	tray := make([]dtos.StoryTrayItemDTO, 0, len(items))
+	for _, item := range items {
+		tray = append(tray, item.dto)
+	}
+
+	return tray, nil
+}
+
+// getMediaBatch returns content of given media mapped by media id, loaded with a single call to media service
+func (s *postsService) getMediaBatch(mediaIDs []uint) (map[uint]string, rest_error.RestErr) {
+	images := make(map[uint]string, len(mediaIDs))
+	if len(mediaIDs) == 0 {
+		return images, nil
+	}
+
+	ids := make([]uint64, 0, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		ids = append(ids, uint64(mediaID))
+	}
+	media, err := s.mediaGrpcClient.GetMediaBatch(dtos.GetMediaBatchRequest{IDs: ids})
+	if err != nil {
+		return nil, rest_error.NewInternalServerError("media grpc client error when getting media", err)
+	}
+
+	for _, mediaID := range mediaIDs {
+		image, ok := media[uint64(mediaID)]
+		if !ok {
+			return nil, rest_error.NewInternalServerError(fmt.Sprintf("media grpc client error when getting media %d", mediaID), nil)
+		}
+		images[mediaID] = image
+	}
+	return images, nil
+}
+
+// getUsernames returns usernames of given users mapped by email, loaded with a single call to users service
+func (s *postsService) getUsernames(userEmails []string) (map[string]string, rest_error.RestErr) {
+	if len(userEmails) == 0 {
+		return make(map[string]string), nil
+	}
+
+	usernames, err := s.userGrpcClient.GetUsernames(dtos.GetUsernamesRequest{Emails: userEmails})
+	if err != nil {
+		return nil, rest_error.NewInternalServerError("user grpc client error when getting usernames", err)
+	}
+	return usernames, nil
+}
+
+func (s *postsService) getActiveStory(storyID uint) (*modelStory.Story, rest_error.RestErr) {
+	storyEntity, err := s.storiesRepository.Get(storyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if storyEntity.ExpiresAt <= time_utils.Now() {
+		return nil, rest_error.NewNotFoundError(fmt.Sprintf("Story with id %d has expired", storyID))
+	}
+
+	return storyEntity, nil
+}
+
+func (s *postsService) ViewStory(storyID uint, user string) rest_error.RestErr {
+	storyEntity, err := s.getActiveStory(storyID)
+	if err != nil {
+		return err
+	}
+
+	if storyEntity.UserEmail == user {
+		return nil
+	}
+
+	if err := s.checkProfileAccess(storyEntity.UserEmail, user, newAudienceCache()); err != nil {
+		return err
+	}
+
+	view := modelStory.StoryView{
+		StoryID:   storyEntity.ID,
+		UserEmail: user,
+		Date:      time_utils.Now(),
+	}
+
+	return s.storiesRepository.RecordView(&view)
+}
+
+func (s *postsService) GetStoryViewers(storyID uint, user string) ([]dtos.StoryViewerDTO, rest_error.RestErr) {
+	storyEntity, err := s.getActiveStory(storyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if storyEntity.UserEmail != user {
+		return nil, rest_error.NewRestError("Only author can see story viewers", http.StatusForbidden, "forbidden", nil)
+	}
+
+	views, err := s.storiesRepository.GetViews(storyEntity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, 0, len(views))
+	for _, view := range views {
+		emails = append(emails, view.UserEmail)
+	}
+	usernames, err := s.getUsernames(emails)
+	if err != nil {
+		return nil, err
+	}
+
+	viewers := make([]dtos.StoryViewerDTO, 0, len(views))
+	for _, view := range views {
+		viewers = append(viewers, dtos.StoryViewerDTO{
+			Username: usernames[view.UserEmail],
+			Date:     view.Date,
+		})
+	}
+
+	return viewers, nil
+}
+
+// SweepExpiredStories removes expired stories together with their media.
+// Stories are removed only once their media is deleted, the rest stay until the next run.
+func (s *postsService) SweepExpiredStories() rest_error.RestErr {
+	expired, err := s.storiesRepository.GetExpired(time_utils.Now())
+	if err != nil {
+		return err
+	}
+
+	deleted := make([]uint, 0, len(expired))
+	for _, storyEntity := range expired {
+		if err := s.deleteMedia([]uint{storyEntity.MediaID}); err != nil {
+			log.Printf("failed to delete media of story %d: %s", storyEntity.ID, err)
+			continue
+		}
+		deleted = append(deleted, storyEntity.ID)
+	}
+
+	return s.storiesRepository.DeleteExpired(deleted)
+}
+
+func (s *postsService) getStoryDTO(storyEntity *modelStory.Story, seen bool) (*dtos.StoryDTO, rest_error.RestErr) {
+	images, err := s.getImages([]uint{storyEntity.MediaID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.StoryDTO{
+		ID:        storyEntity.ID,
+		Image:     images[0],
+		MediaType: storyEntity.MediaType,
+		Date:      storyEntity.Date,
+		ExpiresAt: storyEntity.ExpiresAt,
+		Seen:      seen,
+	}, nil
+}
+
+// ArchiveStory keeps author's story and its media after the story expires
+func (s *postsService) ArchiveStory(storyID uint, user string) rest_error.RestErr {
+	storyEntity, err := s.storiesRepository.Get(storyID)
+	if err != nil {
+		return err
+	}
+
+	if storyEntity.UserEmail != user {
+		return rest_error.NewRestError("Only author can archive a story", http.StatusForbidden, "forbidden", nil)
+	}
+
+	if storyEntity.Archived {
+		return nil
+	}
+
+	return s.storiesRepository.Archive(storyEntity.ID)
+}
+
+func (s *postsService) GetArchivedStories(user string) ([]dtos.StoryDTO, rest_error.RestErr) {
+	stories, err := s.storiesRepository.GetArchived(user)
+	if err != nil {
+		return nil, err
+	}
+
+	storyDTOs := make([]dtos.StoryDTO, 0, len(stories))
+	for i := range stories {
+		storyDTO, err := s.getStoryDTO(&stories[i], true)
+		if err != nil {
+			return nil, err
+		}
+		storyDTOs = append(storyDTOs, *storyDTO)
+	}
+
+	return storyDTOs, nil
+}
+
+// checkHighlight validates highlight's name and checks that all its stories belong to the author
+func (s *postsService) checkHighlight(highlightDTO *dtos.HighlightRequestDTO) rest_error.RestErr {
+	highlightDTO.Name = strings.TrimSpace(highlightDTO.Name)
+	if len(highlightDTO.Name) == 0 || len([]rune(highlightDTO.Name)) > maxHighlightNameLength {
+		return rest_error.NewBadRequestError(fmt.Sprintf("Highlight name must have between 1 and %d characters", maxHighlightNameLength))
+	}
+
+	if len(highlightDTO.StoryIDs) == 0 || len(highlightDTO.StoryIDs) > maxHighlightStories {
+		return rest_error.NewBadRequestError(fmt.Sprintf("Highlight must have between 1 and %d stories", maxHighlightStories))
+	}
+
+	unique := make(map[uint]bool)
+	for _, storyID := range highlightDTO.StoryIDs {
+		if unique[storyID] {
+			return rest_error.NewBadRequestError(fmt.Sprintf("Story with id %d is added more than once", storyID))
+		}
+		unique[storyID] = true
+	}
+
+	stories, err := s.storiesRepository.GetByIDs(highlightDTO.StoryIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(stories) != len(highlightDTO.StoryIDs) {
+		return rest_error.NewNotFoundError("Some of highlight's stories do not exist")
+	}
+
+	for _, storyEntity := range stories {
+		if storyEntity.UserEmail != highlightDTO.UserEmail {
+			return rest_error.NewRestError("Only author can add a story to a highlight", http.StatusForbidden, "forbidden", nil)
+		}
+	}
+
+	return nil
+}
+
+// getHighlightDTOs attaches stories to highlights, reusing their stored media
+func (s *postsService) getHighlightDTOs(highlights []modelHighlight.Highlight) ([]dtos.HighlightDTO, rest_error.RestErr) {
+	highlightIDs := make([]uint, 0, len(highlights))
+	for _, highlightEntity := range highlights {
+		highlightIDs = append(highlightIDs, highlightEntity.ID)
+	}
+
+	items, err := s.highlightsRepository.GetStories(highlightIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	storyIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		storyIDs = append(storyIDs, item.StoryID)
+	}
+
+	stories, err := s.storiesRepository.GetByIDs(storyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	storyDTOs := make(map[uint]dtos.StoryDTO)
+	for i := range stories {
+		storyDTO, err := s.getStoryDTO(&stories[i], true)
+		if err != nil {
+			return nil, err
+		}
+		storyDTOs[stories[i].ID] = *storyDTO
+	}
+
+	highlightStories := make(map[uint][]dtos.StoryDTO)
+	for _, item := range items {
+		if storyDTO, ok := storyDTOs[item.StoryID]; ok {
+			highlightStories[item.HighlightID] = append(highlightStories[item.HighlightID], storyDTO)
+		}
+	}
+
+	highlightDTOs := make([]dtos.HighlightDTO, 0, len(highlights))
+	for _, highlightEntity := range highlights {
+		highlightDTOs = append(highlightDTOs, dtos.HighlightDTO{
+			ID:      highlightEntity.ID,
+			Name:    highlightEntity.Name,
+			Stories: highlightStories[highlightEntity.ID],
+			Date:    highlightEntity.Date,
+		})
+	}
+
+	return highlightDTOs, nil
+}
+
+func (s *postsService) CreateHighlight(highlightDTO *dtos.HighlightRequestDTO) (*dtos.HighlightDTO, rest_error.RestErr) {
+	if err := s.checkHighlight(highlightDTO); err != nil {
+		return nil, err
+	}
+
+	highlightEntity := modelHighlight.Highlight{
+		UserEmail: highlightDTO.UserEmail,
+		Name:      highlightDTO.Name,
+		Date:      time_utils.Now(),
+	}
+	if err := s.highlightsRepository.Create(&highlightEntity, highlightDTO.StoryIDs); err != nil {
+		return nil, err
+	}
+
+	highlightDTOs, err := s.getHighlightDTOs([]modelHighlight.Highlight{highlightEntity})
+	if err != nil {
+		return nil, err
+	}
+
+	return &highlightDTOs[0], nil
+}
+
+// GetHighlights returns author's highlights to a signed in viewer who can access author's profile
+func (s *postsService) GetHighlights(author string, viewer string) ([]dtos.HighlightDTO, rest_error.RestErr) {
+	if err := s.checkProfileAccess(author, viewer, newAudienceCache()); err != nil {
+		return nil, err
+	}
+
+	highlights, err := s.highlightsRepository.GetByUser(author)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getHighlightDTOs(highlights)
+}
+
+func (s *postsService) getOwnHighlight(highlightID uint, user string) (*modelHighlight.Highlight, rest_error.RestErr) {
+	highlightEntity, err := s.highlightsRepository.Get(highlightID)
+	if err != nil {
+		return nil, err
+	}
+
+	if highlightEntity.UserEmail != user {
+		return nil, rest_error.NewRestError("Only author can change a highlight", http.StatusForbidden, "forbidden", nil)
+	}
+
+	return highlightEntity, nil
+}
+
+func (s *postsService) UpdateHighlight(highlightID uint, highlightDTO *dtos.HighlightRequestDTO) (*dtos.HighlightDTO, rest_error.RestErr) {
+	highlightEntity, err := s.getOwnHighlight(highlightID, highlightDTO.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkHighlight(highlightDTO); err != nil {
+		return nil, err
+	}
+
+	highlightEntity.Name = highlightDTO.Name
+	if err := s.highlightsRepository.Update(highlightEntity, highlightDTO.StoryIDs); err != nil {
+		return nil, err
+	}
+
+	highlightDTOs, err := s.getHighlightDTOs([]modelHighlight.Highlight{*highlightEntity})
+	if err != nil {
+		return nil, err
+	}
+
+	return &highlightDTOs[0], nil
+}
+
+// DeleteHighlight removes a highlight, its stories stay in author's archive
+func (s *postsService) DeleteHighlight(highlightID uint, user string) rest_error.RestErr {
+	highlightEntity, err := s.getOwnHighlight(highlightID, user)
+	if err != nil {
+		return err
+	}
+
+	return s.highlightsRepository.Delete(highlightEntity)
+}
+
+// fanOutPost adds a new post to the feeds of author's followers.
+// Posts of large accounts are read on request instead.
+func (s *postsService) fanOutPost(postEntity *modelPost.Post) rest_error.RestErr {
+	followers, err := s.userGrpcClient.GetFollowers(dtos.GetFollowersRequest{UserEmail: postEntity.UserEmail})
+	if err != nil {
+		return rest_error.NewInternalServerError("user grpc client error when getting followers", err)
+	}
+
+	if len(followers) > fanOutFollowerLimit {
+		largeAccount := modelLargeAccount.LargeAccount{
+			UserEmail: postEntity.UserEmail,
+			Followers: int64(len(followers)),
+			Date:      postEntity.Date,
+		}
+		return s.largeAccountsRepository.Save(&largeAccount)
+	}
+
+	items := make([]modelFeedItem.FeedItem, 0, len(followers))
+	for _, follower := range followers {
+		items = append(items, modelFeedItem.FeedItem{
+			UserEmail:   follower,
+			PostID:      postEntity.ID,
+			AuthorEmail: postEntity.UserEmail,
+			Date:        postEntity.Date,
+		})
+	}
+
+	return s.feedItemsRepository.CreateMany(items)
+}
+
+// deferFanOut records a post whose fan-out failed so RetryFanOuts adds it to followers' feeds later
+func (s *postsService) deferFanOut(postEntity *modelPost.Post, fanOutErr rest_error.RestErr) {
+	log.Printf("failed to fan out post %d: %s", postEntity.ID, fanOutErr)
+
+	pendingFanOut := modelPendingFanOut.PendingFanOut{
+		PostID: postEntity.ID,
+		Date:   time_utils.Now(),
+	}
+	if err := s.pendingFanOutsRepository.Save(&pendingFanOut); err != nil {
+		log.Printf("failed to save pending fan-out of post %d: %s", postEntity.ID, err)
+	}
+}
+
+// RetryFanOuts fans out posts whose fan-out failed, posts that fail again stay pending
+func (s *postsService) RetryFanOuts() rest_error.RestErr {
+	pendingFanOuts, err := s.pendingFanOutsRepository.GetAll()
+	if err != nil {
+		return err
+	}
+
+	var retryErr rest_error.RestErr
+	for i := range pendingFanOuts {
+		postEntity, err := s.postsRepository.Get(pendingFanOuts[i].PostID)
+		if err == nil {
+			err = s.fanOutPost(postEntity)
+		}
+		// Posts deleted in the meantime are not fanned out anymore
+		if err != nil && err.Status() != http.StatusNotFound {
+			retryErr = err
+			continue
+		}
+
+		if err := s.pendingFanOutsRepository.Delete(&pendingFanOuts[i]); err != nil {
+			retryErr = err
+		}
+	}
+
+	return retryErr
+}
+
+func (s *postsService) BackfillFeed(user string, followedUser string) rest_error.RestErr {
+	posts, err := s.postsRepository.GetUsersPosts(followedUser)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Date > posts[j].Date
+	})
+	if len(posts) > feedBackfillSize {
+		posts = posts[:feedBackfillSize]
+	}
+
+	items := make([]modelFeedItem.FeedItem, 0, len(posts))
+	for _, postEntity := range posts {
+		items = append(items, modelFeedItem.FeedItem{
+			UserEmail:   user,
+			PostID:      postEntity.ID,
+			AuthorEmail: postEntity.UserEmail,
+			Date:        postEntity.Date,
+		})
+	}
+
+	return s.feedItemsRepository.CreateMany(items)
+}
+
+func (s *postsService) GetUsersPosts(userEmail string, loggedInUserEmail string) ([]dtos.PostDTO, rest_error.RestErr) {
+	var posts []modelPost.Post
+	var postErr rest_error.RestErr
+
+	audiences := newAudienceCache()
+	if postErr = s.checkProfileAccess(userEmail, loggedInUserEmail, audiences); postErr != nil {
+		return nil, postErr
+	}
+
+	// Get all users posts which are not archived
+	if posts, postErr = s.postsRepository.GetProfilePosts(userEmail, false); postErr != nil {
+		return nil, postErr
+	}
+
+	postsDTOs, postErr := s.getPostsDTOs(posts, loggedInUserEmail, dtos.SensitiveContentBlur, audiences)
+	if postErr != nil {
+		return nil, postErr
+	}
+
+	sortProfilePosts(postsDTOs)
+	return postsDTOs, nil
+}
+
+// sortProfilePosts orders posts on a profile, pinned posts come first and each group is sorted newest first
+func sortProfilePosts(postsDTOs []dtos.PostDTO) {
+	sort.SliceStable(postsDTOs, func(i, j int) bool {
+		if postsDTOs[i].Pinned != postsDTOs[j].Pinned {
+			return postsDTOs[i].Pinned
+		}
+		return postsDTOs[i].Timestamp > postsDTOs[j].Timestamp
+	})
+}
+
+func (s *postsService) getOwnPostSetting(postID uint, userEmail string, message string) (*modelPostSetting.PostSetting, rest_error.RestErr) {
+	postEntity, err := s.postsRepository.Get(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if postEntity.UserEmail != userEmail {
+		return nil, rest_error.NewRestError(message, http.StatusForbidden, "forbidden", nil)
+	}
+
+	return s.getPostSetting(postEntity.ID)
+}
+
+func (s *postsService) PinPost(postID uint, userEmail string) rest_error.RestErr {
+	setting, err := s.getOwnPostSetting(postID, userEmail, "Only author can pin a post")
+	if err != nil {
+		return err
+	}
+
+	if setting.IsPinned() {
+		return nil
+	}
+
+	if setting.Archived {
+		return rest_error.NewBadRequestError("Archived post can not be pinned")
+	}
+
+	setting.PinnedAt = time_utils.Now()
+	return s.settingsRepository.Pin(setting, userEmail, maxPinnedPosts)
+}
+
+func (s *postsService) UnpinPost(postID uint, userEmail string) rest_error.RestErr {
+	setting, err := s.getOwnPostSetting(postID, userEmail, "Only author can unpin a post")
+	if err != nil {
+		return err
+	}
+
+	if !setting.IsPinned() {
+		return nil
+	}
+
+	setting.PinnedAt = 0
+	return s.settingsRepository.Update(setting, "pinned_at")
+}
+
+// GetArchivedPosts returns user's archived posts, which only their author can see
+func (s *postsService) GetArchivedPosts(userEmail string) ([]dtos.PostDTO, rest_error.RestErr) {
+	posts, err := s.postsRepository.GetProfilePosts(userEmail, true)
+	if err != nil {
+		return nil, err
+	}
+
+	postsDTOs, err := s.getPostsDTOs(posts, userEmail, dtos.SensitiveContentBlur, newAudienceCache())
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(postsDTOs, func(i, j int) bool {
+		return postsDTOs[i].Timestamp > postsDTOs[j].Timestamp
+	})
+
+	return postsDTOs, nil
+}
+
+// setArchived archives or restores author's post, archived posts are no longer pinned
+func (s *postsService) setArchived(postID uint, userEmail string, archived bool) rest_error.RestErr {
+	setting, err := s.getOwnPostSetting(postID, userEmail, "Only author can archive a post")
+	if err != nil {
+		return err
+	}
+
+	if setting.Archived == archived {
+		return nil
+	}
+	setting.Archived = archived
+	setting.PinnedAt = 0
+
+	return s.settingsRepository.Update(setting, "archived", "pinned_at")
+}
+
+func (s *postsService) ArchivePost(postID uint, userEmail string) rest_error.RestErr {
+	return s.setArchived(postID, userEmail, true)
+}
+
+func (s *postsService) UnarchivePost(postID uint, userEmail string) rest_error.RestErr {
+	return s.setArchived(postID, userEmail, false)
+}
+
+// GetPostsDTOs leaves out posts the viewer can not see, those of blocked, private or shadow banned authors
+// and outside of their audience. Archived posts are left out by the queries loading the posts.
+func (s *postsService) GetPostsDTOs(posts []modelPost.Post, loggedInUserEmail string, sensitiveContent dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr) {
+	return s.getPostsDTOs(posts, loggedInUserEmail, sensitiveContent, newAudienceCache())
+}
+
+func (s *postsService) getPostsDTOs(posts []modelPost.Post, loggedInUserEmail string, sensitiveContent dtos.SensitiveContentPreference, audiences *audienceCache) ([]dtos.PostDTO, rest_error.RestErr) {
+	var postsDTOs []dtos.PostDTO
+	var postErr rest_error.RestErr
+
+	shadowBanned := make(map[string]bool)
+
+	var mediaIDs map[uint][]uint
+	if mediaIDs, postErr = s.getPostsMediaIDs(posts); postErr != nil {
+		return nil, postErr
+	}
+
+	layout := "02.01.2006. 03:04"
+	for _, postEntity := range posts {
+		// Shadow banned authors are the only ones who see their posts
+		if postEntity.UserEmail != loggedInUserEmail && s.isShadowBanned(postEntity.UserEmail, shadowBanned) {
+			continue
+		}
+
+		if postErr = s.checkProfileAccess(postEntity.UserEmail, loggedInUserEmail, audiences); postErr != nil {
+			if postErr.Status() == http.StatusForbidden {
+				continue
+			}
+			return nil, postErr
+		}
+
+		var setting *modelPostSetting.PostSetting
+		if setting, postErr = s.getPostSetting(postEntity.ID); postErr != nil {
+			return nil, postErr
+		}
+
+		var visible bool
+		if visible, postErr = s.isInAudience(postEntity.UserEmail, setting, loggedInUserEmail, audiences); postErr != nil {
+			return nil, postErr
+		}
+		if !visible {
+			continue
+		}
+
+		// Authors always see their own posts unblurred
+		blurred := setting.ContentWarning != "" && postEntity.UserEmail != loggedInUserEmail
+		if blurred && sensitiveContent == dtos.SensitiveContentExclude {
+			continue
+		}
+
+		description := s.ProcessTags(postEntity.Description)
+		// Convert time to format dd.MM.yyyy. HH:mm
+		t := time.Unix(postEntity.Date, 0)
+		date := t.Format(layout)
+
+		// GRPC call media service to get post's images
+		var image string
+		media := make([]string, 0)
+		var err error
+		if !blurred {
+			if media, postErr = s.getImages(mediaIDs[postEntity.ID]); postErr != nil {
+				return nil, postErr
+			}
+			image = media[0]
+		}
+
+		// GRPC CALL TO USER SERVICE FOR USERNAME
+		var username string
+		if username, err = s.userGrpcClient.GetUsername(dtos.GetUsernameRequest{Email: postEntity.UserEmail}); err != nil {
+			return nil, rest_error.NewInternalServerError("user grpc client error when getting username", err)
+		}
+
+		// Check if logged user liked, disliked or added post to favorites
+		liked := false
+		disliked := false
+		inFavorites := false
+		if loggedInUserEmail != "" {
+			if _, postErr = s.likesRepository.GetByUserAndPost(loggedInUserEmail, postEntity.ID); postErr == nil {
+				liked = true
+			}
+
+			if _, postErr = s.dislikesRepository.GetByUserAndPost(loggedInUserEmail, postEntity.ID); postErr == nil {
+				disliked = true
+			}
+
+			// GRPC CALL TO USER SERVICE TO CHECK IF POST IS IN USER'S FAVORITES
+			checkFavoritesRequest := dtos.CheckFavoritesRequest{
+				Email:  loggedInUserEmail,
+				PostID: postEntity.ID,
+			}
+			if inFavorites, err = s.userGrpcClient.CheckPostIsInFavorites(checkFavoritesRequest); err != nil {
+				return nil, rest_error.NewInternalServerError("user grpc client error when checking favorites", err)
+			}
+		}
+
+		var views *int64
+		if loggedInUserEmail != "" && postEntity.UserEmail == loggedInUserEmail {
+			var numberOfViews int64
+			if numberOfViews, postErr = s.impressionsRepository.CountByPost(postEntity.ID); postErr != nil {
+				return nil, postErr
+			}
+			views = &numberOfViews
+		}
+
+		// Calculate number of post's likes and dislikes
+		var numberOfLikes int64
+		if numberOfLikes, postErr = s.likesRepository.GetNumberOfLikes(postEntity.ID); postErr != nil {
+			return nil, postErr
+		}
+
+		var numberOfDislikes int64
+		if numberOfDislikes, postErr = s.dislikesRepository.GetNumberOfDislikes(postEntity.ID); postErr != nil {
+			return nil, postErr
+		}
+
+		// Get posts's comments
+		var commentsDTOs = make([]dtos.CommentDTO, 0)
+		var comments []modelComment.Comment
+		if comments, postErr = s.commentsRepository.GetComments(postEntity.ID); postErr != nil {
+			return nil, postErr
+		}
+		var reviews []modelCommentReview.CommentReview
+		if reviews, postErr = s.commentReviewsRepository.GetByPost(postEntity.ID); postErr != nil {
+			return nil, postErr
+		}
+		quarantined := make(map[uint]bool)
+		for _, review := range reviews {
+			quarantined[review.CommentID] = true
+		}
+		var commUsername string
+		for _, commentEntity := range comments {
+			if commentEntity.UserEmail != loggedInUserEmail &&
+				(quarantined[commentEntity.ID] || s.isShadowBanned(commentEntity.UserEmail, shadowBanned)) {
+				continue
+			}
+			var hidden bool
+			if hidden, postErr = s.isHidden(commentEntity.UserEmail, loggedInUserEmail, audiences); postErr != nil {
+				return nil, postErr
+			}
+			if hidden {
+				continue
+			}
+			if commUsername, err = s.userGrpcClient.GetUsername(dtos.GetUsernameRequest{Email: commentEntity.UserEmail}); err != nil {
+				return nil, rest_error.NewInternalServerError("user grpc client error when getting username", err)
+			}
+			commentsDTOs = append(commentsDTOs, dtos.CommentDTO{
+				Text:     s.ProcessTags(commentEntity.Text),
+				Date:     time.Unix(commentEntity.Date, 0).Format(layout),
+				Username: commUsername,
+			})
+		}
+
+		// CREATE POST DTO
+		postsDTOs = append(postsDTOs, dtos.PostDTO{
+			ID:             postEntity.ID,
+			Description:    description,
+			Date:           date,
+			Timestamp:      postEntity.Date,
+			Image:          image,
+			Media:          media,
+			MediaType:      setting.GetMediaType(),
+			Username:       username,
+			Liked:          liked,
+			Disliked:       disliked,
+			InFavorites:    inFavorites,
+			Likes:          uint(numberOfLikes),
+			Dislikes:       uint(numberOfDislikes),
+			Comments:       commentsDTOs,
+			ContentWarning: setting.ContentWarning,
+			Blurred:        blurred,
+			Audience:       setting.GetAudience(),
+			CommentPolicy:  setting.GetCommentPolicy(),
+			Archived:       setting.Archived,
+			Pinned:         setting.IsPinned(),
+			Views:          views,
+		})
+	}
+
+	return postsDTOs, nil
+}
+
+func (s *postsService) ProcessTags(text string) string {
+	r := regexp.MustCompile(`@[A-Za-z0-9_.]+`)
+	matches := r.FindAllString(text, -1)
+
+	var link string
+	var taggable bool
+	checkTaggableRequest := dtos.CheckTaggableRequest{
+		Username: "",
+	}
+
+	for _, tag := range matches {
+		checkTaggableRequest.Username = tag[1:]
+		if taggable, _ = s.userGrpcClient.CheckIfUserIsTaggable(checkTaggableRequest); !taggable {
+			continue
+		}
+
+		link = "<a href='/users/" + tag[1:] + "' >" + tag + "</a>"
+		text = strings.ReplaceAll(text, tag, link)
+	}
+
+	return text
+}
+
+func (s *postsService) getInappropriateContentReport(postEntity *modelPost.Post) dtos.InappropriateContentReportDTO {
+	getMediaRequest := dtos.GetMediaRequest{
+		ID: uint64(postEntity.MediaID),
+	}
+	media, _ := s.mediaGrpcClient.GetMedia(getMediaRequest)
+
+	reports, _ := s.reportsRepository.GetByPost(postEntity.ID)
+	reasons := make([]string, 0)
+	for _, reportEntity := range reports {
+		if reportEntity.Reason != "" {
+			reasons = append(reasons, reportEntity.Reason)
+		}
+	}
+
+	return dtos.InappropriateContentReportDTO{
+		Description: postEntity.Description,
+		AuthorEmail: postEntity.UserEmail,
+		Image:       media,
+		PostID:      postEntity.ID,
+		Date:        postEntity.Date,
+		Reports:     int64(len(reports)),
+		Reasons:     reasons,
+	}
+}
+
+func (s *postsService) GetInappropriateContent() []dtos.InappropriateContentReportDTO {
+	markedAsInappropriate := s.postsRepository.GetInappropriateContent()
+
+	if len(markedAsInappropriate) == 0 {
+		return []dtos.InappropriateContentReportDTO{}
+	}
+
+	var collection []dtos.InappropriateContentReportDTO
+	for i := 0; i < len(markedAsInappropriate); i++ {
+		collection = append(collection, s.getInappropriateContentReport(&markedAsInappropriate[i]))
+	}
+
+	return collection
+}
+
+func (s *postsService) GetModerationQueue(filter dtos.ModerationQueueFilter) (*dtos.ModerationQueueDTO, rest_error.RestErr) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = defaultModerationPageSize
+	}
+	if filter.Size > maxModerationPageSize {
+		filter.Size = maxModerationPageSize
+	}
+
+	now := time_utils.Now()
+	query := post.ModerationQueueQuery{
+		Author:     filter.Author,
+		Reason:     filter.Reason,
+		MinReports: filter.MinReports,
+		Offset:     (filter.Page - 1) * filter.Size,
+		Limit:      filter.Size,
+	}
+	if filter.MinAge > 0 {
+		query.PublishedBefore = now - filter.MinAge*3600
+	}
+	if filter.MaxAge > 0 {
+		query.PublishedAfter = now - filter.MaxAge*3600
+	}
+
+	posts, total, err := s.postsRepository.GetModerationQueue(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dtos.InappropriateContentReportDTO, 0)
+	for i := range posts {
+		items = append(items, s.getInappropriateContentReport(&posts[i]))
+	}
+
+	return &dtos.ModerationQueueDTO{
+		Items: items,
+		Page:  filter.Page,
+		Size:  filter.Size,
+		Total: total,
+	}, nil
+}
+
+func (s *postsService) DecideOnContent(id uint, delete bool) rest_error.RestErr {
+	postEntity, err := s.postsRepository.Get(id)
+	if err != nil {
+		return err
+	}
+
+	if !delete {
+		return s.postsRepository.ApplyModerationDecisions([]modelPost.Post{*postEntity}, nil, nil)
+	}
+
+	bannedMedia, mediaIDs, err := s.getBannedMedia(postEntity)
+	if err != nil {
+		return err
+	}
+
+	if err := s.postsRepository.ApplyModerationDecisions(nil, []modelPost.Post{*postEntity}, bannedMedia); err != nil {
+		return err
+	}
+
+	// Media is deleted only once the post is gone, so a failed decision does not leave the post without it
+	s.discardMedia(mediaIDs)
+	return nil
+}
+
+func (s *postsService) DecideOnContentBulk(decisions []dtos.ModerationDecisionDTO) ([]dtos.ModerationDecisionResultDTO, rest_error.RestErr) {
+	if len(decisions) > maxModerationDecisions {
+		return nil, rest_error.NewBadRequestError(fmt.Sprintf("At most %d decisions can be made at once", maxModerationDecisions))
+	}
+
+	results := make([]dtos.ModerationDecisionResultDTO, len(decisions))
+	decided := make(map[uint]bool)
+
+	var kept []modelPost.Post
+	var deleted []modelPost.Post
+	var bannedMedia []modelBannedMedia.BannedMedia
+	var deletedMedia []uint
+
+	for i, decision := range decisions {
+		results[i].PostID = decision.PostID
+
+		if decided[decision.PostID] {
+			results[i].Error = "Duplicate decision for post"
+			continue
+		}
+
+		postEntity, err := s.postsRepository.Get(decision.PostID)
+		if err != nil {
+			results[i].Error = err.Message()
+			continue
+		}
+
+		if !decision.Delete {
+			kept = append(kept, *postEntity)
+		} else {
+			postBannedMedia, mediaIDs, err := s.getBannedMedia(postEntity)
+			if err != nil {
+				results[i].Error = err.Message()
+				continue
+			}
+			bannedMedia = append(bannedMedia, postBannedMedia...)
+			deletedMedia = append(deletedMedia, mediaIDs...)
+			deleted = append(deleted, *postEntity)
+		}
+
+		decided[decision.PostID] = true
+		results[i].Success = true
+	}
+
+	if err := s.postsRepository.ApplyModerationDecisions(kept, deleted, bannedMedia); err != nil {
+		return nil, err
+	}
+
+	s.discardMedia(deletedMedia)
+	return results, nil
+}
+
+func (s *postsService) getAffinity(user string, author string) (int64, rest_error.RestErr) {
+	likes, err := s.likesRepository.CountByUserOnAuthor(user, author)
+	if err != nil {
+		return 0, err
+	}
+
+	comments, err := s.commentsRepository.CountByUserOnAuthor(user, author)
+	if err != nil {
+		return 0, err
+	}
+
+	return likes + comments, nil
+}
+
+// getFeedPosts reads posts from user's materialized feed and merges in posts of followed large accounts,
+// which are not fanned out. Gaps in the feed are filled by fan out retries and backfills on follow.
+func (s *postsService) getFeedPosts(user string, followedUsers []string) ([]modelPost.Post, rest_error.RestErr) {
+	items, err := s.feedItemsRepository.GetByUser(user, feedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	followed := make(map[string]bool)
+	for _, u := range followedUsers {
+		followed[u] = true
+	}
+
+	var ids []uint
+	for _, item := range items {
+		// Items of unfollowed authors stay in the table but are no longer shown
+		if followed[item.AuthorEmail] {
+			ids = append(ids, item.PostID)
+		}
+	}
+
+	followedPosts, err := s.postsRepository.GetUnarchivedByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	largeAccounts, err := s.largeAccountsRepository.GetByUsers(followedUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	pulledUsers := make([]string, 0, len(largeAccounts))
+	for _, largeAccount := range largeAccounts {
+		pulledUsers = append(pulledUsers, largeAccount.UserEmail)
+	}
+
+	included := make(map[uint]bool)
+	for _, postEntity := range followedPosts {
+		included[postEntity.ID] = true
+	}
+
+	for _, u := range pulledUsers {
+		posts, err := s.postsRepository.GetProfilePosts(u, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, postEntity := range posts {
+			if !included[postEntity.ID] {
+				included[postEntity.ID] = true
+				followedPosts = append(followedPosts, postEntity)
+			}
+		}
+	}
+
+	return followedPosts, nil
+}
+
+func (s *postsService) GetPostsFeed(user string, sensitiveContent dtos.SensitiveContentPreference, mode dtos.FeedMode) ([]dtos.PostDTO, rest_error.RestErr) {
+	getFollowingUsersRequest := dtos.GetFollowingUsersRequest{
+		UserEmail: user,
+	}
+	var followedUsers []string
+	var err error
+
+	if followedUsers, err = s.userGrpcClient.GetFollowingUsers(getFollowingUsersRequest); err != nil {
+		return nil, rest_error.NewInternalServerError("user grpc client error when getting following users", err)
+	}
+
+	var followedPosts []modelPost.Post
+	var restErr rest_error.RestErr
+
+	if followedPosts, restErr = s.getFeedPosts(user, followedUsers); restErr != nil {
+		return nil, restErr
+	}
+
+	// Muted users stay followed but their posts are left out of the feed
+	var muted map[string]bool
+	if muted, restErr = s.getMutedUsers(user); restErr != nil {
+		return nil, restErr
+	}
+
+	followed := make(map[string]bool, len(followedUsers))
+	for _, followedUser := range followedUsers {
+		followed[followedUser] = true
+	}
+	audiences := newAudienceCache()
+	audiences.following = followed
+	audiences.muted = muted
+
+	authors := make(map[uint]string)
+	unmutedPosts := make([]modelPost.Post, 0, len(followedPosts))
+	for _, postEntity := range followedPosts {
+		if muted[postEntity.UserEmail] {
+			continue
+		}
+		authors[postEntity.ID] = postEntity.UserEmail
+		unmutedPosts = append(unmutedPosts, postEntity)
+	}
+	followedPosts = unmutedPosts
+
+	var feedPosts []dtos.PostDTO
+	if feedPosts, restErr = s.getPostsDTOs(followedPosts, user, sensitiveContent, audiences); restErr != nil {
+		return nil, restErr
+	}
+
+	if mode == dtos.FeedModeChronological {
+		items := make([]feed_ranker.FeedItem, 0, len(feedPosts))
+		for _, postDTO := range feedPosts {
+			items = append(items, feed_ranker.FeedItem{Post: postDTO})
+		}
+		return s.injectSponsoredPosts(user, audiences, feed_ranker.NewChronologicalRanker().Rank(items, time_utils.Now()), sensitiveContent)
+	}
+
+	affinities := make(map[string]int64)
+	items := make([]feed_ranker.FeedItem, 0, len(feedPosts))
+	for _, postDTO := range feedPosts {
+		author := authors[postDTO.ID]
+		affinity, ok := affinities[author]
+		if !ok {
+			if affinity, restErr = s.getAffinity(user, author); restErr != nil {
+				return nil, restErr
+			}
+			affinities[author] = affinity
+		}
+		items = append(items, feed_ranker.FeedItem{
+			Post:     postDTO,
+			Affinity: affinity,
+		})
+	}
+
+	return s.injectSponsoredPosts(user, audiences, s.feedRanker.Rank(items, time_utils.Now()), sensitiveContent)
+}
+
+func (s *postsService) CreateCampaign(campaignDTO *dtos.CreateCampaignDTO) rest_error.RestErr {
+	if campaignDTO.EndDate <= campaignDTO.StartDate || campaignDTO.EndDate <= time_utils.Now() {
+		return rest_error.NewBadRequestError("Campaign must end after it starts and in the future")
+	}
+
+	if campaignDTO.Audience == "" {
+		campaignDTO.Audience = modelCampaign.AudienceAll
+	}
+	if !modelCampaign.IsValidAudience(campaignDTO.Audience) {
+		return rest_error.NewBadRequestError("Invalid campaign audience")
+	}
+
+	if campaignDTO.FrequencyCap < 1 || campaignDTO.FrequencyCap > maxFrequencyCap {
+		return rest_error.NewBadRequestError(fmt.Sprintf("Frequency cap must be between 1 and %d", maxFrequencyCap))
+	}
+
+	// Campaign start date decides when a sponsored post is shown
+	if campaignDTO.Post.PublishAt != 0 {
+		return rest_error.NewBadRequestError("Sponsored post can not be scheduled, campaign start date is used instead")
+	}
+
+	// Campaign audience decides who sees a sponsored post, so the post itself stays public
+	if modelPostSetting.NormalizeAudience(campaignDTO.Post.Audience) != modelPostSetting.AudiencePublic {
+		return rest_error.NewBadRequestError("Sponsored post must be public")
+	}
+
+	interests := make([]string, 0, len(campaignDTO.Interests))
+	for _, interest := range campaignDTO.Interests {
+		interest = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(interest), "#"))
+		if interest == "" || strings.ContainsAny(interest, ", ") {
+			return rest_error.NewBadRequestError("Invalid campaign interest")
+		}
+		interests = append(interests, interest)
+	}
+
+	campaignEntity := modelCampaign.Campaign{
+		StartDate:    campaignDTO.StartDate,
+		EndDate:      campaignDTO.EndDate,
+		Audience:     campaignDTO.Audience,
+		Interests:    strings.Join(interests, ","),
+		FrequencyCap: campaignDTO.FrequencyCap,
+	}
+
+	_, err := s.createPost(&campaignDTO.Post, &campaignEntity)
+	return err
+}
+
+func (s *postsService) GetCampaigns(agentEmail string) ([]dtos.CampaignDTO, rest_error.RestErr) {
+	campaigns, err := s.campaignsRepository.GetByAgent(agentEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	campaignIDs := make([]uint, 0, len(campaigns))
+	postIDs := make([]uint, 0, len(campaigns))
+	for _, campaignEntity := range campaigns {
+		campaignIDs = append(campaignIDs, campaignEntity.ID)
+		postIDs = append(postIDs, campaignEntity.PostID)
+	}
+
+	stats, err := s.campaignsRepository.GetDeliveryStats(campaignIDs)
+	if err != nil {
+		return nil, err
+	}
+	likes, err := s.likesRepository.CountByPosts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.commentsRepository.CountByPosts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	impressions, err := s.impressionsRepository.CountByPosts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time_utils.Now()
+	campaignDTOs := make([]dtos.CampaignDTO, 0, len(campaigns))
+	for _, campaignEntity := range campaigns {
+		campaignDTOs = append(campaignDTOs, dtos.CampaignDTO{
+			ID:           campaignEntity.ID,
+			PostID:       campaignEntity.PostID,
+			StartDate:    campaignEntity.StartDate,
+			EndDate:      campaignEntity.EndDate,
+			Audience:     campaignEntity.Audience,
+			Interests:    campaignEntity.GetInterests(),
+			FrequencyCap: campaignEntity.FrequencyCap,
+			Active:       campaignEntity.StartDate <= now && now < campaignEntity.EndDate,
+			Deliveries:   stats[campaignEntity.ID].Deliveries,
+			Reach:        stats[campaignEntity.ID].Reach,
+			Likes:        likes[campaignEntity.PostID],
+			Comments:     comments[campaignEntity.PostID],
+			Impressions:  impressions[campaignEntity.PostID],
+		})
+	}
+
+	return campaignDTOs, nil
+}
+
+func (s *postsService) matchesCampaignAudience(user string, followed map[string]bool, campaignEntity *modelCampaign.Campaign) (bool, rest_error.RestErr) {
+	switch campaignEntity.Audience {
+	case modelCampaign.AudienceFollowers:
+		if !followed[campaignEntity.AgentEmail] {
+			return false, nil
+		}
+	case modelCampaign.AudienceNonFollowers:
+		if followed[campaignEntity.AgentEmail] {
+			return false, nil
+		}
+	}
+
+	interests := campaignEntity.GetInterests()
+	if len(interests) == 0 {
+		return true, nil
+	}
+
+	return s.likesRepository.HasLikedHashtags(user, interests)
+}
+
+// injectSponsoredPosts places posts of active campaigns matching the viewer into the ranked feed and records their delivery,
+// audiences are the ones the feed was built with
+func (s *postsService) injectSponsoredPosts(user string, audiences *audienceCache, feedPosts []dtos.PostDTO, sensitiveContent dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr) {
+	now := time_utils.Now()
+	campaigns, err := s.campaignsRepository.GetActive(now)
+	if err != nil {
+		return nil, err
+	}
+	if len(campaigns) == 0 {
+		return feedPosts, nil
+	}
+
+	followed := audiences.following
+	muted := audiences.muted
+	inFeed := make(map[uint]bool)
+	for _, postDTO := range feedPosts {
+		inFeed[postDTO.ID] = true
+	}
+
+	day := now - now%secondsInDay
+	selected := make(map[uint]modelCampaign.Campaign)
+	postIDs := make([]uint, 0, maxSponsoredPerFeed)
+	for _, campaignEntity := range campaigns {
+		if len(postIDs) == maxSponsoredPerFeed {
+			break
+		}
+		if campaignEntity.AgentEmail == user || muted[campaignEntity.AgentEmail] || inFeed[campaignEntity.PostID] {
+			continue
+		}
+
+		matches, err := s.matchesCampaignAudience(user, followed, &campaignEntity)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+
+		delivered, err := s.campaignsRepository.CountDeliveries(campaignEntity.ID, user, day)
+		if err != nil {
+			return nil, err
+		}
+		if delivered >= int64(campaignEntity.FrequencyCap) {
+			continue
+		}
+
+		// Sponsoring does not get a post past a block or a private profile
+		if err := s.checkProfileAccess(campaignEntity.AgentEmail, user, audiences); err != nil {
+			if err.Status() == http.StatusForbidden {
+				continue
+			}
+			return nil, err
+		}
+
+		selected[campaignEntity.PostID] = campaignEntity
+		postIDs = append(postIDs, campaignEntity.PostID)
+	}
+	if len(postIDs) == 0 {
+		return feedPosts, nil
+	}
+
+	posts, err := s.postsRepository.GetUnarchivedByIDs(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	postsByID := make(map[uint]modelPost.Post)
+	for _, postEntity := range posts {
+		postsByID[postEntity.ID] = postEntity
+	}
+	sponsoredPosts := make([]modelPost.Post, 0, len(postIDs))
+	for _, postID := range postIDs {
+		if postEntity, ok := postsByID[postID]; ok && !postEntity.MarkedAsInappropriate {
+			sponsoredPosts = append(sponsoredPosts, postEntity)
+		}
+	}
+
+	sponsored, err := s.getPostsDTOs(sponsoredPosts, user, sensitiveContent, audiences)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sponsored {
+		sponsored[i].Sponsored = true
+		if err := s.campaignsRepository.RecordDelivery(selected[sponsored[i].ID].ID, user, day); err != nil {
+			return nil, err
+		}
+	}
+
+	return insertSponsoredPosts(feedPosts, sponsored), nil
+}
+
+// insertSponsoredPosts puts a sponsored post at every sponsoredInterval-th position, leftovers are appended to short feeds
+func insertSponsoredPosts(feedPosts []dtos.PostDTO, sponsored []dtos.PostDTO) []dtos.PostDTO {
+	result := make([]dtos.PostDTO, 0, len(feedPosts)+len(sponsored))
+	for _, postDTO := range feedPosts {
+		if len(sponsored) > 0 && len(result)%sponsoredInterval == sponsoredInterval-1 {
+			result = append(result, sponsored[0])
+			sponsored = sponsored[1:]
+		}
+		result = append(result, postDTO)
+	}
+	return append(result, sponsored...)
+}
+
+// RefreshExplore ranks recently published posts by engagement velocity and caches them for explore page
+func (s *postsService) RefreshExplore() rest_error.RestErr {
+	now := time_utils.Now()
+
+	posts, err := s.postsRepository.GetPublishedSince(now - explore.Window)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint, 0, len(posts))
+	for _, postEntity := range posts {
+		ids = append(ids, postEntity.ID)
+	}
+
+	likes, err := s.likesRepository.CountByPosts(ids)
+	if err != nil {
+		return err
+	}
+
+	comments, err := s.commentsRepository.CountByPosts(ids)
+	if err != nil {
+		return err
+	}
+
+	checked := make(map[string]bool)
+	candidates := make([]explore.Candidate, 0, len(posts))
+	for _, postEntity := range posts {
+		if s.isShadowBanned(postEntity.UserEmail, checked) {
+			continue
+		}
+		candidates = append(candidates, explore.Candidate{
+			Post:     postEntity,
+			Likes:    likes[postEntity.ID],
+			Comments: comments[postEntity.ID],
+		})
+	}
+
+	s.exploreCache.Set(explore.Rank(candidates, now))
+	return nil
+}
+
+func (s *postsService) GetExplore(user string, sensitiveContent dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr) {
+	getFollowingUsersRequest := dtos.GetFollowingUsersRequest{
+		UserEmail: user,
+	}
+	followedUsers, err := s.userGrpcClient.GetFollowingUsers(getFollowingUsersRequest)
+	if err != nil {
+		return nil, rest_error.NewInternalServerError("user grpc client error when getting following users", err)
+	}
+
+	excluded := map[string]bool{user: true}
+	for _, u := range followedUsers {
+		excluded[u] = true
+	}
+
+	var posts []modelPost.Post
+	blocked := make(map[string]bool)
+	for _, postEntity := range s.exploreCache.Get() {
+		if excluded[postEntity.UserEmail] {
+			continue
+		}
+
+		isBlocked, ok := blocked[postEntity.UserEmail]
+		if !ok {
+			var blockErr rest_error.RestErr
+			// Authors whose block status is unknown are left out rather than risk showing a blocked one
+			if isBlocked, blockErr = s.isBlocked(user, postEntity.UserEmail); blockErr != nil {
+				log.Printf("failed to check if %s is blocked for explore: %s", postEntity.UserEmail, blockErr)
+				isBlocked = true
+			}
+			blocked[postEntity.UserEmail] = isBlocked
+		}
+		if isBlocked {
+			continue
+		}
+
+		posts = append(posts, postEntity)
+		if len(posts) == explorePageSize {
+			break
+		}
+	}
+
+	// Posts archived since explore was refreshed are left out
+	ids := make([]uint, 0, len(posts))
+	for _, postEntity := range posts {
+		ids = append(ids, postEntity.ID)
+	}
+	unarchived, restErr := s.postsRepository.GetUnarchivedByIDs(ids)
+	if restErr != nil {
+		return nil, restErr
+	}
+	kept := make(map[uint]bool, len(unarchived))
+	for _, postEntity := range unarchived {
+		kept[postEntity.ID] = true
+	}
+	page := make([]modelPost.Post, 0, len(posts))
+	for _, postEntity := range posts {
+		if kept[postEntity.ID] {
+			page = append(page, postEntity)
+		}
+	}
+
+	return s.GetPostsDTOs(page, user, sensitiveContent)
+}
+
+// savePostTerms stores hashtags and mentions of a stored post or comment for trending,
+// the text is already stored so failure is only logged
+func (s *postsService) savePostTerms(content string, postID uint, commentID uint, userEmail string, date int64) {
+	hashtags, mentions := trending.Extract(content)
+	terms := modelPostTerm.NewPostTerms(hashtags, mentions, postID, commentID, userEmail, date)
+	if len(terms) == 0 {
+		return
+	}
+	if err := s.postTermsRepository.CreateMany(terms); err != nil {
+		log.Printf("failed to save terms of post %d: %s", postID, err)
+	}
+}
+
+// getTrendingExcludedAuthors returns authors whose posts are not public, ones with private profiles and shadow banned ones.
+// Authors whose profile can not be checked are left out too.
+func (s *postsService) getTrendingExcludedAuthors(since int64, shadowBanned []string) ([]string, rest_error.RestErr) {
+	authors, err := s.postTermsRepository.GetPostAuthorsSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := append([]string{}, shadowBanned...)
+	for _, author := range authors {
+		private, err := s.userGrpcClient.CheckIfProfileIsPrivate(dtos.CheckIfProfileIsPrivateRequest{UserEmail: author})
+		if err != nil {
+			log.Printf("failed to check if profile of %s is private for trending: %s", author, err)
+		}
+		if err != nil || private {
+			excluded = append(excluded, author)
+		}
+	}
+
+	return excluded, nil
+}
+
+// RefreshTrending counts hashtags and mentions of public posts and their comments for every trending window
+func (s *postsService) RefreshTrending() rest_error.RestErr {
+	now := time_utils.Now()
+	since := now - dtos.TrendingWindowWeek.Seconds()*(trending.BaselinePeriods+1)
+
+	var shadowBanned []string
+	for _, restriction := range s.restrictionsRepository.GetAll() {
+		if restriction.ShadowBanned {
+			shadowBanned = append(shadowBanned, restriction.UserEmail)
+		}
+	}
+
+	excludedAuthors, err := s.getTrendingExcludedAuthors(since, shadowBanned)
+	if err != nil {
+		return err
+	}
+
+	aggregates := make(map[dtos.TrendingWindow]dtos.TrendingDTO)
+	for _, window := range dtos.TrendingWindows {
+		start := now - window.Seconds()
+		query := post_term.TermCountQuery{
+			BaselineStart:       start - trending.BaselinePeriods*window.Seconds(),
+			Start:               start,
+			End:                 now,
+			ExcludedUsers:       shadowBanned,
+			ExcludedPostAuthors: excludedAuthors,
+			Limit:               trending.TopSize,
+		}
+
+		query.Kind = modelPostTerm.KindHashtag
+		hashtags, err := s.postTermsRepository.CountTerms(query)
+		if err != nil {
+			return err
+		}
+
+		query.Kind = modelPostTerm.KindMention
+		mentions, err := s.postTermsRepository.CountTerms(query)
+		if err != nil {
+			return err
+		}
+
+		aggregates[window] = dtos.TrendingDTO{
+			Window:   window,
+			Hashtags: trending.Rank(hashtags),
+			Mentions: trending.Rank(mentions),
+			Date:     now,
+		}
+	}
+
+	s.trendingCache.Set(aggregates)
+	return nil
+}
+
+func (s *postsService) GetTrending(window dtos.TrendingWindow) *dtos.TrendingDTO {
+	aggregate, ok := s.trendingCache.Get(window)
+	if !ok {
+		// Aggregator has not run yet
+		aggregate = dtos.TrendingDTO{
+			Window:   window,
+			Hashtags: []dtos.TrendingTermDTO{},
+			Mentions: []dtos.TrendingTermDTO{},
+		}
+	}
+	return &aggregate
+}
+
+// RefreshSimilarPosts recomputes similarity table from latest likes and hashtags of recent posts
+func (s *postsService) RefreshSimilarPosts() rest_error.RestErr {
+	posts, err := s.postsRepository.GetPublishedSince(time_utils.Now() - similarity.Window)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint, 0, len(posts))
+	for _, postEntity := range posts {
+		ids = append(ids, postEntity.ID)
+	}
+
+	likes, err := s.likesRepository.GetRecentByPosts(ids, similarity.MaxLikes)
+	if err != nil {
+		return err
+	}
+
+	return s.similaritiesRepository.ReplaceAll(similarity.Compute(posts, likes))
+}
+
+func (s *postsService) GetSimilarPosts(postID uint, loggedInUserEmail string, sensitiveContent dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr) {
+	postEntity, err := s.postsRepository.Get(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	audiences := newAudienceCache()
+	if err := s.checkPostAccess(postEntity, loggedInUserEmail, audiences); err != nil {
+		return nil, err
+	}
+
+	similarities, err := s.similaritiesRepository.GetByPost(postID, similarPostsSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(similarities))
+	for _, similar := range similarities {
+		ids = append(ids, similar.SimilarPostID)
+	}
+
+	found, err := s.postsRepository.GetUnarchivedByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]modelPost.Post, len(found))
+	for _, postEntity := range found {
+		byID[postEntity.ID] = postEntity
+	}
+
+	// Keep similarity order, posts deleted or flagged since the last computation are left out
+	var posts []modelPost.Post
+	for _, id := range ids {
+		if postEntity, ok := byID[id]; ok && !postEntity.MarkedAsInappropriate {
+			posts = append(posts, postEntity)
+		}
+	}
+
+	return s.getPostsDTOs(posts, loggedInUserEmail, sensitiveContent, audiences)
+}
+
+// RecordImpressions stores a batch of posts seen by a viewer, authors viewing their own posts are not counted.
+// Posts the viewer is not allowed to see are skipped.
+func (s *postsService) RecordImpressions(impressionsRequest *dtos.ImpressionsRequestDTO) (*dtos.ImpressionsResultDTO, rest_error.RestErr) {
+	if impressionsRequest.UserEmail == "" {
+		return nil, rest_error.NewBadRequestError("User email is required")
+	}
+	if len(impressionsRequest.PostIDs) == 0 || len(impressionsRequest.PostIDs) > maxImpressionsBatchSize {
+		return nil, rest_error.NewBadRequestError(fmt.Sprintf("Batch should contain between 1 and %d posts", maxImpressionsBatchSize))
+	}
+
+	posts, err := s.postsRepository.GetUnarchivedByIDs(impressionsRequest.PostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time_utils.Now()
+	day := now - now%secondsInDay
+
+	shadowBanned := make(map[string]bool)
+	audiences := newAudienceCache()
+	impressions := make([]modelImpression.Impression, 0, len(posts))
+	for i, postEntity := range posts {
+		if postEntity.UserEmail == impressionsRequest.UserEmail || s.isShadowBanned(postEntity.UserEmail, shadowBanned) {
+			continue
+		}
+		if err := s.checkPostAccess(&posts[i], impressionsRequest.UserEmail, audiences); err != nil {
+			if err.Status() == http.StatusForbidden || err.Status() == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		impressions = append(impressions, modelImpression.Impression{
+			PostID:    postEntity.ID,
+			UserEmail: impressionsRequest.UserEmail,
+			Day:       day,
+		})
+	}
+
+	if len(impressions) == 0 {
+		return &dtos.ImpressionsResultDTO{}, nil
+	}
+
+	recorded, err := s.impressionsRepository.Record(impressions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.ImpressionsResultDTO{Recorded: recorded}, nil
+}
+
+func getInsightsFilter(filter dtos.InsightsFilter) (dtos.InsightsFilter, rest_error.RestErr) {
+	if filter.To == 0 {
+		filter.To = time_utils.Now()
+	}
+	if filter.From == 0 {
+		filter.From = filter.To - defaultInsightsPeriod
+	}
+	if filter.Bucket == "" {
+		filter.Bucket = dtos.InsightsBucketDay
+	}
+
+	if filter.From >= filter.To {
+		return filter, rest_error.NewBadRequestError("from should be before to")
+	}
+	if filter.To-filter.From > maxInsightsPeriod {
+		return filter, rest_error.NewBadRequestError("Insights period can not be longer than a year")
+	}
+
+	return filter, nil
+}
+
+func (s *postsService) getFollowersSet(userEmail string) (map[string]bool, rest_error.RestErr) {
+	followers, err := s.userGrpcClient.GetFollowers(dtos.GetFollowersRequest{UserEmail: userEmail})
+	if err != nil {
+		return nil, rest_error.NewInternalServerError("user grpc client error when getting followers", err)
+	}
+
+	followersSet := make(map[string]bool, len(followers))
+	for _, follower := range followers {
+		followersSet[follower] = true
+	}
+	return followersSet, nil
+}
+
+func (s *postsService) getInsightsEvents(postIDs []uint, from int64, to int64) ([]insights.Event, rest_error.RestErr) {
+	reactions, err := s.reactionsRepository.GetByPosts(postIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentsRepository.GetByPostsBetween(postIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	impressions, err := s.impressionsRepository.GetByPosts(postIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]insights.Event, 0, len(reactions)+len(comments)+len(impressions))
+	for _, reactionEntity := range reactions {
+		kind := insights.Like
+		if reactionEntity.Kind == modelReaction.ReactionDislike {
+			kind = insights.Dislike
+		}
+		events = append(events, insights.Event{PostID: reactionEntity.PostID, UserEmail: reactionEntity.UserEmail, Kind: kind, Date: reactionEntity.Date})
+	}
+	for _, commentEntity := range comments {
+		events = append(events, insights.Event{PostID: commentEntity.PostID, UserEmail: commentEntity.UserEmail, Kind: insights.Comment, Date: commentEntity.Date})
+	}
+	for _, impressionEntity := range impressions {
+		// Impressions are only known by day, the first day of the period may start before it
+		date := impressionEntity.Day
+		if date < from {
+			date = from
+		}
+		events = append(events, insights.Event{PostID: impressionEntity.PostID, UserEmail: impressionEntity.UserEmail, Kind: insights.Impression, Date: date})
+	}
+
+	return events, nil
+}
+
+func (s *postsService) GetPostInsights(postID uint, userEmail string, filter dtos.InsightsFilter) (*dtos.PostInsightsDTO, rest_error.RestErr) {
+	postEntity, err := s.postsRepository.Get(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if postEntity.UserEmail != userEmail {
+		return nil, rest_error.NewRestError("Only author can see post insights", http.StatusForbidden, "forbidden", nil)
+	}
+
+	if filter, err = getInsightsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	followers, err := s.getFollowersSet(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.getInsightsEvents([]uint{postID}, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	total, audience := insights.Totals(events, followers)
+
+	return &dtos.PostInsightsDTO{
+		PostID:   postID,
+		From:     filter.From,
+		To:       filter.To,
+		Bucket:   filter.Bucket,
+		Buckets:  insights.Buckets(events, filter.From, filter.To, filter.Bucket.Seconds()),
+		Total:    total,
+		Audience: audience,
+	}, nil
+}
+
+func (s *postsService) GetInsightsSummary(userEmail string, filter dtos.InsightsFilter) (*dtos.InsightsSummaryDTO, rest_error.RestErr) {
+	if userEmail == "" {
+		return nil, rest_error.NewBadRequestError("User email is required")
+	}
+
+	filter, err := getInsightsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postsRepository.GetUsersPosts(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Date > posts[j].Date
+	})
+
+	ids := make([]uint, 0, len(posts))
+	for _, postEntity := range posts {
+		ids = append(ids, postEntity.ID)
+	}
+
+	followers, err := s.getFollowersSet(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.getInsightsEvents(ids, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	total, audience := insights.Totals(events, followers)
+	counts := insights.ByPost(events)
+
+	postsSummary := make([]dtos.PostInsightsSummaryDTO, 0, len(posts))
+	for _, postEntity := range posts {
+		postsSummary = append(postsSummary, dtos.PostInsightsSummaryDTO{
+			PostID:            postEntity.ID,
+			Date:              postEntity.Date,
+			InsightsCountsDTO: counts[postEntity.ID],
+		})
+	}
+
+	return &dtos.InsightsSummaryDTO{
+		UserEmail: userEmail,
+		From:      filter.From,
+		To:        filter.To,
+		Posts:     postsSummary,
+		Total:     total,
+		Audience:  audience,
+	}, nil
 }
 
-func (s *postsService) SearchTags(tag string, user string) ([]dtos.PostDTO, rest_error.RestErr) {
+func (s *postsService) SearchTags(tag string, user string, sensitiveContent dtos.SensitiveContentPreference) ([]dtos.PostDTO, rest_error.RestErr) {
 	var posts []modelPost.Post
 	var err rest_error.RestErr
 
@@ -428,7 +3670,7 @@ func (s *postsService) SearchTags(tag string, user string) ([]dtos.PostDTO, rest
 	}
 
 	var postsDTO []dtos.PostDTO
-	if postsDTO, err = s.GetPostsDTOs(posts, user); err != nil {
+	if postsDTO, err = s.GetPostsDTOs(posts, user, sensitiveContent); err != nil {
 		return nil, err
 	}
 
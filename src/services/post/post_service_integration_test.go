@@ -6,10 +6,51 @@ import (
 	"github.com/Nistagram-Organization/nistagram-posts/src/clients/user_grpc_client"
 	"github.com/Nistagram-Organization/nistagram-posts/src/datasources/mysql"
 	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/Nistagram-Organization/nistagram-posts/src/feed_ranker"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/highlight"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/impression"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/large_account"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/pending_fan_out"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/report"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/scheduled_post"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	authorrestrictionrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/author_restriction"
+	bannedmediarepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/banned_media"
+	campaignrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/campaign"
+	closefriendrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/close_friend"
 	commentRepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment"
+	commentreviewrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment_review"
 	dislikerepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/dislike"
+	draftrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/draft"
+	feeditemrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/feed_item"
+	highlightrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/highlight"
+	impressionrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/impression"
+	largeaccountrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/large_account"
 	likerepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/like"
+	muterepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/mute"
+	pendingfanoutrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/pending_fan_out"
 	postrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post"
+	postmediarepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_media"
+	postsettingrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_setting"
+	postsimilarityrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_similarity"
+	posttermrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_term"
+	reactionrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/reaction"
+	reportrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/report"
+	scheduledpostrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/scheduled_post"
+	storyrepository "github.com/Nistagram-Organization/nistagram-posts/src/repositories/story"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/comment"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/dislike"
 	"github.com/Nistagram-Organization/nistagram-shared/src/model/like"
@@ -49,6 +90,32 @@ func (suite *PostServiceIntegrationTestsSuite) SetupSuite() {
 		&comment.Comment{},
 		&user_tag.UserTag{},
 		&post.Post{},
+		&banned_media.BannedMedia{},
+		&banned_media.BannedMediaBand{},
+		&report.Report{},
+		&author_restriction.AuthorRestriction{},
+		&post_setting.PostSetting{},
+		&comment_review.CommentReview{},
+		&feed_item.FeedItem{},
+		&large_account.LargeAccount{},
+		&post_similarity.PostSimilarity{},
+		&impression.Impression{},
+		&impression.ImpressionCount{},
+		&reaction.Reaction{},
+		&campaign.Campaign{},
+		&campaign.CampaignDelivery{},
+		&scheduled_post.ScheduledPost{},
+		&scheduled_post.ScheduledPostMedia{},
+		&draft.Draft{},
+		&post_media.PostMedia{},
+		&story.Story{},
+		&story.StoryView{},
+		&highlight.Highlight{},
+		&highlight.HighlightStory{},
+		&close_friend.CloseFriend{},
+		&mute.Mute{},
+		&pending_fan_out.PendingFanOut{},
+		&post_term.PostTerm{},
 	); err != nil {
 		panic(err)
 	}
@@ -61,7 +128,27 @@ func (suite *PostServiceIntegrationTestsSuite) SetupSuite() {
 	dislikeRepo := dislikerepository.NewDislikeRepository(database)
 	likeRepo := likerepository.NewLikeRepository(database)
 	postRepo := postrepository.NewPostRepository(database)
-	suite.service = NewPostService(postRepo, likeRepo, dislikeRepo, commentRepo, mediaGrpcClient, userGrpcClient)
+	bannedMediaRepo := bannedmediarepository.NewBannedMediaRepository(database)
+	reportRepo := reportrepository.NewReportRepository(database)
+	restrictionRepo := authorrestrictionrepository.NewAuthorRestrictionRepository(database)
+	settingRepo := postsettingrepository.NewPostSettingRepository(database)
+	commentReviewRepo := commentreviewrepository.NewCommentReviewRepository(database)
+	feedItemRepo := feeditemrepository.NewFeedItemRepository(database)
+	largeAccountRepo := largeaccountrepository.NewLargeAccountRepository(database)
+	postSimilarityRepo := postsimilarityrepository.NewPostSimilarityRepository(database)
+	impressionRepo := impressionrepository.NewImpressionRepository(database)
+	reactionRepo := reactionrepository.NewReactionRepository(database)
+	campaignRepo := campaignrepository.NewCampaignRepository(database)
+	scheduledPostRepo := scheduledpostrepository.NewScheduledPostRepository(database)
+	draftRepo := draftrepository.NewDraftRepository(database)
+	postMediaRepo := postmediarepository.NewPostMediaRepository(database)
+	storyRepo := storyrepository.NewStoryRepository(database)
+	highlightRepo := highlightrepository.NewHighlightRepository(database)
+	closeFriendRepo := closefriendrepository.NewCloseFriendRepository(database)
+	muteRepo := muterepository.NewMuteRepository(database)
+	pendingFanOutRepo := pendingfanoutrepository.NewPendingFanOutRepository(database)
+	postTermRepo := posttermrepository.NewPostTermRepository(database)
+	suite.service = NewPostService(postRepo, likeRepo, dislikeRepo, commentRepo, bannedMediaRepo, reportRepo, restrictionRepo, settingRepo, commentReviewRepo, feedItemRepo, largeAccountRepo, postSimilarityRepo, impressionRepo, reactionRepo, campaignRepo, scheduledPostRepo, draftRepo, postMediaRepo, storyRepo, highlightRepo, closeFriendRepo, muteRepo, pendingFanOutRepo, postTermRepo, feed_ranker.NewFeedRanker(), mediaGrpcClient, userGrpcClient)
 }
 
 func (suite *PostServiceIntegrationTestsSuite) SetupTest() {
@@ -232,13 +319,13 @@ func (suite *PostServiceIntegrationTestsSuite) TestIntegrationPostService_Report
 	id := uint(10000)
 	err := rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get post with id %d", id))
 
-	reportErr := suite.service.ReportInappropriateContent(id)
+	reportErr := suite.service.ReportInappropriateContent(id, "")
 
 	assert.Equal(suite.T(), err, reportErr)
 }
 
 func (suite *PostServiceIntegrationTestsSuite) TestIntegrationPostService_ReportInappropriatePost() {
-	reportErr := suite.service.ReportInappropriateContent(1)
+	reportErr := suite.service.ReportInappropriateContent(1, "spam")
 
 	assert.Equal(suite.T(), nil, reportErr)
 }
@@ -1,35 +1,109 @@
 package post
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/Nistagram-Organization/nistagram-posts/src/clients/media_grpc_client"
 	"github.com/Nistagram-Organization/nistagram-posts/src/clients/user_grpc_client"
 	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/Nistagram-Organization/nistagram-posts/src/feed_ranker"
+	"github.com/Nistagram-Organization/nistagram-posts/src/image_hash"
+	modelAuthorRestriction "github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	modelBannedMedia "github.com/Nistagram-Organization/nistagram-posts/src/model/banned_media"
+	modelCampaign "github.com/Nistagram-Organization/nistagram-posts/src/model/campaign"
+	modelCloseFriend "github.com/Nistagram-Organization/nistagram-posts/src/model/close_friend"
+	modelCommentReview "github.com/Nistagram-Organization/nistagram-posts/src/model/comment_review"
+	modelDraft "github.com/Nistagram-Organization/nistagram-posts/src/model/draft"
+	modelFeedItem "github.com/Nistagram-Organization/nistagram-posts/src/model/feed_item"
+	modelHighlight "github.com/Nistagram-Organization/nistagram-posts/src/model/highlight"
+	modelImpression "github.com/Nistagram-Organization/nistagram-posts/src/model/impression"
+	modelLargeAccount "github.com/Nistagram-Organization/nistagram-posts/src/model/large_account"
+	modelMute "github.com/Nistagram-Organization/nistagram-posts/src/model/mute"
+	modelPendingFanOut "github.com/Nistagram-Organization/nistagram-posts/src/model/pending_fan_out"
+	modelPostMedia "github.com/Nistagram-Organization/nistagram-posts/src/model/post_media"
+	modelPostSetting "github.com/Nistagram-Organization/nistagram-posts/src/model/post_setting"
+	modelPostSimilarity "github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	modelPostTerm "github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	modelReaction "github.com/Nistagram-Organization/nistagram-posts/src/model/reaction"
+	modelReport "github.com/Nistagram-Organization/nistagram-posts/src/model/report"
+	modelScheduledPost "github.com/Nistagram-Organization/nistagram-posts/src/model/scheduled_post"
+	modelStory "github.com/Nistagram-Organization/nistagram-posts/src/model/story"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/banned_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/campaign"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/close_friend"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/comment_review"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/dislike"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/draft"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/feed_item"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/highlight"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/impression"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/large_account"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/like"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/mute"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/pending_fan_out"
 	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_media"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_setting"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/post_term"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/reaction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/report"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/scheduled_post"
+	"github.com/Nistagram-Organization/nistagram-posts/src/repositories/story"
+	"github.com/Nistagram-Organization/nistagram-posts/src/similarity"
 	"github.com/Nistagram-Organization/nistagram-posts/src/time_utils"
+	"github.com/Nistagram-Organization/nistagram-posts/src/trending"
 	modelComment "github.com/Nistagram-Organization/nistagram-shared/src/model/comment"
 	modelDislike "github.com/Nistagram-Organization/nistagram-shared/src/model/dislike"
 	modelLike "github.com/Nistagram-Organization/nistagram-shared/src/model/like"
 	modelPost "github.com/Nistagram-Organization/nistagram-shared/src/model/post"
 	"github.com/Nistagram-Organization/nistagram-shared/src/utils/rest_error"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strings"
 	"testing"
 )
 
 type PostServiceUnitTestsSuite struct {
 	suite.Suite
-	postsRepositoryMock    *post.PostRepositoryMock
-	likesRepositoryMock    *like.LikeRepositoryMock
-	dislikesRepositoryMock *dislike.DislikeRepositoryMock
-	commentsRepositoryMock *comment.CommentRepositoryMock
-	mediaGrpcClientMock    *media_grpc_client.MediaGrpcClientMock
-	userGrpcClient         *user_grpc_client.UserGrpcClient
-	service                PostService
+	postsRepositoryMock          *post.PostRepositoryMock
+	likesRepositoryMock          *like.LikeRepositoryMock
+	dislikesRepositoryMock       *dislike.DislikeRepositoryMock
+	commentsRepositoryMock       *comment.CommentRepositoryMock
+	bannedMediaRepositoryMock    *banned_media.BannedMediaRepositoryMock
+	reportsRepositoryMock        *report.ReportRepositoryMock
+	restrictionsRepositoryMock   *author_restriction.AuthorRestrictionRepositoryMock
+	settingsRepositoryMock       *post_setting.PostSettingRepositoryMock
+	commentReviewsRepositoryMock *comment_review.CommentReviewRepositoryMock
+	feedItemsRepositoryMock      *feed_item.FeedItemRepositoryMock
+	largeAccountsRepositoryMock  *large_account.LargeAccountRepositoryMock
+	similaritiesRepositoryMock   *post_similarity.PostSimilarityRepositoryMock
+	impressionsRepositoryMock    *impression.ImpressionRepositoryMock
+	reactionsRepositoryMock      *reaction.ReactionRepositoryMock
+	campaignsRepositoryMock      *campaign.CampaignRepositoryMock
+	scheduledPostsRepositoryMock *scheduled_post.ScheduledPostRepositoryMock
+	draftsRepositoryMock         *draft.DraftRepositoryMock
+	postMediaRepositoryMock      *post_media.PostMediaRepositoryMock
+	storiesRepositoryMock        *story.StoryRepositoryMock
+	highlightsRepositoryMock     *highlight.HighlightRepositoryMock
+	closeFriendsRepositoryMock   *close_friend.CloseFriendRepositoryMock
+	mutesRepositoryMock          *mute.MuteRepositoryMock
+	pendingFanOutsRepositoryMock *pending_fan_out.PendingFanOutRepositoryMock
+	postTermsRepositoryMock      *post_term.PostTermRepositoryMock
+	mediaGrpcClientMock          *media_grpc_client.MediaGrpcClientMock
+	userGrpcClientMock           *user_grpc_client.UserGrpcClientMock
+	service                      PostService
 }
 
 func TestPostServiceUnitTestsSuite(t *testing.T) {
@@ -41,10 +115,106 @@ func (suite *PostServiceUnitTestsSuite) SetupSuite() {
 	suite.likesRepositoryMock = new(like.LikeRepositoryMock)
 	suite.dislikesRepositoryMock = new(dislike.DislikeRepositoryMock)
 	suite.commentsRepositoryMock = new(comment.CommentRepositoryMock)
+	suite.bannedMediaRepositoryMock = new(banned_media.BannedMediaRepositoryMock)
+	suite.reportsRepositoryMock = new(report.ReportRepositoryMock)
+	suite.restrictionsRepositoryMock = new(author_restriction.AuthorRestrictionRepositoryMock)
+	suite.settingsRepositoryMock = new(post_setting.PostSettingRepositoryMock)
+	suite.commentReviewsRepositoryMock = new(comment_review.CommentReviewRepositoryMock)
+	suite.feedItemsRepositoryMock = new(feed_item.FeedItemRepositoryMock)
+	suite.largeAccountsRepositoryMock = new(large_account.LargeAccountRepositoryMock)
+	suite.similaritiesRepositoryMock = new(post_similarity.PostSimilarityRepositoryMock)
+	suite.impressionsRepositoryMock = new(impression.ImpressionRepositoryMock)
+	suite.reactionsRepositoryMock = new(reaction.ReactionRepositoryMock)
+	suite.campaignsRepositoryMock = new(campaign.CampaignRepositoryMock)
+	suite.scheduledPostsRepositoryMock = new(scheduled_post.ScheduledPostRepositoryMock)
+	suite.draftsRepositoryMock = new(draft.DraftRepositoryMock)
+	suite.postMediaRepositoryMock = new(post_media.PostMediaRepositoryMock)
+	suite.storiesRepositoryMock = new(story.StoryRepositoryMock)
+	suite.highlightsRepositoryMock = new(highlight.HighlightRepositoryMock)
+	suite.closeFriendsRepositoryMock = new(close_friend.CloseFriendRepositoryMock)
+	suite.mutesRepositoryMock = new(mute.MuteRepositoryMock)
+	suite.pendingFanOutsRepositoryMock = new(pending_fan_out.PendingFanOutRepositoryMock)
+	suite.postTermsRepositoryMock = new(post_term.PostTermRepositoryMock)
 	suite.mediaGrpcClientMock = new(media_grpc_client.MediaGrpcClientMock)
-	suite.userGrpcClient = new(user_grpc_client.UserGrpcClient)
+	suite.userGrpcClientMock = new(user_grpc_client.UserGrpcClientMock)
 	suite.service = NewPostService(suite.postsRepositoryMock, suite.likesRepositoryMock, suite.dislikesRepositoryMock,
-		suite.commentsRepositoryMock, suite.mediaGrpcClientMock, *suite.userGrpcClient)
+		suite.commentsRepositoryMock, suite.bannedMediaRepositoryMock, suite.reportsRepositoryMock,
+		suite.restrictionsRepositoryMock, suite.settingsRepositoryMock, suite.commentReviewsRepositoryMock,
+		suite.feedItemsRepositoryMock, suite.largeAccountsRepositoryMock, suite.similaritiesRepositoryMock,
+		suite.impressionsRepositoryMock, suite.reactionsRepositoryMock, suite.campaignsRepositoryMock,
+		suite.scheduledPostsRepositoryMock, suite.draftsRepositoryMock, suite.postMediaRepositoryMock, suite.storiesRepositoryMock, suite.highlightsRepositoryMock, suite.closeFriendsRepositoryMock, suite.mutesRepositoryMock, suite.pendingFanOutsRepositoryMock, suite.postTermsRepositoryMock,
+		feed_ranker.NewFeedRanker(), suite.mediaGrpcClientMock, suite.userGrpcClientMock)
+}
+
+func gradientImageBase64() string {
+	img := image.NewGray(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8((x*4 + y*2) % 256)})
+		}
+	}
+
+	var buffer bytes.Buffer
+	png.Encode(&buffer, img)
+
+	return base64.StdEncoding.EncodeToString(buffer.Bytes())
+}
+
+// sniffableImageBase64 returns media recognized as a 1x1 PNG whose pixels can not be decoded, so it is never hashed
+func sniffableImageBase64(content string) string {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 1, 1)))
+	// Signature and header chunk are kept, image data is replaced with the content
+	header := buffer.Bytes()[:33]
+	return base64.StdEncoding.EncodeToString(append(header, content...))
+}
+
+func notRestricted(userEmail string) rest_error.RestErr {
+	return rest_error.NewNotFoundError(fmt.Sprintf("User %s is not restricted", userEmail))
+}
+
+// allowProfile expects a profile access check of a public profile without blocks
+func (suite *PostServiceUnitTestsSuite) allowProfile(author string, viewer string) {
+	if viewer != "" {
+		suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: author}).Return(false, nil).Once()
+		suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: author, BlockedUser: viewer}).Return(false, nil).Once()
+	}
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: author}).Return(false, nil).Once()
+}
+
+// expectVisiblePost mocks reads of a post's details for a viewer who can see it, the post has no settings and no comments
+func (suite *PostServiceUnitTestsSuite) expectVisiblePost(postEntity modelPost.Post, viewer string) {
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(nil, rest_error.NewNotFoundError(fmt.Sprintf("Post with id %d has no settings", postEntity.ID))).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: uint64(postEntity.MediaID)}).Return(fmt.Sprintf("image%d", postEntity.ID), nil).Once()
+	suite.expectPostDetails(postEntity, viewer)
+}
+
+// expectBlurredPost mocks reads of a post with a content warning shown blurred to the viewer, its media is not read
+func (suite *PostServiceUnitTestsSuite) expectBlurredPost(postEntity modelPost.Post, viewer string) {
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(&modelPostSetting.PostSetting{PostID: postEntity.ID, ContentWarning: modelPostSetting.ContentWarningGraphic}, nil).Once()
+	suite.expectPostDetails(postEntity, viewer)
+}
+
+// expectPostDetails mocks reads of a visible post's author, reactions and comments, the post has no comments
+func (suite *PostServiceUnitTestsSuite) expectPostDetails(postEntity modelPost.Post, viewer string) {
+	suite.userGrpcClientMock.On("GetUsername", dtos.GetUsernameRequest{Email: postEntity.UserEmail}).Return(postEntity.UserEmail, nil).Once()
+	if viewer != "" {
+		suite.likesRepositoryMock.On("GetByUserAndPost", viewer, postEntity.ID).Return(nil, rest_error.NewNotFoundError("Like not found")).Once()
+		suite.dislikesRepositoryMock.On("GetByUserAndPost", viewer, postEntity.ID).Return(nil, rest_error.NewNotFoundError("Dislike not found")).Once()
+		suite.userGrpcClientMock.On("CheckPostIsInFavorites", dtos.CheckFavoritesRequest{Email: viewer, PostID: postEntity.ID}).Return(false, nil).Once()
+	}
+	suite.likesRepositoryMock.On("GetNumberOfLikes", postEntity.ID).Return(int64(0), nil).Once()
+	suite.dislikesRepositoryMock.On("GetNumberOfDislikes", postEntity.ID).Return(int64(0), nil).Once()
+	suite.commentsRepositoryMock.On("GetComments", postEntity.ID).Return([]modelComment.Comment{}, nil).Once()
+	suite.commentReviewsRepositoryMock.On("GetByPost", postEntity.ID).Return([]modelCommentReview.CommentReview{}, nil).Once()
+}
+
+func postIDs(postsDTOs []dtos.PostDTO) []uint {
+	ids := make([]uint, 0, len(postsDTOs))
+	for _, postDTO := range postsDTOs {
+		ids = append(ids, postDTO.ID)
+	}
+	return ids
 }
 
 func (suite *PostServiceUnitTestsSuite) TestNewPostService() {
@@ -201,7 +371,13 @@ func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment() {
 		PostID: 1,
 	}
 
-	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{}, nil).Once()
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{ID: commentEntity.PostID}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(nil, notRestricted(commentEntity.UserEmail)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", commentEntity.PostID).Return(nil, rest_error.NewNotFoundError("Post with id 1 has no settings")).Once()
+	suite.commentsRepositoryMock.On("CountDuplicates", commentEntity.UserEmail, commentEntity.Text, commentEntity.PostID, mock.AnythingOfType("int64")).Return(int64(0), nil).Once()
+	suite.commentsRepositoryMock.On("CountUsersCommentsSince", commentEntity.UserEmail, mock.AnythingOfType("int64")).Return(int64(0), nil).Once()
+	suite.commentsRepositoryMock.On("GetUsersFirstCommentDate", commentEntity.UserEmail).Return(int64(0), nil).Once()
+	suite.postsRepositoryMock.On("GetUsersFirstPostDate", commentEntity.UserEmail).Return(int64(0), nil).Once()
 	suite.commentsRepositoryMock.On("Create", &commentEntity).Return(nil).Once()
 
 	commErr := suite.service.PostComment(&commentEntity)
@@ -212,7 +388,7 @@ func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment() {
 func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_GRPCError() {
 	postDTO := dtos.CreatePostDTO{
 		Description: "Opis",
-		Image:       "Image",
+		Image:       sniffableImageBase64("Image"),
 		UserEmail:   "mail@mail.com",
 	}
 	saveMediaRequest := dtos.SaveMediaRequest{
@@ -221,6 +397,7 @@ func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_GRPCError() {
 	errGrpc := errors.New("")
 	err := rest_error.NewInternalServerError("user grpc client error when saving media", errGrpc)
 
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
 	suite.mediaGrpcClientMock.On("SaveMedia", saveMediaRequest).Return(new(uint), errGrpc).Once()
 
 	createErr := suite.service.CreatePost(&postDTO)
@@ -231,7 +408,7 @@ func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_GRPCError() {
 func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost() {
 	postDTO := dtos.CreatePostDTO{
 		Description: "Opis",
-		Image:       "Image",
+		Image:       sniffableImageBase64("Image"),
 		UserEmail:   "mail@mail.com",
 	}
 	saveMediaRequest := dtos.SaveMediaRequest{
@@ -245,10 +422,2735 @@ func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost() {
 		MediaID:               0,
 	}
 
+	feedItems := []modelFeedItem.FeedItem{
+		{
+			UserEmail:   "follower@mail.com",
+			AuthorEmail: postDTO.UserEmail,
+			Date:        postEntity.Date,
+		},
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", saveMediaRequest).Return(new(uint), nil).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: postDTO.UserEmail}).Return([]string{"follower@mail.com"}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", feedItems).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_LargeAccount() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "large@mail.com",
+	}
+	saveMediaRequest := dtos.SaveMediaRequest{
+		Image: postDTO.Image,
+	}
+	postEntity := modelPost.Post{
+		Description:           postDTO.Description,
+		UserEmail:             postDTO.UserEmail,
+		MarkedAsInappropriate: false,
+		Date:                  time_utils.Now(),
+		MediaID:               0,
+	}
+	followers := make([]string, fanOutFollowerLimit+1)
+	largeAccount := modelLargeAccount.LargeAccount{
+		UserEmail: postDTO.UserEmail,
+		Followers: fanOutFollowerLimit + 1,
+		Date:      postEntity.Date,
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", saveMediaRequest).Return(new(uint), nil).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: postDTO.UserEmail}).Return(followers, nil).Once()
+	suite.largeAccountsRepositoryMock.On("Save", &largeAccount).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_FanOutFailed() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "unfanned@mail.com",
+	}
+	saveMediaRequest := dtos.SaveMediaRequest{
+		Image: postDTO.Image,
+	}
+	postEntity := modelPost.Post{
+		Description:           postDTO.Description,
+		UserEmail:             postDTO.UserEmail,
+		MarkedAsInappropriate: false,
+		Date:                  time_utils.Now(),
+		MediaID:               0,
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", saveMediaRequest).Return(new(uint), nil).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: postDTO.UserEmail}).Return(nil, errors.New("unavailable")).Once()
+	suite.pendingFanOutsRepositoryMock.On("Save", mock.AnythingOfType("*pending_fan_out.PendingFanOut")).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+	suite.largeAccountsRepositoryMock.AssertNotCalled(suite.T(), "Save", mock.MatchedBy(func(largeAccount *modelLargeAccount.LargeAccount) bool {
+		return largeAccount.UserEmail == postDTO.UserEmail
+	}))
+	suite.pendingFanOutsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RetryFanOuts() {
+	fannedOut := modelPost.Post{ID: 870, UserEmail: "retried@mail.com", Date: 100}
+	failing := modelPost.Post{ID: 872, UserEmail: "stillfailing@mail.com", Date: 100}
+	pendingFanOuts := []modelPendingFanOut.PendingFanOut{
+		{PostID: fannedOut.ID},
+		{PostID: 871},
+		{PostID: failing.ID},
+	}
+	feedItems := []modelFeedItem.FeedItem{
+		{UserEmail: "follower@mail.com", PostID: fannedOut.ID, AuthorEmail: fannedOut.UserEmail, Date: fannedOut.Date},
+	}
+	err := rest_error.NewInternalServerError("user grpc client error when getting followers", errors.New("unavailable"))
+
+	suite.pendingFanOutsRepositoryMock.On("GetAll").Return(pendingFanOuts, nil).Once()
+	suite.postsRepositoryMock.On("Get", fannedOut.ID).Return(&fannedOut, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: fannedOut.UserEmail}).Return([]string{"follower@mail.com"}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", feedItems).Return(nil).Once()
+	suite.pendingFanOutsRepositoryMock.On("Delete", &pendingFanOuts[0]).Return(nil).Once()
+	suite.postsRepositoryMock.On("Get", uint(871)).Return(nil, rest_error.NewNotFoundError("Error when trying to get post with id 871")).Once()
+	suite.pendingFanOutsRepositoryMock.On("Delete", &pendingFanOuts[1]).Return(nil).Once()
+	suite.postsRepositoryMock.On("Get", failing.ID).Return(&failing, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: failing.UserEmail}).Return(nil, errors.New("unavailable")).Once()
+
+	retryErr := suite.service.RetryFanOuts()
+
+	assert.Equal(suite.T(), err, retryErr)
+	suite.pendingFanOutsRepositoryMock.AssertNotCalled(suite.T(), "Delete", &pendingFanOuts[2])
+	suite.pendingFanOutsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetFeedPosts_PartialFeedReadsOnlyLargeAccounts() {
+	user := "partialfeed@mail.com"
+	followedUsers := []string{"fannedout@mail.com", "quietfollowed@mail.com", "partial-huge@mail.com"}
+	materialized := modelPost.Post{ID: 880, UserEmail: "fannedout@mail.com", Date: 300}
+	large := modelPost.Post{ID: 881, UserEmail: "partial-huge@mail.com", Date: 100}
+	items := []modelFeedItem.FeedItem{
+		{UserEmail: user, PostID: materialized.ID, AuthorEmail: materialized.UserEmail, Date: materialized.Date},
+		{UserEmail: user, PostID: 882, AuthorEmail: "formerlyfollowed@mail.com", Date: 200},
+	}
+
+	suite.feedItemsRepositoryMock.On("GetByUser", user, feedSize).Return(items, nil).Once()
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", []uint{materialized.ID}).Return([]modelPost.Post{materialized}, nil).Once()
+	suite.largeAccountsRepositoryMock.On("GetByUsers", followedUsers).Return([]modelLargeAccount.LargeAccount{{UserEmail: "partial-huge@mail.com"}}, nil).Once()
+	suite.postsRepositoryMock.On("GetProfilePosts", "partial-huge@mail.com", false).Return([]modelPost.Post{large}, nil).Once()
+
+	posts, err := suite.service.(*postsService).getFeedPosts(user, followedUsers)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []modelPost.Post{materialized, large}, posts)
+	suite.postsRepositoryMock.AssertNotCalled(suite.T(), "GetProfilePosts", "fannedout@mail.com", false)
+	suite.postsRepositoryMock.AssertNotCalled(suite.T(), "GetProfilePosts", "quietfollowed@mail.com", false)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetFeedPosts_FullFeedReadsLargeAccounts() {
+	user := "fullfeed@mail.com"
+	followedUsers := []string{"small@mail.com", "huge@mail.com"}
+	items := make([]modelFeedItem.FeedItem, feedSize)
+	for i := range items {
+		items[i] = modelFeedItem.FeedItem{UserEmail: user, PostID: 890, AuthorEmail: "small@mail.com", Date: 300}
+	}
+	materialized := modelPost.Post{ID: 890, UserEmail: "small@mail.com", Date: 300}
+	large := modelPost.Post{ID: 891, UserEmail: "huge@mail.com", Date: 200}
+	ids := make([]uint, feedSize)
+	for i := range ids {
+		ids[i] = materialized.ID
+	}
+
+	suite.feedItemsRepositoryMock.On("GetByUser", user, feedSize).Return(items, nil).Once()
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", ids).Return([]modelPost.Post{materialized}, nil).Once()
+	suite.largeAccountsRepositoryMock.On("GetByUsers", followedUsers).Return([]modelLargeAccount.LargeAccount{{UserEmail: "huge@mail.com"}}, nil).Once()
+	suite.postsRepositoryMock.On("GetProfilePosts", "huge@mail.com", false).Return([]modelPost.Post{large}, nil).Once()
+
+	posts, err := suite.service.(*postsService).getFeedPosts(user, followedUsers)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []modelPost.Post{materialized, large}, posts)
+	suite.postsRepositoryMock.AssertNotCalled(suite.T(), "GetProfilePosts", "small@mail.com", false)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_BannedMedia() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       gradientImageBase64(),
+		UserEmail:   "banned@mail.com",
+	}
+	hash, _ := image_hash.Compute(postDTO.Image)
+	bannedMedia := []modelBannedMedia.BannedMedia{
+		{
+			ID:   1,
+			Hash: hash,
+		},
+	}
+	err := rest_error.NewBadRequestError("Image matches previously removed content")
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.bannedMediaRepositoryMock.On("GetByBands", image_hash.Bands(hash, bannedMediaBands)).Return(bannedMedia, nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_SimilarToBannedMedia() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       gradientImageBase64(),
+		UserEmail:   "similar@mail.com",
+	}
+	saveMediaRequest := dtos.SaveMediaRequest{
+		Image: postDTO.Image,
+	}
+	hash, _ := image_hash.Compute(postDTO.Image)
+	bannedMedia := []modelBannedMedia.BannedMedia{
+		{
+			ID:   1,
+			Hash: hash ^ 0xFF,
+		},
+	}
+	postEntity := modelPost.Post{
+		Description:           postDTO.Description,
+		UserEmail:             postDTO.UserEmail,
+		MarkedAsInappropriate: true,
+		Date:                  time_utils.Now(),
+		MediaID:               0,
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.bannedMediaRepositoryMock.On("GetByBands", image_hash.Bands(hash, bannedMediaBands)).Return(bannedMedia, nil).Once()
 	suite.mediaGrpcClientMock.On("SaveMedia", saveMediaRequest).Return(new(uint), nil).Once()
-	suite.postsRepositoryMock.On("Create", &postEntity).Return(nil).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: postDTO.UserEmail}).Return([]string{}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", []modelFeedItem.FeedItem{}).Return(nil).Once()
 
 	createErr := suite.service.CreatePost(&postDTO)
 
 	assert.Equal(suite.T(), nil, createErr)
 }
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_DecideOnContentBulk() {
+	decisions := []dtos.ModerationDecisionDTO{
+		{PostID: 101, Delete: false},
+		{PostID: 102, Delete: false},
+		{PostID: 101, Delete: true},
+	}
+	keptPost := modelPost.Post{
+		ID:                    101,
+		MarkedAsInappropriate: true,
+	}
+	err := rest_error.NewNotFoundError(fmt.Sprintf("Error when trying to get post with id %d", 102))
+	expected := []dtos.ModerationDecisionResultDTO{
+		{PostID: 101, Success: true},
+		{PostID: 102, Success: false, Error: err.Message()},
+		{PostID: 101, Success: false, Error: "Duplicate decision for post"},
+	}
+
+	suite.postsRepositoryMock.On("Get", uint(101)).Return(&keptPost, nil).Once()
+	suite.postsRepositoryMock.On("Get", uint(102)).Return(nil, err).Once()
+	suite.postsRepositoryMock.On("ApplyModerationDecisions", []modelPost.Post{keptPost}, []modelPost.Post(nil), []modelBannedMedia.BannedMedia(nil)).Return(nil).Once()
+
+	results, decideErr := suite.service.DecideOnContentBulk(decisions)
+
+	assert.Equal(suite.T(), nil, decideErr)
+	assert.Equal(suite.T(), expected, results)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_DecideOnContentBulk_TooManyDecisions() {
+	decisions := make([]dtos.ModerationDecisionDTO, maxModerationDecisions+1)
+	err := rest_error.NewBadRequestError(fmt.Sprintf("At most %d decisions can be made at once", maxModerationDecisions))
+
+	results, decideErr := suite.service.DecideOnContentBulk(decisions)
+
+	assert.Nil(suite.T(), results)
+	assert.Equal(suite.T(), err, decideErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetModerationQueue() {
+	filter := dtos.ModerationQueueFilter{
+		Author: "queued@mail.com",
+		Reason: "spam",
+		MinAge: 2,
+		Page:   3,
+		Size:   maxModerationPageSize + 50,
+	}
+	queuedPost := modelPost.Post{
+		ID:          850,
+		MediaID:     851,
+		UserEmail:   filter.Author,
+		Description: "queued",
+	}
+	reports := []modelReport.Report{
+		{PostID: queuedPost.ID, Reason: "spam"},
+		{PostID: queuedPost.ID},
+	}
+	expected := &dtos.ModerationQueueDTO{
+		Items: []dtos.InappropriateContentReportDTO{
+			{
+				Description: queuedPost.Description,
+				AuthorEmail: queuedPost.UserEmail,
+				Image:       "image",
+				PostID:      queuedPost.ID,
+				Reports:     2,
+				Reasons:     []string{"spam"},
+			},
+		},
+		Page:  3,
+		Size:  maxModerationPageSize,
+		Total: 201,
+	}
+	before := time_utils.Now() - 2*3600
+
+	suite.postsRepositoryMock.On("GetModerationQueue", mock.MatchedBy(func(query post.ModerationQueueQuery) bool {
+		return query.Author == filter.Author && query.Reason == filter.Reason &&
+			query.PublishedBefore >= before && query.PublishedBefore <= before+1 && query.PublishedAfter == 0 &&
+			query.Offset == 2*maxModerationPageSize && query.Limit == maxModerationPageSize
+	})).Return([]modelPost.Post{queuedPost}, int64(201), nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 851}).Return("image", nil).Once()
+	suite.reportsRepositoryMock.On("GetByPost", queuedPost.ID).Return(reports, nil).Once()
+
+	queue, getErr := suite.service.GetModerationQueue(filter)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), expected, queue)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment_CommentsDisabled() {
+	commentEntity := modelComment.Comment{
+		PostID:    1,
+		UserEmail: "spammer@mail.com",
+	}
+	restriction := modelAuthorRestriction.AuthorRestriction{
+		UserEmail:        commentEntity.UserEmail,
+		CommentsDisabled: true,
+	}
+	err := rest_error.NewRestError("Commenting is disabled for this user", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(&restriction, nil).Once()
+
+	commErr := suite.service.PostComment(&commentEntity)
+
+	assert.Equal(suite.T(), err, commErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RemoveAuthorRestriction() {
+	restriction := modelAuthorRestriction.AuthorRestriction{
+		ID:           860,
+		UserEmail:    "forgiven@mail.com",
+		ShadowBanned: true,
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", restriction.UserEmail).Return(&restriction, nil).Once()
+	suite.restrictionsRepositoryMock.On("Delete", &restriction).Return(nil).Once()
+
+	removeErr := suite.service.RemoveAuthorRestriction(restriction.UserEmail)
+
+	assert.Equal(suite.T(), nil, removeErr)
+	suite.restrictionsRepositoryMock.AssertCalled(suite.T(), "Delete", &restriction)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RemoveAuthorRestriction_NotRestricted() {
+	userEmail := "unrestricted@mail.com"
+	err := notRestricted(userEmail)
+
+	suite.restrictionsRepositoryMock.On("GetByUser", userEmail).Return(nil, err).Once()
+
+	removeErr := suite.service.RemoveAuthorRestriction(userEmail)
+
+	assert.Equal(suite.T(), err, removeErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RestrictAuthor_EmptyRestrictionDeletes() {
+	restriction := modelAuthorRestriction.AuthorRestriction{
+		ID:               861,
+		UserEmail:        "cleared@mail.com",
+		CommentsDisabled: true,
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", restriction.UserEmail).Return(&restriction, nil).Once()
+	suite.restrictionsRepositoryMock.On("Delete", &restriction).Return(nil).Once()
+
+	restrictErr := suite.service.RestrictAuthor(&modelAuthorRestriction.AuthorRestriction{UserEmail: restriction.UserEmail})
+
+	assert.Equal(suite.T(), nil, restrictErr)
+	suite.restrictionsRepositoryMock.AssertNotCalled(suite.T(), "Save", mock.MatchedBy(func(saved *modelAuthorRestriction.AuthorRestriction) bool {
+		return saved.UserEmail == restriction.UserEmail
+	}))
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_RateLimited() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "spammer@mail.com",
+	}
+	restriction := modelAuthorRestriction.AuthorRestriction{
+		UserEmail:     postDTO.UserEmail,
+		PostRateLimit: 2,
+	}
+	err := rest_error.NewRestError("Post rate limit exceeded", http.StatusTooManyRequests, "too_many_requests", nil)
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(&restriction, nil).Once()
+	suite.postsRepositoryMock.On("CountUsersPostsSince", postDTO.UserEmail, mock.AnythingOfType("int64")).Return(int64(2), nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_InvalidContentWarning() {
+	postDTO := dtos.CreatePostDTO{
+		Description:    "Opis",
+		Image:          sniffableImageBase64("Image"),
+		UserEmail:      "mail@mail.com",
+		ContentWarning: "unknown",
+	}
+	err := rest_error.NewBadRequestError("Invalid content warning")
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetContentWarning_NotAuthor() {
+	contentWarningRequest := dtos.ContentWarningRequestDTO{
+		PostID:         201,
+		UserEmail:      "other@mail.com",
+		ContentWarning: modelPostSetting.ContentWarningMedical,
+	}
+	err := rest_error.NewRestError("Only author can set content warning", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", contentWarningRequest.PostID).Return(&modelPost.Post{ID: 201, UserEmail: "author@mail.com"}, nil).Once()
+
+	warningErr := suite.service.SetContentWarning(&contentWarningRequest, false)
+
+	assert.Equal(suite.T(), err, warningErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetContentWarning_Moderator() {
+	contentWarningRequest := dtos.ContentWarningRequestDTO{
+		PostID:         202,
+		ContentWarning: modelPostSetting.ContentWarningGraphic,
+	}
+	setting := modelPostSetting.PostSetting{
+		ID:     3,
+		PostID: contentWarningRequest.PostID,
+	}
+
+	suite.postsRepositoryMock.On("Get", contentWarningRequest.PostID).Return(&modelPost.Post{ID: 202, UserEmail: "author@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", contentWarningRequest.PostID).Return(&setting, nil).Once()
+	suite.settingsRepositoryMock.On("Update", &modelPostSetting.PostSetting{
+		ID:             3,
+		PostID:         contentWarningRequest.PostID,
+		ContentWarning: modelPostSetting.ContentWarningGraphic,
+	}, []string{"content_warning"}).Return(nil).Once()
+
+	warningErr := suite.service.SetContentWarning(&contentWarningRequest, true)
+
+	assert.Equal(suite.T(), nil, warningErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment_Quarantined() {
+	commentEntity := modelComment.Comment{
+		PostID:    301,
+		UserEmail: "spammer@mail.com",
+		Text:      "Cheap followers http://spam.com www.spam.com",
+	}
+
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{ID: commentEntity.PostID}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(nil, notRestricted(commentEntity.UserEmail)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", commentEntity.PostID).Return(nil, rest_error.NewNotFoundError("Post with id 301 has no settings")).Once()
+	suite.commentsRepositoryMock.On("CountDuplicates", commentEntity.UserEmail, commentEntity.Text, commentEntity.PostID, mock.AnythingOfType("int64")).Return(int64(2), nil).Once()
+	suite.commentsRepositoryMock.On("CountUsersCommentsSince", commentEntity.UserEmail, mock.AnythingOfType("int64")).Return(int64(5), nil).Once()
+	suite.commentsRepositoryMock.On("GetUsersFirstCommentDate", commentEntity.UserEmail).Return(int64(0), nil).Once()
+	suite.postsRepositoryMock.On("GetUsersFirstPostDate", commentEntity.UserEmail).Return(int64(0), nil).Once()
+	suite.commentsRepositoryMock.On("Create", &commentEntity).Return(nil).Once()
+	suite.commentReviewsRepositoryMock.On("Create", mock.MatchedBy(func(review *modelCommentReview.CommentReview) bool {
+		return review.PostID == commentEntity.PostID && review.Reasons == "duplicate,links,velocity,new_account"
+	})).Return(nil).Once()
+
+	commErr := suite.service.PostComment(&commentEntity)
+
+	assert.Equal(suite.T(), nil, commErr)
+	suite.commentReviewsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetExplore_ExcludesFollowedAndBlockedAuthors() {
+	viewer := "viewer@mail.com"
+	now := time_utils.Now()
+	posts := []modelPost.Post{
+		{ID: 401, UserEmail: "followed@mail.com", MediaID: 4010, Date: now},
+		{ID: 402, UserEmail: "blocked@mail.com", MediaID: 4020, Date: now},
+		{ID: 403, UserEmail: "quiet@mail.com", MediaID: 4030, Date: now},
+		{ID: 404, UserEmail: viewer, MediaID: 4040, Date: now},
+		{ID: 405, UserEmail: "unknown@mail.com", MediaID: 4050, Date: now},
+		{ID: 406, UserEmail: "popular@mail.com", MediaID: 4060, Date: now},
+	}
+	ids := []uint{401, 402, 403, 404, 405, 406}
+
+	suite.postsRepositoryMock.On("GetPublishedSince", mock.AnythingOfType("int64")).Return(posts, nil).Once()
+	suite.likesRepositoryMock.On("CountByPosts", ids).Return(map[uint]int64{401: 9, 402: 8, 403: 2, 404: 1, 405: 6, 406: 7}, nil).Once()
+	suite.commentsRepositoryMock.On("CountByPosts", ids).Return(map[uint]int64{}, nil).Once()
+	for _, postEntity := range posts {
+		suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	}
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{"followed@mail.com"}, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "blocked@mail.com"}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "unknown@mail.com"}).Return(false, errors.New("unavailable")).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "popular@mail.com"}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "quiet@mail.com"}).Return(false, nil).Once()
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", []uint{406, 403}).Return([]modelPost.Post{posts[2], posts[5]}, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{406, 403}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	for _, postEntity := range []modelPost.Post{posts[5], posts[2]} {
+		suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+		suite.allowProfile(postEntity.UserEmail, viewer)
+		suite.expectVisiblePost(postEntity, viewer)
+	}
+
+	refreshErr := suite.service.RefreshExplore()
+	explorePosts, exploreErr := suite.service.GetExplore(viewer, dtos.SensitiveContentBlur)
+
+	assert.Equal(suite.T(), nil, refreshErr)
+	assert.Equal(suite.T(), nil, exploreErr)
+	assert.Equal(suite.T(), []uint{406, 403}, postIDs(explorePosts))
+	suite.userGrpcClientMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetTrending() {
+	restrictions := []modelAuthorRestriction.AuthorRestriction{
+		{UserEmail: "trend-shadow@mail.com", ShadowBanned: true},
+		{UserEmail: "trend-limited@mail.com", PostRateLimit: 1},
+	}
+	countQuery := func(kind string, window dtos.TrendingWindow) interface{} {
+		return mock.MatchedBy(func(query post_term.TermCountQuery) bool {
+			return query.Kind == kind && query.End-query.Start == window.Seconds() &&
+				query.Start-query.BaselineStart == trending.BaselinePeriods*window.Seconds() &&
+				assert.ObjectsAreEqual([]string{"trend-shadow@mail.com"}, query.ExcludedUsers) &&
+				assert.ObjectsAreEqual([]string{"trend-shadow@mail.com", "trend-private@mail.com", "trend-unknown@mail.com"}, query.ExcludedPostAuthors)
+		})
+	}
+
+	suite.restrictionsRepositoryMock.On("GetAll").Return(restrictions).Once()
+	suite.postTermsRepositoryMock.On("GetPostAuthorsSince", mock.AnythingOfType("int64")).Return([]string{"trend@mail.com", "trend-private@mail.com", "trend-unknown@mail.com"}, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: "trend@mail.com"}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: "trend-private@mail.com"}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: "trend-unknown@mail.com"}).Return(false, errors.New("unavailable")).Once()
+	suite.postTermsRepositoryMock.On("CountTerms", countQuery(modelPostTerm.KindHashtag, dtos.TrendingWindowHour)).Return([]modelPostTerm.TermCount{
+		{Term: "#golang", Current: 6, Baseline: 4},
+		{Term: "#rust", Current: 7, Baseline: 40},
+	}, nil).Once()
+	suite.postTermsRepositoryMock.On("CountTerms", countQuery(modelPostTerm.KindMention, dtos.TrendingWindowHour)).Return([]modelPostTerm.TermCount{}, nil).Once()
+	suite.postTermsRepositoryMock.On("CountTerms", countQuery(modelPostTerm.KindHashtag, dtos.TrendingWindowDay)).Return([]modelPostTerm.TermCount{
+		{Term: "#golang", Current: 2},
+	}, nil).Once()
+	suite.postTermsRepositoryMock.On("CountTerms", countQuery(modelPostTerm.KindMention, dtos.TrendingWindowDay)).Return([]modelPostTerm.TermCount{
+		{Term: "ana", Current: 1},
+	}, nil).Once()
+	suite.postTermsRepositoryMock.On("CountTerms", countQuery(modelPostTerm.KindHashtag, dtos.TrendingWindowWeek)).Return([]modelPostTerm.TermCount{}, nil).Once()
+	suite.postTermsRepositoryMock.On("CountTerms", countQuery(modelPostTerm.KindMention, dtos.TrendingWindowWeek)).Return([]modelPostTerm.TermCount{}, nil).Once()
+
+	refreshErr := suite.service.RefreshTrending()
+	hourTrending := suite.service.GetTrending(dtos.TrendingWindowHour)
+	dayTrending := suite.service.GetTrending(dtos.TrendingWindowDay)
+
+	assert.Equal(suite.T(), nil, refreshErr)
+	assert.Equal(suite.T(), []dtos.TrendingTermDTO{
+		{Term: "#rust", Count: 7, Baseline: 10},
+		{Term: "#golang", Count: 6, Baseline: 1, Spike: true},
+	}, hourTrending.Hashtags)
+	assert.Empty(suite.T(), hourTrending.Mentions)
+	assert.Equal(suite.T(), []dtos.TrendingTermDTO{{Term: "#golang", Count: 2}}, dayTrending.Hashtags)
+	assert.Equal(suite.T(), []dtos.TrendingTermDTO{{Term: "ana", Count: 1}}, dayTrending.Mentions)
+	suite.postTermsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RefreshSimilarPosts() {
+	posts := []modelPost.Post{
+		{ID: 601, Description: "#sea"},
+		{ID: 602, Description: "#sea #sun"},
+	}
+	likes := []modelLike.Like{
+		{PostID: 601, UserEmail: "fan@mail.com"},
+		{PostID: 602, UserEmail: "fan@mail.com"},
+	}
+
+	suite.postsRepositoryMock.On("GetPublishedSince", mock.AnythingOfType("int64")).Return(posts, nil).Once()
+	suite.likesRepositoryMock.On("GetRecentByPosts", []uint{601, 602}, similarity.MaxLikes).Return(likes, nil).Once()
+	suite.similaritiesRepositoryMock.On("ReplaceAll", []modelPostSimilarity.PostSimilarity{
+		{PostID: 601, SimilarPostID: 602, Score: 0.85},
+		{PostID: 602, SimilarPostID: 601, Score: 0.85},
+	}).Return(nil).Once()
+
+	refreshErr := suite.service.RefreshSimilarPosts()
+
+	assert.Equal(suite.T(), nil, refreshErr)
+	suite.similaritiesRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetSimilarPosts_SkipsRemovedAndFlaggedPosts() {
+	similarities := []modelPostSimilarity.PostSimilarity{
+		{PostID: 603, SimilarPostID: 607, Score: 0.95},
+		{PostID: 603, SimilarPostID: 604, Score: 0.9},
+		{PostID: 603, SimilarPostID: 605, Score: 0.5},
+		{PostID: 603, SimilarPostID: 608, Score: 0.3},
+	}
+	found := []modelPost.Post{
+		{ID: 608, UserEmail: "similar-second@mail.com", MediaID: 6080},
+		{ID: 605, MarkedAsInappropriate: true},
+		{ID: 607, UserEmail: "similar-first@mail.com", MediaID: 6070},
+	}
+
+	suite.postsRepositoryMock.On("Get", uint(603)).Return(&modelPost.Post{ID: 603}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(603)).Return(nil, rest_error.NewNotFoundError("Post with id 603 has no settings")).Once()
+	suite.similaritiesRepositoryMock.On("GetByPost", uint(603), similarPostsSize).Return(similarities, nil).Once()
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", []uint{607, 604, 605, 608}).Return(found, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{607, 608}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	for _, postEntity := range []modelPost.Post{found[2], found[0]} {
+		suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+		suite.allowProfile(postEntity.UserEmail, "")
+		suite.expectVisiblePost(postEntity, "")
+	}
+
+	posts, err := suite.service.GetSimilarPosts(603, "", dtos.SensitiveContentBlur)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []uint{607, 608}, postIDs(posts))
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetSimilarPosts_PostNotFound() {
+	err := rest_error.NewNotFoundError("Error when trying to get post with id 606")
+
+	suite.postsRepositoryMock.On("Get", uint(606)).Return(nil, err).Once()
+
+	posts, getErr := suite.service.GetSimilarPosts(606, "", dtos.SensitiveContentBlur)
+
+	assert.Nil(suite.T(), posts)
+	assert.Equal(suite.T(), http.StatusNotFound, getErr.Status())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RecordImpressions() {
+	impressionsRequest := dtos.ImpressionsRequestDTO{
+		UserEmail: "viewer@mail.com",
+		PostIDs:   []uint{701, 702, 703},
+	}
+	posts := []modelPost.Post{
+		{ID: 701, UserEmail: "author@mail.com"},
+		{ID: 702, UserEmail: impressionsRequest.UserEmail},
+	}
+
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", impressionsRequest.PostIDs).Return(posts, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", "author@mail.com").Return(nil, notRestricted("author@mail.com")).Once()
+	suite.allowProfile("author@mail.com", impressionsRequest.UserEmail)
+	suite.settingsRepositoryMock.On("GetByPost", uint(701)).Return(nil, rest_error.NewNotFoundError("Post with id 701 has no settings")).Once()
+	suite.impressionsRepositoryMock.On("Record", mock.MatchedBy(func(impressions []modelImpression.Impression) bool {
+		return len(impressions) == 1 && impressions[0].PostID == 701 &&
+			impressions[0].UserEmail == impressionsRequest.UserEmail && impressions[0].Day%(24*3600) == 0
+	})).Return(int64(1), nil).Once()
+
+	result, err := suite.service.RecordImpressions(&impressionsRequest)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), int64(1), result.Recorded)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RecordImpressions_SkipsHiddenPosts() {
+	viewer := "impressions-viewer@mail.com"
+	impressionsRequest := dtos.ImpressionsRequestDTO{
+		UserEmail: viewer,
+		PostIDs:   []uint{704, 705, 706, 707},
+	}
+	posts := []modelPost.Post{
+		{ID: 704, UserEmail: "impressions-banned@mail.com"},
+		{ID: 705, UserEmail: "impressions-blocker@mail.com"},
+		{ID: 706, UserEmail: "impressions-followers@mail.com"},
+		{ID: 707, UserEmail: "impressions-public@mail.com"},
+	}
+
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", impressionsRequest.PostIDs).Return(posts, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", "impressions-banned@mail.com").Return(&modelAuthorRestriction.AuthorRestriction{UserEmail: "impressions-banned@mail.com", ShadowBanned: true}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", "impressions-blocker@mail.com").Return(nil, notRestricted("impressions-blocker@mail.com")).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "impressions-blocker@mail.com"}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "impressions-blocker@mail.com", BlockedUser: viewer}).Return(true, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", "impressions-followers@mail.com").Return(nil, notRestricted("impressions-followers@mail.com")).Once()
+	suite.allowProfile("impressions-followers@mail.com", viewer)
+	suite.settingsRepositoryMock.On("GetByPost", uint(706)).Return(&modelPostSetting.PostSetting{PostID: 706, Audience: modelPostSetting.AudienceFollowers}, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", "impressions-public@mail.com").Return(nil, notRestricted("impressions-public@mail.com")).Once()
+	suite.allowProfile("impressions-public@mail.com", viewer)
+	suite.settingsRepositoryMock.On("GetByPost", uint(707)).Return(nil, rest_error.NewNotFoundError("Post with id 707 has no settings")).Once()
+	suite.impressionsRepositoryMock.On("Record", []modelImpression.Impression{
+		{PostID: 707, UserEmail: viewer, Day: time_utils.Now() - time_utils.Now()%secondsInDay},
+	}).Return(int64(1), nil).Once()
+
+	result, err := suite.service.RecordImpressions(&impressionsRequest)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), int64(1), result.Recorded)
+	suite.impressionsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RecordImpressions_BatchTooLarge() {
+	impressionsRequest := dtos.ImpressionsRequestDTO{
+		UserEmail: "viewer@mail.com",
+		PostIDs:   make([]uint, maxImpressionsBatchSize+1),
+	}
+
+	result, err := suite.service.RecordImpressions(&impressionsRequest)
+
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), http.StatusBadRequest, err.Status())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostInsights_NotAuthor() {
+	suite.postsRepositoryMock.On("Get", uint(801)).Return(&modelPost.Post{ID: 801, UserEmail: "agent@mail.com"}, nil).Once()
+
+	postInsights, err := suite.service.GetPostInsights(801, "other@mail.com", dtos.InsightsFilter{})
+
+	assert.Nil(suite.T(), postInsights)
+	assert.Equal(suite.T(), http.StatusForbidden, err.Status())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostInsights_InvalidPeriod() {
+	suite.postsRepositoryMock.On("Get", uint(802)).Return(&modelPost.Post{ID: 802, UserEmail: "agent@mail.com"}, nil).Once()
+
+	postInsights, err := suite.service.GetPostInsights(802, "agent@mail.com", dtos.InsightsFilter{From: 2000, To: 1000})
+
+	assert.Nil(suite.T(), postInsights)
+	assert.Equal(suite.T(), http.StatusBadRequest, err.Status())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostInsights() {
+	from := int64(1625011200)
+	filter := dtos.InsightsFilter{From: from, To: from + 2*24*3600, Bucket: dtos.InsightsBucketDay}
+	reactions := []modelReaction.Reaction{
+		{PostID: 803, UserEmail: "follower@mail.com", Kind: modelReaction.ReactionLike, Date: from + 100},
+		{PostID: 803, UserEmail: "stranger@mail.com", Kind: modelReaction.ReactionDislike, Date: from + 24*3600},
+	}
+	comments := []modelComment.Comment{
+		{PostID: 803, UserEmail: "stranger@mail.com", Date: from + 24*3600 + 5},
+	}
+	impressions := []modelImpression.Impression{
+		{PostID: 803, UserEmail: "follower@mail.com", Day: from - 3600},
+	}
+
+	suite.postsRepositoryMock.On("Get", uint(803)).Return(&modelPost.Post{ID: 803, UserEmail: "agent@mail.com"}, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: "agent@mail.com"}).Return([]string{"follower@mail.com"}, nil).Once()
+	suite.reactionsRepositoryMock.On("GetByPosts", []uint{803}, filter.From, filter.To).Return(reactions, nil).Once()
+	suite.commentsRepositoryMock.On("GetByPostsBetween", []uint{803}, filter.From, filter.To).Return(comments, nil).Once()
+	suite.impressionsRepositoryMock.On("GetByPosts", []uint{803}, filter.From, filter.To).Return(impressions, nil).Once()
+
+	postInsights, err := suite.service.GetPostInsights(803, "agent@mail.com", filter)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []dtos.InsightsBucketDTO{
+		{Start: from, InsightsCountsDTO: dtos.InsightsCountsDTO{Likes: 1, Impressions: 1}},
+		{Start: from + 24*3600, InsightsCountsDTO: dtos.InsightsCountsDTO{Dislikes: 1, Comments: 1}},
+	}, postInsights.Buckets)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Likes: 1, Impressions: 1}, postInsights.Audience.Followers)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Dislikes: 1, Comments: 1}, postInsights.Audience.NonFollowers)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetInsightsSummary() {
+	from := int64(1625011200)
+	filter := dtos.InsightsFilter{From: from, To: from + 7*24*3600}
+	posts := []modelPost.Post{
+		{ID: 804, UserEmail: "summary-agent@mail.com", Date: from - 3600},
+		{ID: 805, UserEmail: "summary-agent@mail.com", Date: from + 3600},
+	}
+	reactions := []modelReaction.Reaction{
+		{PostID: 804, UserEmail: "summary-follower@mail.com", Kind: modelReaction.ReactionLike, Date: from + 100},
+		{PostID: 805, UserEmail: "summary-follower@mail.com", Kind: modelReaction.ReactionLike, Date: from + 3700},
+		{PostID: 805, UserEmail: "summary-stranger@mail.com", Kind: modelReaction.ReactionDislike, Date: from + 3800},
+	}
+	comments := []modelComment.Comment{
+		{PostID: 804, UserEmail: "summary-stranger@mail.com", Date: from + 200},
+	}
+
+	suite.postsRepositoryMock.On("GetUsersPosts", "summary-agent@mail.com").Return(posts, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: "summary-agent@mail.com"}).Return([]string{"summary-follower@mail.com"}, nil).Once()
+	suite.reactionsRepositoryMock.On("GetByPosts", []uint{805, 804}, filter.From, filter.To).Return(reactions, nil).Once()
+	suite.commentsRepositoryMock.On("GetByPostsBetween", []uint{805, 804}, filter.From, filter.To).Return(comments, nil).Once()
+	suite.impressionsRepositoryMock.On("GetByPosts", []uint{805, 804}, filter.From, filter.To).Return([]modelImpression.Impression{}, nil).Once()
+
+	summary, err := suite.service.GetInsightsSummary("summary-agent@mail.com", filter)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []dtos.PostInsightsSummaryDTO{
+		{PostID: 805, Date: from + 3600, InsightsCountsDTO: dtos.InsightsCountsDTO{Likes: 1, Dislikes: 1}},
+		{PostID: 804, Date: from - 3600, InsightsCountsDTO: dtos.InsightsCountsDTO{Likes: 1, Comments: 1}},
+	}, summary.Posts)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Likes: 2, Dislikes: 1, Comments: 1}, summary.Total)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Likes: 2}, summary.Audience.Followers)
+	assert.Equal(suite.T(), dtos.InsightsCountsDTO{Dislikes: 1, Comments: 1}, summary.Audience.NonFollowers)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetInsightsSummary_UserRequired() {
+	summary, err := suite.service.GetInsightsSummary("", dtos.InsightsFilter{})
+
+	assert.Nil(suite.T(), summary)
+	assert.Equal(suite.T(), rest_error.NewBadRequestError("User email is required"), err)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateCampaign_InvalidFrequencyCap() {
+	campaignDTO := dtos.CreateCampaignDTO{
+		Post:         dtos.CreatePostDTO{Description: "Opis", Image: sniffableImageBase64("Image"), UserEmail: "agent@mail.com"},
+		StartDate:    time_utils.Now(),
+		EndDate:      time_utils.Now() + secondsInDay,
+		FrequencyCap: 0,
+	}
+	err := rest_error.NewBadRequestError(fmt.Sprintf("Frequency cap must be between 1 and %d", maxFrequencyCap))
+
+	createErr := suite.service.CreateCampaign(&campaignDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateCampaign_InvalidAudience() {
+	campaignDTO := dtos.CreateCampaignDTO{
+		Post:         dtos.CreatePostDTO{Description: "Opis", Image: sniffableImageBase64("Image"), UserEmail: "agent@mail.com"},
+		StartDate:    time_utils.Now(),
+		EndDate:      time_utils.Now() + secondsInDay,
+		Audience:     "everyone",
+		FrequencyCap: 2,
+	}
+	err := rest_error.NewBadRequestError("Invalid campaign audience")
+
+	createErr := suite.service.CreateCampaign(&campaignDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateCampaign() {
+	now := time_utils.Now()
+	campaignDTO := dtos.CreateCampaignDTO{
+		Post:         dtos.CreatePostDTO{Description: "Sale #shoes", Image: sniffableImageBase64("Image"), UserEmail: "agent@mail.com"},
+		StartDate:    now,
+		EndDate:      now + secondsInDay,
+		Audience:     modelCampaign.AudienceNonFollowers,
+		Interests:    []string{"#Shoes", "running"},
+		FrequencyCap: 2,
+	}
+	postEntity := modelPost.Post{
+		Description: campaignDTO.Post.Description,
+		UserEmail:   campaignDTO.Post.UserEmail,
+		Date:        now,
+	}
+	campaignEntity := modelCampaign.Campaign{
+		AgentEmail:   campaignDTO.Post.UserEmail,
+		StartDate:    campaignDTO.StartDate,
+		EndDate:      campaignDTO.EndDate,
+		Audience:     modelCampaign.AudienceNonFollowers,
+		Interests:    "shoes,running",
+		FrequencyCap: 2,
+		Date:         now,
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", campaignDTO.Post.UserEmail).Return(nil, notRestricted(campaignDTO.Post.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: campaignDTO.Post.Image}).Return(new(uint), nil).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity, Campaign: &campaignEntity}).Return(nil).Once()
+	suite.postTermsRepositoryMock.On("CreateMany", []modelPostTerm.PostTerm{
+		{Kind: modelPostTerm.KindHashtag, Term: "#shoes", UserEmail: postEntity.UserEmail, Date: now},
+	}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: campaignDTO.Post.UserEmail}).Return([]string{}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", []modelFeedItem.FeedItem{}).Return(nil).Once()
+
+	createErr := suite.service.CreateCampaign(&campaignDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateCampaign_Scheduled() {
+	campaignDTO := dtos.CreateCampaignDTO{
+		Post:         dtos.CreatePostDTO{Description: "Opis", Image: sniffableImageBase64("Image"), UserEmail: "agent@mail.com", PublishAt: time_utils.Now() + secondsInDay},
+		StartDate:    time_utils.Now(),
+		EndDate:      time_utils.Now() + 2*secondsInDay,
+		FrequencyCap: 2,
+	}
+	err := rest_error.NewBadRequestError("Sponsored post can not be scheduled, campaign start date is used instead")
+
+	createErr := suite.service.CreateCampaign(&campaignDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetCampaigns() {
+	now := time_utils.Now()
+	campaigns := []modelCampaign.Campaign{
+		{ID: 31, PostID: 831, AgentEmail: "campaigns-agent@mail.com", StartDate: now - secondsInDay, EndDate: now + secondsInDay, Audience: modelCampaign.AudienceAll, Interests: "shoes,running", FrequencyCap: 2},
+		{ID: 32, PostID: 832, AgentEmail: "campaigns-agent@mail.com", StartDate: now - 3*secondsInDay, EndDate: now - secondsInDay, Audience: modelCampaign.AudienceFollowers, FrequencyCap: 1},
+	}
+
+	suite.campaignsRepositoryMock.On("GetByAgent", "campaigns-agent@mail.com").Return(campaigns, nil).Once()
+	suite.campaignsRepositoryMock.On("GetDeliveryStats", []uint{31, 32}).Return(map[uint]modelCampaign.DeliveryStats{
+		31: {CampaignID: 31, Deliveries: 7, Reach: 4},
+	}, nil).Once()
+	suite.likesRepositoryMock.On("CountByPosts", []uint{831, 832}).Return(map[uint]int64{831: 3, 832: 1}, nil).Once()
+	suite.commentsRepositoryMock.On("CountByPosts", []uint{831, 832}).Return(map[uint]int64{832: 2}, nil).Once()
+	suite.impressionsRepositoryMock.On("CountByPosts", []uint{831, 832}).Return(map[uint]int64{831: 9}, nil).Once()
+
+	campaignDTOs, err := suite.service.GetCampaigns("campaigns-agent@mail.com")
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []dtos.CampaignDTO{
+		{ID: 31, PostID: 831, StartDate: now - secondsInDay, EndDate: now + secondsInDay, Audience: modelCampaign.AudienceAll, Interests: []string{"shoes", "running"}, FrequencyCap: 2, Active: true, Deliveries: 7, Reach: 4, Likes: 3, Impressions: 9},
+		{ID: 32, PostID: 832, StartDate: now - 3*secondsInDay, EndDate: now - secondsInDay, Audience: modelCampaign.AudienceFollowers, Interests: []string{}, FrequencyCap: 1, Likes: 1, Comments: 2},
+	}, campaignDTOs)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_InjectSponsoredPosts_SkipsBlockedAndPrivateAgents() {
+	user := "sponsored-viewer@mail.com"
+	campaigns := []modelCampaign.Campaign{
+		{ID: 41, PostID: 841, AgentEmail: "sponsored-blocked@mail.com", Audience: modelCampaign.AudienceAll, FrequencyCap: 1},
+		{ID: 42, PostID: 842, AgentEmail: "sponsored-private@mail.com", Audience: modelCampaign.AudienceAll, FrequencyCap: 1},
+		{ID: 43, PostID: 843, AgentEmail: "sponsored-agent@mail.com", Audience: modelCampaign.AudienceAll, FrequencyCap: 1},
+	}
+	sponsoredPost := modelPost.Post{ID: 843, UserEmail: "sponsored-agent@mail.com", MediaID: 8430}
+	feedPosts := []dtos.PostDTO{{ID: 1}}
+
+	suite.campaignsRepositoryMock.On("GetActive", mock.AnythingOfType("int64")).Return(campaigns, nil).Once()
+	for _, campaignEntity := range campaigns {
+		suite.campaignsRepositoryMock.On("CountDeliveries", campaignEntity.ID, user, mock.AnythingOfType("int64")).Return(int64(0), nil).Once()
+	}
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: user, BlockedUser: "sponsored-blocked@mail.com"}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: user, BlockedUser: "sponsored-private@mail.com"}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "sponsored-private@mail.com", BlockedUser: user}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: "sponsored-private@mail.com"}).Return(true, nil).Once()
+	suite.allowProfile(sponsoredPost.UserEmail, user)
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", []uint{843}).Return([]modelPost.Post{sponsoredPost}, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{843}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", sponsoredPost.UserEmail).Return(nil, notRestricted(sponsoredPost.UserEmail)).Once()
+	suite.expectVisiblePost(sponsoredPost, user)
+	suite.campaignsRepositoryMock.On("RecordDelivery", uint(43), user, mock.AnythingOfType("int64")).Return(nil).Once()
+
+	audiences := newAudienceCache()
+	audiences.following = map[string]bool{}
+	audiences.muted = map[string]bool{}
+	posts, err := suite.service.(*postsService).injectSponsoredPosts(user, audiences, feedPosts, dtos.SensitiveContentBlur)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []uint{1, 843}, postIDs(posts))
+	assert.True(suite.T(), posts[1].Sponsored)
+	suite.campaignsRepositoryMock.AssertNotCalled(suite.T(), "RecordDelivery", uint(41), user, mock.AnythingOfType("int64"))
+	suite.campaignsRepositoryMock.AssertNotCalled(suite.T(), "RecordDelivery", uint(42), user, mock.AnythingOfType("int64"))
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_InsertSponsoredPosts() {
+	feedPosts := []dtos.PostDTO{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}}
+	sponsored := []dtos.PostDTO{{ID: 10, Sponsored: true}, {ID: 11, Sponsored: true}}
+
+	result := insertSponsoredPosts(feedPosts, sponsored)
+
+	ids := make([]uint, 0, len(result))
+	for _, postDTO := range result {
+		ids = append(ids, postDTO.ID)
+	}
+	assert.Equal(suite.T(), []uint{1, 2, 3, 4, 10, 5, 6, 11}, ids)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_Scheduled() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "mail@mail.com",
+		PublishAt:   time_utils.Now() + secondsInDay,
+	}
+	scheduledPost := modelScheduledPost.ScheduledPost{
+		Description: postDTO.Description,
+		UserEmail:   postDTO.UserEmail,
+		PublishAt:   postDTO.PublishAt,
+		Date:        time_utils.Now(),
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: postDTO.Image}).Return(new(uint), nil).Once()
+	suite.scheduledPostsRepositoryMock.On("Create", &scheduledPost).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_ScheduledTooFarAhead() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "mail@mail.com",
+		PublishAt:   time_utils.Now() + maxScheduleAhead + secondsInDay,
+	}
+	err := rest_error.NewBadRequestError(fmt.Sprintf("Publish time must be in the next %d days", maxScheduleAhead/secondsInDay))
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ReschedulePost_NotAuthor() {
+	var id uint = 31
+	rescheduleRequest := dtos.ReschedulePostDTO{
+		UserEmail: "other@mail.com",
+		PublishAt: time_utils.Now() + secondsInDay,
+	}
+	scheduledPost := modelScheduledPost.ScheduledPost{ID: id, UserEmail: "mail@mail.com"}
+	err := rest_error.NewRestError("Only author can change a scheduled post", http.StatusForbidden, "forbidden", nil)
+
+	suite.scheduledPostsRepositoryMock.On("Get", id).Return(&scheduledPost, nil).Once()
+
+	rescheduleErr := suite.service.ReschedulePost(id, &rescheduleRequest)
+
+	assert.Equal(suite.T(), err, rescheduleErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ReschedulePost() {
+	var id uint = 32
+	rescheduleRequest := dtos.ReschedulePostDTO{
+		UserEmail: "mail@mail.com",
+		PublishAt: time_utils.Now() + secondsInDay,
+	}
+	scheduledPost := modelScheduledPost.ScheduledPost{ID: id, UserEmail: "mail@mail.com"}
+
+	suite.scheduledPostsRepositoryMock.On("Get", id).Return(&scheduledPost, nil).Once()
+	suite.scheduledPostsRepositoryMock.On("Reschedule", id, rescheduleRequest.PublishAt).Return(nil).Once()
+
+	rescheduleErr := suite.service.ReschedulePost(id, &rescheduleRequest)
+
+	assert.Equal(suite.T(), nil, rescheduleErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CancelScheduledPost() {
+	var id uint = 33
+	scheduledPost := modelScheduledPost.ScheduledPost{ID: id, UserEmail: "mail@mail.com"}
+
+	suite.scheduledPostsRepositoryMock.On("Get", id).Return(&scheduledPost, nil).Once()
+	suite.scheduledPostsRepositoryMock.On("Delete", &scheduledPost).Return(nil).Once()
+
+	cancelErr := suite.service.CancelScheduledPost(id, "mail@mail.com")
+
+	assert.Equal(suite.T(), nil, cancelErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetScheduledPosts() {
+	scheduledPosts := []modelScheduledPost.ScheduledPost{
+		{ID: 35, Description: "Opis", UserEmail: "scheduled-list@mail.com", MediaID: 350, PublishAt: 1000, Date: 500},
+		{ID: 36, Description: "Karusel", UserEmail: "scheduled-list@mail.com", MediaID: 360, Media: []modelScheduledPost.ScheduledPostMedia{{MediaID: 360, Position: 0}, {MediaID: 361, Position: 1}}, Audience: modelPostSetting.AudienceFollowers, PublishAt: 2000, Date: 600},
+	}
+
+	suite.scheduledPostsRepositoryMock.On("GetByUser", "scheduled-list@mail.com").Return(scheduledPosts, nil).Once()
+	for _, mediaID := range []uint64{350, 360, 361} {
+		suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: mediaID}).Return(fmt.Sprintf("image%d", mediaID), nil).Once()
+	}
+
+	scheduledPostDTOs, err := suite.service.GetScheduledPosts("scheduled-list@mail.com")
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []dtos.ScheduledPostDTO{
+		{ID: 35, Description: "Opis", Image: "image350", Media: []string{"image350"}, MediaType: modelPostSetting.MediaTypeImage, Audience: modelPostSetting.AudiencePublic, CommentPolicy: modelPostSetting.CommentPolicyEveryone, PublishAt: 1000, Date: 500},
+		{ID: 36, Description: "Karusel", Image: "image360", Media: []string{"image360", "image361"}, MediaType: modelPostSetting.MediaTypeImage, Audience: modelPostSetting.AudienceFollowers, CommentPolicy: modelPostSetting.CommentPolicyEveryone, PublishAt: 2000, Date: 600},
+	}, scheduledPostDTOs)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PublishScheduledPosts() {
+	image := gradientImageBase64()
+	hash, _ := image_hash.Compute(image)
+	due := []modelScheduledPost.ScheduledPost{
+		{ID: 34, UserEmail: "scheduler@mail.com", MediaID: 340},
+		{ID: 37, UserEmail: "scheduler@mail.com", MediaID: 370},
+		{ID: 38, UserEmail: "scheduler@mail.com", MediaID: 380},
+	}
+	published := []modelPost.Post{
+		{ID: 34, UserEmail: "scheduler@mail.com", Date: time_utils.Now()},
+		{ID: 37, UserEmail: "scheduler@mail.com", MarkedAsInappropriate: true, Date: time_utils.Now()},
+	}
+	feedItems := func(postEntity modelPost.Post) []modelFeedItem.FeedItem {
+		return []modelFeedItem.FeedItem{
+			{
+				UserEmail:   "follower@mail.com",
+				PostID:      postEntity.ID,
+				AuthorEmail: postEntity.UserEmail,
+				Date:        postEntity.Date,
+			},
+		}
+	}
+
+	suite.scheduledPostsRepositoryMock.On("GetDue", mock.AnythingOfType("int64"), scheduledPublishBatchSize).Return(due, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 340}).Return(sniffableImageBase64("Image"), nil).Once()
+	// Media of post 37 was banned after the post had been scheduled
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 370}).Return(image, nil).Once()
+	suite.bannedMediaRepositoryMock.On("GetByBands", image_hash.Bands(hash, bannedMediaBands)).Return([]modelBannedMedia.BannedMedia{{ID: 2, Hash: hash}}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 380}).Return("", errors.New("unavailable")).Once()
+	suite.scheduledPostsRepositoryMock.On("Postpone", uint(38), mock.AnythingOfType("int64")).Return(nil).Once()
+	suite.scheduledPostsRepositoryMock.On("PublishDue", mock.AnythingOfType("int64"), map[uint]bool{34: false, 37: true}).Return(published, nil).Once()
+	for _, postEntity := range published {
+		suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: "scheduler@mail.com"}).Return([]string{"follower@mail.com"}, nil).Once()
+		suite.feedItemsRepositoryMock.On("CreateMany", feedItems(postEntity)).Return(nil).Once()
+	}
+
+	publishErr := suite.service.PublishScheduledPosts()
+
+	assert.Equal(suite.T(), nil, publishErr)
+	suite.scheduledPostsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PublishScheduledPosts_FailingHeadBatch() {
+	failing := make([]modelScheduledPost.ScheduledPost, scheduledPublishBatchSize)
+	for i := range failing {
+		failing[i] = modelScheduledPost.ScheduledPost{ID: uint(3900 + i), UserEmail: "failingscheduler@mail.com", MediaID: uint(39000 + i), FailedAttempts: 1}
+	}
+	due := []modelScheduledPost.ScheduledPost{{ID: 4000, UserEmail: "failingscheduler@mail.com", MediaID: 40000}}
+	published := []modelPost.Post{{ID: 4000, UserEmail: "failingscheduler@mail.com", Date: time_utils.Now()}}
+
+	suite.scheduledPostsRepositoryMock.On("GetDue", mock.AnythingOfType("int64"), scheduledPublishBatchSize).Return(failing, nil).Once()
+	for _, scheduledPost := range failing {
+		suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: uint64(scheduledPost.MediaID)}).Return("", errors.New("unavailable")).Once()
+		suite.scheduledPostsRepositoryMock.On("Postpone", scheduledPost.ID, mock.MatchedBy(func(retryAt int64) bool {
+			return retryAt >= time_utils.Now()+2*scheduledRetryDelay-1
+		})).Return(nil).Once()
+	}
+	suite.scheduledPostsRepositoryMock.On("PublishDue", mock.AnythingOfType("int64"), map[uint]bool{}).Return([]modelPost.Post{}, nil).Once()
+	suite.scheduledPostsRepositoryMock.On("GetDue", mock.AnythingOfType("int64"), scheduledPublishBatchSize).Return(due, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 40000}).Return(sniffableImageBase64("Image"), nil).Once()
+	suite.scheduledPostsRepositoryMock.On("PublishDue", mock.AnythingOfType("int64"), map[uint]bool{4000: false}).Return(published, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: "failingscheduler@mail.com"}).Return([]string{}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", []modelFeedItem.FeedItem{}).Return(nil).Once()
+
+	publishErr := suite.service.PublishScheduledPosts()
+
+	assert.Equal(suite.T(), nil, publishErr)
+	suite.scheduledPostsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateDraft() {
+	draftDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "mail@mail.com",
+	}
+	draftEntity := modelDraft.Draft{
+		Description: draftDTO.Description,
+		UserEmail:   draftDTO.UserEmail,
+		Date:        time_utils.Now(),
+		Updated:     time_utils.Now(),
+	}
+	expected := dtos.DraftDTO{
+		Description:   draftDTO.Description,
+		Image:         draftDTO.Image,
+		MediaType:     modelPostSetting.MediaTypeImage,
+		Audience:      modelPostSetting.AudiencePublic,
+		CommentPolicy: modelPostSetting.CommentPolicyEveryone,
+		Date:          draftEntity.Date,
+		Updated:       draftEntity.Updated,
+	}
+
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: draftDTO.Image}).Return(new(uint), nil).Once()
+	suite.draftsRepositoryMock.On("Create", &draftEntity).Return(nil).Once()
+
+	draft, createErr := suite.service.CreateDraft(&draftDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+	assert.Equal(suite.T(), &expected, draft)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UpdateDraft_NotAuthor() {
+	var id uint = 41
+	draftDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		UserEmail:   "other@mail.com",
+	}
+	draftEntity := modelDraft.Draft{ID: id, UserEmail: "mail@mail.com"}
+	err := rest_error.NewRestError("Only author can access a draft", http.StatusForbidden, "forbidden", nil)
+
+	suite.draftsRepositoryMock.On("Get", id).Return(&draftEntity, nil).Once()
+
+	draft, updateErr := suite.service.UpdateDraft(id, &draftDTO)
+
+	assert.Nil(suite.T(), draft)
+	assert.Equal(suite.T(), err, updateErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UpdateDraft_ReplacesImage() {
+	var id uint = 44
+	var savedID uint = 45
+	draftDTO := dtos.CreatePostDTO{
+		Description: "Novi opis",
+		Image:       sniffableImageBase64("Replaced"),
+		UserEmail:   "replacer@mail.com",
+	}
+	draftEntity := modelDraft.Draft{ID: id, UserEmail: draftDTO.UserEmail, MediaID: 46}
+
+	suite.draftsRepositoryMock.On("Get", id).Return(&draftEntity, nil).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: draftDTO.Image}).Return(&savedID, nil).Once()
+	suite.draftsRepositoryMock.On("Update", &draftEntity).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 46}).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: uint64(savedID)}).Return(draftDTO.Image, nil).Once()
+
+	draft, updateErr := suite.service.UpdateDraft(id, &draftDTO)
+
+	assert.Equal(suite.T(), nil, updateErr)
+	assert.Equal(suite.T(), draftDTO.Image, draft.Image)
+	assert.Equal(suite.T(), savedID, draftEntity.MediaID)
+	suite.mediaGrpcClientMock.AssertCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: 46})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetDrafts() {
+	userEmail := "drafts@mail.com"
+	drafts := []modelDraft.Draft{
+		{ID: 47, Description: "Bez slike", UserEmail: userEmail},
+		{ID: 48, Description: "Sa slikom", UserEmail: userEmail, MediaID: 49, MediaType: modelPostSetting.MediaTypeVideo},
+	}
+	image := sniffableImageBase64("Drafted")
+
+	suite.draftsRepositoryMock.On("GetByUser", userEmail).Return(drafts, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 49}).Return(image, nil).Once()
+
+	draftDTOs, getErr := suite.service.GetDrafts(userEmail)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []dtos.DraftDTO{
+		{
+			ID:            47,
+			Description:   "Bez slike",
+			MediaType:     modelPostSetting.MediaTypeImage,
+			Audience:      modelPostSetting.AudiencePublic,
+			CommentPolicy: modelPostSetting.CommentPolicyEveryone,
+		},
+		{
+			ID:            48,
+			Description:   "Sa slikom",
+			Image:         image,
+			MediaType:     modelPostSetting.MediaTypeVideo,
+			Audience:      modelPostSetting.AudiencePublic,
+			CommentPolicy: modelPostSetting.CommentPolicyEveryone,
+		},
+	}, draftDTOs)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PublishDraft_NoImage() {
+	var id uint = 42
+	draftEntity := modelDraft.Draft{ID: id, UserEmail: "mail@mail.com"}
+	err := rest_error.NewBadRequestError("Draft has no image")
+
+	suite.draftsRepositoryMock.On("Get", id).Return(&draftEntity, nil).Once()
+
+	publishErr := suite.service.PublishDraft(id, "mail@mail.com")
+
+	assert.Equal(suite.T(), err, publishErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PublishDraft() {
+	var id uint = 43
+	draftEntity := modelDraft.Draft{
+		ID:          id,
+		Description: "Opis",
+		UserEmail:   "drafter@mail.com",
+		MediaID:     7,
+	}
+	postEntity := modelPost.Post{
+		Description: draftEntity.Description,
+		UserEmail:   draftEntity.UserEmail,
+		Date:        time_utils.Now(),
+		MediaID:     draftEntity.MediaID,
+	}
+
+	suite.draftsRepositoryMock.On("Get", id).Return(&draftEntity, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 7}).Return(sniffableImageBase64("Image"), nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", draftEntity.UserEmail).Return(nil, notRestricted(draftEntity.UserEmail)).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity, Draft: &draftEntity}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: draftEntity.UserEmail}).Return([]string{}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", []modelFeedItem.FeedItem{}).Return(nil).Once()
+
+	publishErr := suite.service.PublishDraft(id, draftEntity.UserEmail)
+
+	assert.Equal(suite.T(), nil, publishErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_Carousel() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Carousel",
+		Images:      []string{sniffableImageBase64("First"), sniffableImageBase64("Second")},
+		UserEmail:   "carousel@mail.com",
+	}
+	firstID, secondID := uint(51), uint(52)
+	postEntity := modelPost.Post{
+		Description: postDTO.Description,
+		UserEmail:   postDTO.UserEmail,
+		Date:        time_utils.Now(),
+		MediaID:     firstID,
+	}
+	media := []modelPostMedia.PostMedia{
+		{MediaID: firstID, Position: 0},
+		{MediaID: secondID, Position: 1},
+	}
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("First")}).Return(&firstID, nil).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("Second")}).Return(&secondID, nil).Once()
+	suite.postsRepositoryMock.On("Publish", &post.Publication{Post: &postEntity, Media: media}).Return(nil).Once()
+	suite.userGrpcClientMock.On("GetFollowers", dtos.GetFollowersRequest{UserEmail: postDTO.UserEmail}).Return([]string{}, nil).Once()
+	suite.feedItemsRepositoryMock.On("CreateMany", []modelFeedItem.FeedItem{}).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_CarouselRollback() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Carousel",
+		Images:      []string{sniffableImageBase64("Saved"), sniffableImageBase64("Failing")},
+		UserEmail:   "rollback@mail.com",
+	}
+	savedID := uint(53)
+	grpcErr := errors.New("media service unavailable")
+	err := rest_error.NewInternalServerError("user grpc client error when saving media", grpcErr)
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("Saved")}).Return(&savedID, nil).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("Failing")}).Return(nil, grpcErr).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: uint64(savedID)}).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+	suite.mediaGrpcClientMock.AssertCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: uint64(savedID)})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_PublishRollback() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Carousel",
+		Images:      []string{sniffableImageBase64("Stored"), sniffableImageBase64("Unpublished")},
+		UserEmail:   "publish-rollback@mail.com",
+		Audience:    modelPostSetting.AudienceFollowers,
+	}
+	firstID, secondID := uint(54), uint(55)
+	postEntity := modelPost.Post{
+		Description: postDTO.Description,
+		UserEmail:   postDTO.UserEmail,
+		Date:        time_utils.Now(),
+		MediaID:     firstID,
+	}
+	publication := post.Publication{
+		Post: &postEntity,
+		Media: []modelPostMedia.PostMedia{
+			{MediaID: firstID, Position: 0},
+			{MediaID: secondID, Position: 1},
+		},
+		Setting: &modelPostSetting.PostSetting{Audience: modelPostSetting.AudienceFollowers},
+	}
+	err := rest_error.NewInternalServerError("Error when trying to publish post", errors.New("deadlock"))
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("Stored")}).Return(&firstID, nil).Once()
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("Unpublished")}).Return(&secondID, nil).Once()
+	suite.postsRepositoryMock.On("Publish", &publication).Return(err).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: uint64(firstID)}).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: uint64(secondID)}).Return(nil).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+	suite.mediaGrpcClientMock.AssertCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: uint64(firstID)})
+	suite.mediaGrpcClientMock.AssertCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: uint64(secondID)})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_EmptyImage() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Carousel",
+		Images:      []string{sniffableImageBase64("First"), ""},
+		UserEmail:   "empty-image@mail.com",
+	}
+	err := rest_error.NewBadRequestError("Post images must not be empty")
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_TooManyImages() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Carousel",
+		Images:      make([]string, maxPostMedia+1),
+		UserEmail:   "mail@mail.com",
+	}
+	err := rest_error.NewBadRequestError(fmt.Sprintf("Post must have between 1 and %d images", maxPostMedia))
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_DeclaredVideoIsImage() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       sniffableImageBase64("Image"),
+		MediaType:   modelPostSetting.MediaTypeVideo,
+		UserEmail:   "video@mail.com",
+	}
+	err := rest_error.NewBadRequestError("Media declared as video is image")
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_UnsupportedMedia() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       base64.StdEncoding.EncodeToString([]byte("plain text")),
+		UserEmail:   "text@mail.com",
+	}
+	err := rest_error.NewBadRequestError("Invalid media: unsupported media type text/plain; charset=utf-8")
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_TooManyPixels() {
+	var buffer bytes.Buffer
+	_ = png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 4, 4)))
+	data := buffer.Bytes()
+	// Header declares a 100000x100000 image, decoding it would take 10 GB
+	binary.BigEndian.PutUint32(data[16:20], 100000)
+	binary.BigEndian.PutUint32(data[20:24], 100000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	postDTO := dtos.CreatePostDTO{
+		Description: "Opis",
+		Image:       base64.StdEncoding.EncodeToString(data),
+		UserEmail:   "bomb@mail.com",
+	}
+	err := rest_error.NewBadRequestError("Invalid media: image is larger than 50 megapixels")
+
+	suite.restrictionsRepositoryMock.On("GetByUser", postDTO.UserEmail).Return(nil, notRestricted(postDTO.UserEmail)).Once()
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateStory() {
+	storyDTO := dtos.CreateStoryDTO{
+		Image:     "data:image/png;base64," + sniffableImageBase64("Story"),
+		UserEmail: "story@mail.com",
+	}
+	now := time_utils.Now()
+	storyEntity := modelStory.Story{
+		UserEmail: storyDTO.UserEmail,
+		MediaType: modelPostSetting.MediaTypeImage,
+		Date:      now,
+		ExpiresAt: now + storyLifetime,
+	}
+
+	suite.mediaGrpcClientMock.On("SaveMedia", dtos.SaveMediaRequest{Image: sniffableImageBase64("Story")}).Return(new(uint), nil).Once()
+	suite.storiesRepositoryMock.On("Create", &storyEntity).Return(nil).Once()
+
+	createErr := suite.service.CreateStory(&storyDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateStory_Flagged() {
+	storyDTO := dtos.CreateStoryDTO{
+		Image:     gradientImageBase64(),
+		UserEmail: "flagged-story@mail.com",
+	}
+	hash, _ := image_hash.Compute(storyDTO.Image)
+	err := rest_error.NewBadRequestError("Image matches previously removed content")
+
+	suite.bannedMediaRepositoryMock.On("GetByBands", image_hash.Bands(hash, bannedMediaBands)).Return([]modelBannedMedia.BannedMedia{{ID: 3, Hash: hash ^ 0xFF}}, nil).Once()
+
+	createErr := suite.service.CreateStory(&storyDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetStoriesTray() {
+	viewer := "tray@mail.com"
+	now := time_utils.Now()
+	stories := []modelStory.Story{
+		{ID: 61, UserEmail: "seen@mail.com", MediaID: 1, Date: now - 300, ExpiresAt: now + 100},
+		{ID: 62, UserEmail: viewer, MediaID: 2, Date: now - 200, ExpiresAt: now + 200},
+		{ID: 63, UserEmail: "unseen@mail.com", MediaID: 3, Date: now - 100, ExpiresAt: now + 300},
+		{ID: 64, UserEmail: "seen@mail.com", MediaID: 4, Date: now - 50, ExpiresAt: now + 350},
+		{ID: 69, UserEmail: "blocker@mail.com", MediaID: 5, Date: now - 25, ExpiresAt: now + 375},
+	}
+	authors := []string{viewer, "seen@mail.com", "unseen@mail.com"}
+
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{"seen@mail.com", "unseen@mail.com", "blocker@mail.com"}, nil).Once()
+	suite.storiesRepositoryMock.On("GetActiveByUsers", []string{viewer, "seen@mail.com", "unseen@mail.com", "blocker@mail.com"}, mock.AnythingOfType("int64")).Return(stories, nil).Once()
+	for _, author := range []string{"seen@mail.com", "unseen@mail.com", "blocker@mail.com"} {
+		suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+		suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: author}).Return(false, nil).Once()
+	}
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "seen@mail.com", BlockedUser: viewer}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "unseen@mail.com", BlockedUser: viewer}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "blocker@mail.com", BlockedUser: viewer}).Return(true, nil).Once()
+	suite.storiesRepositoryMock.On("GetViewedStories", viewer, []uint{61, 62, 63, 64}).Return(map[uint]bool{61: true, 64: true}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMediaBatch", dtos.GetMediaBatchRequest{IDs: []uint64{1, 2, 3, 4}}).Return(map[uint64]string{1: "image0", 2: "image1", 3: "image2", 4: "image3"}, nil).Once()
+	usernames := make(map[string]string)
+	for _, author := range authors {
+		usernames[author] = strings.Split(author, "@")[0]
+	}
+	suite.userGrpcClientMock.On("GetUsernames", dtos.GetUsernamesRequest{Emails: []string{"seen@mail.com", viewer, "unseen@mail.com"}}).Return(usernames, nil).Once()
+
+	tray, getErr := suite.service.GetStoriesTray(viewer)
+
+	assert.Equal(suite.T(), nil, getErr)
+	trayUsernames := make([]string, 0, len(tray))
+	for _, item := range tray {
+		trayUsernames = append(trayUsernames, item.Username)
+	}
+	assert.Equal(suite.T(), []string{"tray", "unseen", "seen"}, trayUsernames)
+	assert.Equal(suite.T(), 2, len(tray[2].Stories))
+	assert.Equal(suite.T(), "image3", tray[2].Stories[1].Image)
+	assert.True(suite.T(), tray[2].Seen)
+	assert.False(suite.T(), tray[1].Seen)
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "GetMedia", dtos.GetMediaRequest{ID: 5})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetStoryViewers_NotAuthor() {
+	var id uint = 65
+	storyEntity := modelStory.Story{ID: id, UserEmail: "author@mail.com", ExpiresAt: time_utils.Now() + storyLifetime}
+	err := rest_error.NewRestError("Only author can see story viewers", http.StatusForbidden, "forbidden", nil)
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+
+	viewers, getErr := suite.service.GetStoryViewers(id, "other@mail.com")
+
+	assert.Nil(suite.T(), viewers)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetStoryViewers() {
+	var id uint = 1115
+	author := "viewed-author@mail.com"
+	storyEntity := modelStory.Story{ID: id, UserEmail: author, ExpiresAt: time_utils.Now() + storyLifetime}
+	views := []modelStory.StoryView{
+		{StoryID: id, UserEmail: "first-viewer@mail.com", Date: 300},
+		{StoryID: id, UserEmail: "second-viewer@mail.com", Date: 200},
+		{StoryID: id, UserEmail: "third-viewer@mail.com", Date: 100},
+	}
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+	suite.storiesRepositoryMock.On("GetViews", id).Return(views, nil).Once()
+	suite.userGrpcClientMock.On("GetUsernames", dtos.GetUsernamesRequest{Emails: []string{"first-viewer@mail.com", "second-viewer@mail.com", "third-viewer@mail.com"}}).Return(map[string]string{
+		"first-viewer@mail.com":  "first",
+		"second-viewer@mail.com": "second",
+		"third-viewer@mail.com":  "third",
+	}, nil).Once()
+
+	viewers, getErr := suite.service.GetStoryViewers(id, author)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []dtos.StoryViewerDTO{
+		{Username: "first", Date: 300},
+		{Username: "second", Date: 200},
+		{Username: "third", Date: 100},
+	}, viewers)
+	suite.userGrpcClientMock.AssertNotCalled(suite.T(), "GetUsername", dtos.GetUsernameRequest{Email: "first-viewer@mail.com"})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ViewStory_Expired() {
+	var id uint = 66
+	storyEntity := modelStory.Story{ID: id, UserEmail: "author@mail.com", ExpiresAt: time_utils.Now() - 1}
+	err := rest_error.NewNotFoundError(fmt.Sprintf("Story with id %d has expired", id))
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+
+	viewErr := suite.service.ViewStory(id, "viewer@mail.com")
+
+	assert.Equal(suite.T(), err, viewErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SweepExpiredStories() {
+	expired := []modelStory.Story{{ID: 67, MediaID: 670}, {ID: 70, MediaID: 700}}
+
+	suite.storiesRepositoryMock.On("GetExpired", mock.AnythingOfType("int64")).Return(expired, nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 670}).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 700}).Return(errors.New("unavailable")).Once()
+	suite.storiesRepositoryMock.On("DeleteExpired", []uint{67}).Return(nil).Once()
+
+	sweepErr := suite.service.SweepExpiredStories()
+
+	assert.Equal(suite.T(), nil, sweepErr)
+	suite.storiesRepositoryMock.AssertCalled(suite.T(), "DeleteExpired", []uint{67})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ViewStory_Blocked() {
+	var id uint = 71
+	storyEntity := modelStory.Story{ID: id, UserEmail: "story-blocker@mail.com", ExpiresAt: time_utils.Now() + storyLifetime}
+	err := rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "story-viewer@mail.com", BlockedUser: storyEntity.UserEmail}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: storyEntity.UserEmail, BlockedUser: "story-viewer@mail.com"}).Return(true, nil).Once()
+
+	viewErr := suite.service.ViewStory(id, "story-viewer@mail.com")
+
+	assert.Equal(suite.T(), err, viewErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ViewStory() {
+	var id uint = 72
+	storyEntity := modelStory.Story{ID: id, UserEmail: "story-author@mail.com", ExpiresAt: time_utils.Now() + storyLifetime}
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+	suite.allowProfile(storyEntity.UserEmail, "story-fan@mail.com")
+	suite.storiesRepositoryMock.On("RecordView", mock.MatchedBy(func(view *modelStory.StoryView) bool {
+		return view.StoryID == id && view.UserEmail == "story-fan@mail.com"
+	})).Return(nil).Once()
+
+	viewErr := suite.service.ViewStory(id, "story-fan@mail.com")
+
+	assert.Equal(suite.T(), nil, viewErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ArchiveStory_NotAuthor() {
+	var id uint = 68
+	storyEntity := modelStory.Story{ID: id, UserEmail: "author@mail.com"}
+	err := rest_error.NewRestError("Only author can archive a story", http.StatusForbidden, "forbidden", nil)
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+
+	archiveErr := suite.service.ArchiveStory(id, "other@mail.com")
+
+	assert.Equal(suite.T(), err, archiveErr)
+	suite.storiesRepositoryMock.AssertNotCalled(suite.T(), "Archive", id)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ArchiveStory() {
+	var id uint = 69
+	storyEntity := modelStory.Story{ID: id, UserEmail: "author@mail.com", ExpiresAt: time_utils.Now() - 1}
+
+	suite.storiesRepositoryMock.On("Get", id).Return(&storyEntity, nil).Once()
+	suite.storiesRepositoryMock.On("Archive", id).Return(nil).Once()
+
+	archiveErr := suite.service.ArchiveStory(id, "author@mail.com")
+
+	assert.Equal(suite.T(), nil, archiveErr)
+	suite.storiesRepositoryMock.AssertCalled(suite.T(), "Archive", id)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateHighlight_InvalidName() {
+	highlightDTO := dtos.HighlightRequestDTO{UserEmail: "author@mail.com", Name: "   ", StoryIDs: []uint{70}}
+	err := rest_error.NewBadRequestError(fmt.Sprintf("Highlight name must have between 1 and %d characters", maxHighlightNameLength))
+
+	highlight, createErr := suite.service.CreateHighlight(&highlightDTO)
+
+	assert.Nil(suite.T(), highlight)
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateHighlight_DuplicateStory() {
+	highlightDTO := dtos.HighlightRequestDTO{UserEmail: "author@mail.com", Name: "Summer", StoryIDs: []uint{71, 71}}
+	err := rest_error.NewBadRequestError("Story with id 71 is added more than once")
+
+	highlight, createErr := suite.service.CreateHighlight(&highlightDTO)
+
+	assert.Nil(suite.T(), highlight)
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateHighlight_ForeignStory() {
+	storyIDs := []uint{72, 73}
+	highlightDTO := dtos.HighlightRequestDTO{UserEmail: "author@mail.com", Name: "Summer", StoryIDs: storyIDs}
+	stories := []modelStory.Story{{ID: 72, UserEmail: "author@mail.com"}, {ID: 73, UserEmail: "other@mail.com"}}
+	err := rest_error.NewRestError("Only author can add a story to a highlight", http.StatusForbidden, "forbidden", nil)
+
+	suite.storiesRepositoryMock.On("GetByIDs", storyIDs).Return(stories, nil).Once()
+
+	highlight, createErr := suite.service.CreateHighlight(&highlightDTO)
+
+	assert.Nil(suite.T(), highlight)
+	assert.Equal(suite.T(), err, createErr)
+	suite.highlightsRepositoryMock.AssertNotCalled(suite.T(), "Create", mock.Anything, storyIDs)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreateHighlight() {
+	storyIDs := []uint{75, 74}
+	highlightDTO := dtos.HighlightRequestDTO{UserEmail: "author@mail.com", Name: " Summer ", StoryIDs: storyIDs}
+	stories := []modelStory.Story{{ID: 74, UserEmail: "author@mail.com", MediaID: 740}, {ID: 75, UserEmail: "author@mail.com", MediaID: 750}}
+	items := []modelHighlight.HighlightStory{{HighlightID: 0, StoryID: 75, Position: 0}, {HighlightID: 0, StoryID: 74, Position: 1}}
+
+	suite.storiesRepositoryMock.On("GetByIDs", storyIDs).Return(stories, nil).Twice()
+	suite.highlightsRepositoryMock.On("Create", mock.AnythingOfType("*highlight.Highlight"), storyIDs).Return(nil).Once()
+	suite.highlightsRepositoryMock.On("GetStories", []uint{0}).Return(items, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 740}).Return("image74", nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 750}).Return("image75", nil).Once()
+
+	highlight, createErr := suite.service.CreateHighlight(&highlightDTO)
+
+	assert.Equal(suite.T(), nil, createErr)
+	assert.Equal(suite.T(), "Summer", highlight.Name)
+	assert.Equal(suite.T(), 2, len(highlight.Stories))
+	assert.Equal(suite.T(), "image75", highlight.Stories[0].Image)
+	assert.Equal(suite.T(), "image74", highlight.Stories[1].Image)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UpdateHighlight_NotAuthor() {
+	var id uint = 77
+	highlightDTO := dtos.HighlightRequestDTO{UserEmail: "other@mail.com", Name: "Winter", StoryIDs: []uint{78}}
+	highlightEntity := modelHighlight.Highlight{ID: id, UserEmail: "author@mail.com", Name: "Summer"}
+	err := rest_error.NewRestError("Only author can change a highlight", http.StatusForbidden, "forbidden", nil)
+
+	suite.highlightsRepositoryMock.On("Get", id).Return(&highlightEntity, nil).Once()
+
+	highlight, updateErr := suite.service.UpdateHighlight(id, &highlightDTO)
+
+	assert.Nil(suite.T(), highlight)
+	assert.Equal(suite.T(), err, updateErr)
+	suite.highlightsRepositoryMock.AssertNotCalled(suite.T(), "Update", &highlightEntity, highlightDTO.StoryIDs)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UpdateHighlight() {
+	var id uint = 79
+	storyIDs := []uint{81, 80}
+	highlightDTO := dtos.HighlightRequestDTO{UserEmail: "highlighter@mail.com", Name: " Winter ", StoryIDs: storyIDs}
+	highlightEntity := modelHighlight.Highlight{ID: id, UserEmail: "highlighter@mail.com", Name: "Summer", Date: 100}
+	stories := []modelStory.Story{{ID: 80, UserEmail: "highlighter@mail.com", MediaID: 800}, {ID: 81, UserEmail: "highlighter@mail.com", MediaID: 810}}
+	items := []modelHighlight.HighlightStory{{HighlightID: id, StoryID: 81, Position: 0}, {HighlightID: id, StoryID: 80, Position: 1}}
+
+	suite.highlightsRepositoryMock.On("Get", id).Return(&highlightEntity, nil).Once()
+	suite.storiesRepositoryMock.On("GetByIDs", storyIDs).Return(stories, nil).Twice()
+	suite.highlightsRepositoryMock.On("Update", &highlightEntity, storyIDs).Return(nil).Once()
+	suite.highlightsRepositoryMock.On("GetStories", []uint{id}).Return(items, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 800}).Return("image80", nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 810}).Return("image81", nil).Once()
+
+	highlight, updateErr := suite.service.UpdateHighlight(id, &highlightDTO)
+
+	assert.Equal(suite.T(), nil, updateErr)
+	assert.Equal(suite.T(), "Winter", highlightEntity.Name)
+	assert.Equal(suite.T(), id, highlight.ID)
+	assert.Equal(suite.T(), "Winter", highlight.Name)
+	assert.Equal(suite.T(), int64(100), highlight.Date)
+	assert.Equal(suite.T(), []uint{81, 80}, []uint{highlight.Stories[0].ID, highlight.Stories[1].ID})
+	assert.Equal(suite.T(), "image81", highlight.Stories[0].Image)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetArchivedStories() {
+	user := "archivist@mail.com"
+	stories := []modelStory.Story{
+		{ID: 82, UserEmail: user, MediaID: 820, MediaType: modelPostSetting.MediaTypeVideo, Date: 300, ExpiresAt: 400, Archived: true},
+		{ID: 83, UserEmail: user, MediaID: 830, MediaType: modelPostSetting.MediaTypeImage, Date: 100, ExpiresAt: 200, Archived: true},
+	}
+
+	suite.storiesRepositoryMock.On("GetArchived", user).Return(stories, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 820}).Return("video82", nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 830}).Return("image83", nil).Once()
+
+	storyDTOs, getErr := suite.service.GetArchivedStories(user)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []dtos.StoryDTO{
+		{ID: 82, Image: "video82", MediaType: modelPostSetting.MediaTypeVideo, Date: 300, ExpiresAt: 400, Seen: true},
+		{ID: 83, Image: "image83", MediaType: modelPostSetting.MediaTypeImage, Date: 100, ExpiresAt: 200, Seen: true},
+	}, storyDTOs)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_DeleteHighlight_NotAuthor() {
+	var id uint = 76
+	highlightEntity := modelHighlight.Highlight{ID: id, UserEmail: "author@mail.com"}
+	err := rest_error.NewRestError("Only author can change a highlight", http.StatusForbidden, "forbidden", nil)
+
+	suite.highlightsRepositoryMock.On("Get", id).Return(&highlightEntity, nil).Once()
+
+	deleteErr := suite.service.DeleteHighlight(id, "other@mail.com")
+
+	assert.Equal(suite.T(), err, deleteErr)
+	suite.highlightsRepositoryMock.AssertNotCalled(suite.T(), "Delete", &highlightEntity)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_CreatePost_InvalidAudience() {
+	postDTO := dtos.CreatePostDTO{
+		Description: "Description",
+		Image:       sniffableImageBase64("Image"),
+		UserEmail:   "mail@mail.com",
+		Audience:    "everyone",
+	}
+	err := rest_error.NewBadRequestError("Invalid audience")
+
+	createErr := suite.service.CreatePost(&postDTO)
+
+	assert.Equal(suite.T(), err, createErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetAudience_NotAuthor() {
+	audienceRequest := dtos.AudienceRequestDTO{PostID: 801, UserEmail: "other@mail.com", Audience: modelPostSetting.AudienceFollowers}
+	err := rest_error.NewRestError("Only author can set post audience", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", audienceRequest.PostID).Return(&modelPost.Post{ID: 801, UserEmail: "author@mail.com"}, nil).Once()
+
+	audienceErr := suite.service.SetAudience(&audienceRequest)
+
+	assert.Equal(suite.T(), err, audienceErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetAudience() {
+	audienceRequest := dtos.AudienceRequestDTO{PostID: 802, UserEmail: "author@mail.com", Audience: modelPostSetting.AudienceCloseFriends}
+	setting := modelPostSetting.PostSetting{ID: 8, PostID: 802, ContentWarning: modelPostSetting.ContentWarningGraphic}
+
+	suite.postsRepositoryMock.On("Get", audienceRequest.PostID).Return(&modelPost.Post{ID: 802, UserEmail: "author@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", audienceRequest.PostID).Return(&setting, nil).Once()
+	suite.settingsRepositoryMock.On("Update", &modelPostSetting.PostSetting{
+		ID:             8,
+		PostID:         802,
+		ContentWarning: modelPostSetting.ContentWarningGraphic,
+		Audience:       modelPostSetting.AudienceCloseFriends,
+	}, []string{"audience"}).Return(nil).Once()
+
+	audienceErr := suite.service.SetAudience(&audienceRequest)
+
+	assert.Equal(suite.T(), nil, audienceErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_AddCloseFriend_Self() {
+	closeFriend := modelCloseFriend.CloseFriend{UserEmail: "author@mail.com", FriendEmail: "author@mail.com"}
+	err := rest_error.NewBadRequestError("Users can not add themselves to close friends")
+
+	addErr := suite.service.AddCloseFriend(&closeFriend)
+
+	assert.Equal(suite.T(), err, addErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_RemoveCloseFriend() {
+	suite.closeFriendsRepositoryMock.On("Remove", "circle@mail.com", "friend@mail.com").Return(nil).Once()
+
+	removeErr := suite.service.RemoveCloseFriend("circle@mail.com", "friend@mail.com")
+
+	assert.Equal(suite.T(), nil, removeErr)
+	suite.closeFriendsRepositoryMock.AssertCalled(suite.T(), "Remove", "circle@mail.com", "friend@mail.com")
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetCloseFriends() {
+	closeFriends := []modelCloseFriend.CloseFriend{
+		{ID: 1, UserEmail: "circle@mail.com", FriendEmail: "newest@mail.com", Date: 200},
+		{ID: 2, UserEmail: "circle@mail.com", FriendEmail: "oldest@mail.com", Date: 100},
+	}
+
+	suite.closeFriendsRepositoryMock.On("GetByUser", "circle@mail.com").Return(closeFriends, nil).Once()
+
+	friends, getErr := suite.service.GetCloseFriends("circle@mail.com")
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), closeFriends, friends)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_SettingsError() {
+	postEntity := modelPost.Post{ID: 809, UserEmail: "settings-down@mail.com", MediaID: 8090}
+	err := rest_error.NewInternalServerError("Error when trying to get post settings", errors.New("connection refused"))
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.allowProfile(postEntity.UserEmail, "")
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(nil, err).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, "")
+
+	assert.Nil(suite.T(), media)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_HidesPostsOutsideAudience() {
+	author := "audience@mail.com"
+	viewer := "stranger@mail.com"
+	posts := []modelPost.Post{
+		{ID: 803, UserEmail: author, MediaID: 8030},
+		{ID: 804, UserEmail: author, MediaID: 8040},
+	}
+
+	suite.allowProfile(author, viewer)
+	suite.postsRepositoryMock.On("GetProfilePosts", author, false).Return(posts, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{803, 804}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(803)).Return(&modelPostSetting.PostSetting{PostID: 803, Audience: modelPostSetting.AudienceFollowers}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(804)).Return(&modelPostSetting.PostSetting{PostID: 804, Audience: modelPostSetting.AudienceCloseFriends}, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{"other@mail.com"}, nil).Once()
+	suite.closeFriendsRepositoryMock.On("IsCloseFriend", author, viewer).Return(false, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, viewer)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Empty(suite.T(), postsDTOs)
+	suite.closeFriendsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_OutsideAudience() {
+	postEntity := modelPost.Post{ID: 805, UserEmail: "followers@mail.com", MediaID: 8050}
+	err := rest_error.NewRestError("Post is visible only to its audience", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.allowProfile(postEntity.UserEmail, "")
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(&modelPostSetting.PostSetting{PostID: 805, Audience: modelPostSetting.AudienceFollowers}, nil).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, "")
+
+	assert.Nil(suite.T(), media)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_CloseFriend() {
+	postEntity := modelPost.Post{ID: 806, UserEmail: "friends@mail.com", MediaID: 8060}
+	viewer := "friend@mail.com"
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.allowProfile(postEntity.UserEmail, viewer)
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(&modelPostSetting.PostSetting{PostID: 806, Audience: modelPostSetting.AudienceCloseFriends}, nil).Once()
+	suite.closeFriendsRepositoryMock.On("IsCloseFriend", postEntity.UserEmail, viewer).Return(true, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{postEntity.ID}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 8060}).Return("image806", nil).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, viewer)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), "image806", media.Image)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostsDTOs_BlursSensitiveContent() {
+	author := "blurred-author@mail.com"
+	viewer := "blurred-viewer@mail.com"
+	sensitive := modelPost.Post{ID: 1101, UserEmail: author, MediaID: 11010}
+	plain := modelPost.Post{ID: 1102, UserEmail: author, MediaID: 11020}
+
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{1101, 1102}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+	suite.allowProfile(author, viewer)
+	suite.expectBlurredPost(sensitive, viewer)
+	suite.expectVisiblePost(plain, viewer)
+
+	postsDTOs, getErr := suite.service.(*postsService).GetPostsDTOs([]modelPost.Post{sensitive, plain}, viewer, dtos.SensitiveContentBlur)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []uint{1101, 1102}, postIDs(postsDTOs))
+	assert.True(suite.T(), postsDTOs[0].Blurred)
+	assert.Equal(suite.T(), modelPostSetting.ContentWarningGraphic, postsDTOs[0].ContentWarning)
+	assert.Equal(suite.T(), "", postsDTOs[0].Image)
+	assert.Empty(suite.T(), postsDTOs[0].Media)
+	assert.False(suite.T(), postsDTOs[1].Blurred)
+	assert.Equal(suite.T(), "image1102", postsDTOs[1].Image)
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "GetMedia", dtos.GetMediaRequest{ID: 11010})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostsDTOs_ExcludesSensitiveContent() {
+	author := "excluded-author@mail.com"
+	viewer := "excluded-viewer@mail.com"
+	sensitive := modelPost.Post{ID: 1103, UserEmail: author, MediaID: 11030}
+	plain := modelPost.Post{ID: 1104, UserEmail: author, MediaID: 11040}
+
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{1103, 1104}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+	suite.allowProfile(author, viewer)
+	suite.settingsRepositoryMock.On("GetByPost", sensitive.ID).Return(&modelPostSetting.PostSetting{PostID: sensitive.ID, ContentWarning: modelPostSetting.ContentWarningViolence}, nil).Once()
+	suite.expectVisiblePost(plain, viewer)
+
+	postsDTOs, getErr := suite.service.(*postsService).GetPostsDTOs([]modelPost.Post{sensitive, plain}, viewer, dtos.SensitiveContentExclude)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []uint{1104}, postIDs(postsDTOs))
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "GetMedia", dtos.GetMediaRequest{ID: 11030})
+	suite.likesRepositoryMock.AssertNotCalled(suite.T(), "GetNumberOfLikes", sensitive.ID)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostsDTOs_AuthorSeesOwnSensitiveContent() {
+	author := "own-sensitive@mail.com"
+	postEntity := modelPost.Post{ID: 1105, UserEmail: author, MediaID: 11050}
+
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{1105}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(&modelPostSetting.PostSetting{PostID: postEntity.ID, ContentWarning: modelPostSetting.ContentWarningNudity}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 11050}).Return("image1105", nil).Once()
+	suite.expectPostDetails(postEntity, author)
+	suite.impressionsRepositoryMock.On("CountByPost", postEntity.ID).Return(int64(0), nil).Once()
+
+	postsDTOs, getErr := suite.service.(*postsService).GetPostsDTOs([]modelPost.Post{postEntity}, author, dtos.SensitiveContentExclude)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []uint{1105}, postIDs(postsDTOs))
+	assert.False(suite.T(), postsDTOs[0].Blurred)
+	assert.Equal(suite.T(), "image1105", postsDTOs[0].Image)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostsFeed_SensitiveContent() {
+	author := "feed-sensitive@mail.com"
+	sensitive := modelPost.Post{ID: 1106, UserEmail: author, MediaID: 11060, Date: 200}
+	plain := modelPost.Post{ID: 1107, UserEmail: author, MediaID: 11070, Date: 100}
+
+	for _, sensitiveContent := range []dtos.SensitiveContentPreference{dtos.SensitiveContentBlur, dtos.SensitiveContentExclude} {
+		user := fmt.Sprintf("feed-%s@mail.com", sensitiveContent)
+		items := []modelFeedItem.FeedItem{
+			{UserEmail: user, PostID: sensitive.ID, AuthorEmail: author, Date: sensitive.Date},
+			{UserEmail: user, PostID: plain.ID, AuthorEmail: author, Date: plain.Date},
+		}
+
+		suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: user}).Return([]string{author}, nil).Once()
+		suite.feedItemsRepositoryMock.On("GetByUser", user, feedSize).Return(items, nil).Once()
+		suite.postsRepositoryMock.On("GetUnarchivedByIDs", []uint{1106, 1107}).Return([]modelPost.Post{sensitive, plain}, nil).Once()
+		suite.largeAccountsRepositoryMock.On("GetByUsers", []string{author}).Return([]modelLargeAccount.LargeAccount{}, nil).Once()
+		suite.mutesRepositoryMock.On("GetByUser", user).Return([]modelMute.Mute{}, nil).Once()
+		suite.postMediaRepositoryMock.On("GetByPosts", []uint{1106, 1107}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+		suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+		suite.allowProfile(author, user)
+		if sensitiveContent == dtos.SensitiveContentBlur {
+			suite.expectBlurredPost(sensitive, user)
+		} else {
+			suite.settingsRepositoryMock.On("GetByPost", sensitive.ID).Return(&modelPostSetting.PostSetting{PostID: sensitive.ID, ContentWarning: modelPostSetting.ContentWarningGraphic}, nil).Once()
+		}
+		suite.expectVisiblePost(plain, user)
+		suite.campaignsRepositoryMock.On("GetActive", mock.AnythingOfType("int64")).Return([]modelCampaign.Campaign{}, nil).Once()
+
+		postsDTOs, getErr := suite.service.GetPostsFeed(user, sensitiveContent, dtos.FeedModeChronological)
+
+		assert.Equal(suite.T(), nil, getErr)
+		if sensitiveContent == dtos.SensitiveContentBlur {
+			assert.Equal(suite.T(), []uint{1106, 1107}, postIDs(postsDTOs))
+			assert.True(suite.T(), postsDTOs[0].Blurred)
+			assert.Empty(suite.T(), postsDTOs[0].Media)
+		} else {
+			assert.Equal(suite.T(), []uint{1107}, postIDs(postsDTOs))
+		}
+	}
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "GetMedia", dtos.GetMediaRequest{ID: 11060})
+}
+
+// expectFeed mocks reads of a feed with visible posts of followed authors, none of whom is a large account or muted
+func (suite *PostServiceUnitTestsSuite) expectFeed(user string, followedUsers []string, posts []modelPost.Post) {
+	items := make([]modelFeedItem.FeedItem, 0, len(posts))
+	ids := make([]uint, 0, len(posts))
+	for _, postEntity := range posts {
+		items = append(items, modelFeedItem.FeedItem{UserEmail: user, PostID: postEntity.ID, AuthorEmail: postEntity.UserEmail, Date: postEntity.Date})
+		ids = append(ids, postEntity.ID)
+	}
+
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: user}).Return(followedUsers, nil).Once()
+	suite.feedItemsRepositoryMock.On("GetByUser", user, feedSize).Return(items, nil).Once()
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", ids).Return(posts, nil).Once()
+	suite.largeAccountsRepositoryMock.On("GetByUsers", followedUsers).Return([]modelLargeAccount.LargeAccount{}, nil).Once()
+	suite.mutesRepositoryMock.On("GetByUser", user).Return([]modelMute.Mute{}, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", ids).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	for _, author := range followedUsers {
+		suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+		suite.allowProfile(author, user)
+	}
+	for _, postEntity := range posts {
+		suite.expectVisiblePost(postEntity, user)
+	}
+	suite.campaignsRepositoryMock.On("GetActive", mock.AnythingOfType("int64")).Return([]modelCampaign.Campaign{}, nil).Once()
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostsFeed_Ranked() {
+	user := "ranked-feed@mail.com"
+	now := time_utils.Now()
+	// Viewer often engages with the author of the older post
+	favorite := modelPost.Post{ID: 1111, UserEmail: "ranked-favorite@mail.com", MediaID: 11110, Date: now - 2*3600}
+	other := modelPost.Post{ID: 1112, UserEmail: "ranked-other@mail.com", MediaID: 11120, Date: now - 3600}
+
+	suite.expectFeed(user, []string{favorite.UserEmail, other.UserEmail}, []modelPost.Post{favorite, other})
+	suite.likesRepositoryMock.On("CountByUserOnAuthor", user, favorite.UserEmail).Return(int64(40), nil).Once()
+	suite.commentsRepositoryMock.On("CountByUserOnAuthor", user, favorite.UserEmail).Return(int64(20), nil).Once()
+	suite.likesRepositoryMock.On("CountByUserOnAuthor", user, other.UserEmail).Return(int64(0), nil).Once()
+	suite.commentsRepositoryMock.On("CountByUserOnAuthor", user, other.UserEmail).Return(int64(0), nil).Once()
+
+	postsDTOs, getErr := suite.service.GetPostsFeed(user, dtos.SensitiveContentBlur, dtos.FeedModeRanked)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []uint{1111, 1112}, postIDs(postsDTOs))
+	suite.likesRepositoryMock.AssertCalled(suite.T(), "CountByUserOnAuthor", user, favorite.UserEmail)
+	suite.commentsRepositoryMock.AssertCalled(suite.T(), "CountByUserOnAuthor", user, favorite.UserEmail)
+	suite.likesRepositoryMock.AssertCalled(suite.T(), "CountByUserOnAuthor", user, other.UserEmail)
+	suite.commentsRepositoryMock.AssertCalled(suite.T(), "CountByUserOnAuthor", user, other.UserEmail)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostsFeed_Chronological() {
+	user := "chronological-feed@mail.com"
+	now := time_utils.Now()
+	favorite := modelPost.Post{ID: 1113, UserEmail: "chronological-favorite@mail.com", MediaID: 11130, Date: now - 2*3600}
+	other := modelPost.Post{ID: 1114, UserEmail: "chronological-other@mail.com", MediaID: 11140, Date: now - 3600}
+
+	suite.expectFeed(user, []string{favorite.UserEmail, other.UserEmail}, []modelPost.Post{favorite, other})
+
+	postsDTOs, getErr := suite.service.GetPostsFeed(user, dtos.SensitiveContentBlur, dtos.FeedModeChronological)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []uint{1114, 1113}, postIDs(postsDTOs))
+	suite.likesRepositoryMock.AssertNotCalled(suite.T(), "CountByUserOnAuthor", user, mock.Anything)
+	suite.commentsRepositoryMock.AssertNotCalled(suite.T(), "CountByUserOnAuthor", user, mock.Anything)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SearchTags_SensitiveContent() {
+	tag := "sensitivetag"
+	author := "tagged-sensitive@mail.com"
+	sensitive := modelPost.Post{ID: 1108, UserEmail: author, MediaID: 11080, Date: 200}
+	plain := modelPost.Post{ID: 1109, UserEmail: author, MediaID: 11090, Date: 100}
+
+	for _, sensitiveContent := range []dtos.SensitiveContentPreference{dtos.SensitiveContentBlur, dtos.SensitiveContentExclude} {
+		suite.userGrpcClientMock.On("CheckIfUserIsTaggable", dtos.CheckTaggableRequest{Username: tag}).Return(true, nil).Once()
+		suite.postsRepositoryMock.On("SearchByTag", tag).Return([]modelPost.Post{sensitive, plain}, nil).Once()
+		suite.postMediaRepositoryMock.On("GetByPosts", []uint{1108, 1109}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+		suite.restrictionsRepositoryMock.On("GetByUser", author).Return(nil, notRestricted(author)).Once()
+		suite.allowProfile(author, "")
+		if sensitiveContent == dtos.SensitiveContentBlur {
+			suite.expectBlurredPost(sensitive, "")
+		} else {
+			suite.settingsRepositoryMock.On("GetByPost", sensitive.ID).Return(&modelPostSetting.PostSetting{PostID: sensitive.ID, ContentWarning: modelPostSetting.ContentWarningMedical}, nil).Once()
+		}
+		suite.expectVisiblePost(plain, "")
+
+		postsDTOs, searchErr := suite.service.SearchTags(tag, "", sensitiveContent)
+
+		assert.Equal(suite.T(), nil, searchErr)
+		if sensitiveContent == dtos.SensitiveContentBlur {
+			assert.Equal(suite.T(), []uint{1108, 1109}, postIDs(postsDTOs))
+			assert.True(suite.T(), postsDTOs[0].Blurred)
+			assert.Empty(suite.T(), postsDTOs[0].Media)
+		} else {
+			assert.Equal(suite.T(), []uint{1109}, postIDs(postsDTOs))
+		}
+	}
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "GetMedia", dtos.GetMediaRequest{ID: 11080})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_RevealsSensitiveContent() {
+	postEntity := modelPost.Post{ID: 1110, UserEmail: "revealed@mail.com", MediaID: 11100}
+	viewer := "revealing@mail.com"
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.allowProfile(postEntity.UserEmail, viewer)
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(&modelPostSetting.PostSetting{PostID: postEntity.ID, ContentWarning: modelPostSetting.ContentWarningGraphic}, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{postEntity.ID}).Return([]modelPostMedia.PostMedia{
+		{PostID: postEntity.ID, MediaID: 11101},
+		{PostID: postEntity.ID, MediaID: 11102},
+	}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 11101}).Return("image11101", nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 11102}).Return("image11102", nil).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, viewer)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), &dtos.PostMediaDTO{PostID: postEntity.ID, Image: "image11101", Media: []string{"image11101", "image11102"}}, media)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_ViewerBlockedAuthor() {
+	author := "blocked-author@mail.com"
+	viewer := "blocking-viewer@mail.com"
+	err := rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: author}).Return(true, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, viewer)
+
+	assert.Nil(suite.T(), postsDTOs)
+	assert.Equal(suite.T(), err, getErr)
+	suite.postsRepositoryMock.AssertNotCalled(suite.T(), "GetProfilePosts", author, false)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_AuthorBlockedViewer() {
+	author := "blocking-author@mail.com"
+	viewer := "blocked-viewer@mail.com"
+	err := rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: author}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: author, BlockedUser: viewer}).Return(true, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, viewer)
+
+	assert.Nil(suite.T(), postsDTOs)
+	assert.Equal(suite.T(), err, getErr)
+	suite.postsRepositoryMock.AssertNotCalled(suite.T(), "GetProfilePosts", author, false)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_PrivateProfileNotFollower() {
+	author := "private-author@mail.com"
+	viewer := "not-follower@mail.com"
+	err := rest_error.NewRestError("Profile is private", http.StatusForbidden, "forbidden", nil)
+
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: author}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: author, BlockedUser: viewer}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: author}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{"other@mail.com"}, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, viewer)
+
+	assert.Nil(suite.T(), postsDTOs)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_PrivateProfileAnonymous() {
+	author := "private-anonymous@mail.com"
+	err := rest_error.NewRestError("Profile is private", http.StatusForbidden, "forbidden", nil)
+
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: author}).Return(true, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, "")
+
+	assert.Nil(suite.T(), postsDTOs)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_PrivateProfileCheckFails() {
+	author := "unknown-privacy@mail.com"
+	err := rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: author}).Return(false, errors.New("unavailable")).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, "")
+
+	assert.Nil(suite.T(), postsDTOs)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_PrivateProfileFollower() {
+	author := "private-followed@mail.com"
+	viewer := "follower@mail.com"
+
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: author}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: author, BlockedUser: viewer}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: author}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{author}, nil).Once()
+	suite.postsRepositoryMock.On("GetProfilePosts", author, false).Return([]modelPost.Post{}, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, viewer)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Empty(suite.T(), postsDTOs)
+	suite.postsRepositoryMock.AssertCalled(suite.T(), "GetProfilePosts", author, false)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_OwnPrivateProfile() {
+	author := "private-own@mail.com"
+
+	suite.postsRepositoryMock.On("GetProfilePosts", author, false).Return([]modelPost.Post{}, nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, author)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Empty(suite.T(), postsDTOs)
+	suite.userGrpcClientMock.AssertNotCalled(suite.T(), "CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: author})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_AuthorBlockedViewer() {
+	postEntity := modelPost.Post{ID: 807, UserEmail: "media-blocker@mail.com", MediaID: 8070}
+	viewer := "media-blocked@mail.com"
+	err := rest_error.NewRestError("Profile is not available", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: postEntity.UserEmail}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: postEntity.UserEmail, BlockedUser: viewer}).Return(true, nil).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, viewer)
+
+	assert.Nil(suite.T(), media)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_PrivateProfileAnonymous() {
+	postEntity := modelPost.Post{ID: 808, UserEmail: "media-private@mail.com", MediaID: 8080}
+	err := rest_error.NewRestError("Profile is private", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: postEntity.UserEmail}).Return(true, nil).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, "")
+
+	assert.Nil(suite.T(), media)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetSimilarPosts_SkipsBlockedAndPrivateAuthors() {
+	source := modelPost.Post{ID: 809, UserEmail: "similar-source@mail.com"}
+	viewer := "similar-viewer@mail.com"
+	similarities := []modelPostSimilarity.PostSimilarity{
+		{PostID: 809, SimilarPostID: 810, Score: 0.9},
+		{PostID: 809, SimilarPostID: 811, Score: 0.5},
+	}
+	found := []modelPost.Post{
+		{ID: 810, UserEmail: "similar-blocker@mail.com", MediaID: 8100},
+		{ID: 811, UserEmail: "similar-private@mail.com", MediaID: 8110},
+	}
+
+	suite.postsRepositoryMock.On("Get", source.ID).Return(&source, nil).Once()
+	suite.allowProfile(source.UserEmail, viewer)
+	suite.settingsRepositoryMock.On("GetByPost", source.ID).Return(nil, rest_error.NewNotFoundError("Post with id 809 has no settings")).Once()
+	suite.similaritiesRepositoryMock.On("GetByPost", source.ID, similarPostsSize).Return(similarities, nil).Once()
+	suite.postsRepositoryMock.On("GetUnarchivedByIDs", []uint{810, 811}).Return(found, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{810, 811}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	for _, postEntity := range found {
+		suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	}
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "similar-blocker@mail.com"}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "similar-blocker@mail.com", BlockedUser: viewer}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: viewer, BlockedUser: "similar-private@mail.com"}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfUserIsBlocked", dtos.CheckIfUserIsBlockedRequest{User: "similar-private@mail.com", BlockedUser: viewer}).Return(false, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfProfileIsPrivate", dtos.CheckIfProfileIsPrivateRequest{UserEmail: "similar-private@mail.com"}).Return(true, nil).Once()
+	suite.userGrpcClientMock.On("GetFollowingUsers", dtos.GetFollowingUsersRequest{UserEmail: viewer}).Return([]string{}, nil).Once()
+
+	posts, getErr := suite.service.GetSimilarPosts(source.ID, viewer, dtos.SensitiveContentBlur)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Empty(suite.T(), posts)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_MuteUser_Self() {
+	muteEntity := modelMute.Mute{UserEmail: "muter@mail.com", MutedEmail: "muter@mail.com"}
+	err := rest_error.NewBadRequestError("Users can not mute themselves")
+
+	muteErr := suite.service.MuteUser(&muteEntity)
+
+	assert.Equal(suite.T(), err, muteErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_MuteUser() {
+	muteEntity := modelMute.Mute{UserEmail: "muter@mail.com", MutedEmail: "noisy@mail.com"}
+
+	suite.mutesRepositoryMock.On("Create", &muteEntity).Return(nil).Once()
+
+	muteErr := suite.service.MuteUser(&muteEntity)
+
+	assert.Equal(suite.T(), nil, muteErr)
+	assert.NotEqual(suite.T(), int64(0), muteEntity.Date)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UnmuteUser() {
+	suite.mutesRepositoryMock.On("Delete", "muter@mail.com", "quiet@mail.com").Return(nil).Once()
+
+	unmuteErr := suite.service.UnmuteUser("muter@mail.com", "quiet@mail.com")
+
+	assert.Equal(suite.T(), nil, unmuteErr)
+	suite.mutesRepositoryMock.AssertCalled(suite.T(), "Delete", "muter@mail.com", "quiet@mail.com")
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetMutedUsers() {
+	mutes := []modelMute.Mute{
+		{ID: 1, UserEmail: "mute-lister@mail.com", MutedEmail: "newest-muted@mail.com", Date: 200},
+		{ID: 2, UserEmail: "mute-lister@mail.com", MutedEmail: "oldest-muted@mail.com", Date: 100},
+	}
+
+	suite.mutesRepositoryMock.On("GetByUser", "mute-lister@mail.com").Return(mutes, nil).Once()
+	suite.userGrpcClientMock.On("GetUsernames", dtos.GetUsernamesRequest{Emails: []string{"newest-muted@mail.com", "oldest-muted@mail.com"}}).
+		Return(map[string]string{"newest-muted@mail.com": "newest", "oldest-muted@mail.com": "oldest"}, nil).Once()
+
+	mutedUsers, getErr := suite.service.GetMutedUsers("mute-lister@mail.com")
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), []dtos.MutedUserDTO{
+		{Email: "newest-muted@mail.com", Username: "newest", Date: 200},
+		{Email: "oldest-muted@mail.com", Username: "oldest", Date: 100},
+	}, mutedUsers)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetUsersPosts_HidesCommentsOfBlockedAndMutedUsers() {
+	author := "comments-author@mail.com"
+	viewer := "comments-viewer@mail.com"
+	muted := "muted-commenter@mail.com"
+	blocker := "blocking-commenter@mail.com"
+	friendly := "friendly-commenter@mail.com"
+	postEntity := modelPost.Post{ID: 812, UserEmail: author, MediaID: 8120}
+	comments := []modelComment.Comment{
+		{ID: 901, PostID: 812, UserEmail: muted, Text: "Muted"},
+		{ID: 902, PostID: 812, UserEmail: blocker, Text: "Blocked"},
+		{ID: 903, PostID: 812, UserEmail: friendly, Text: "Visible"},
+		{ID: 904, PostID: 812, UserEmail: viewer, Text: "Own"},
+	}
+
+	suite.allowProfile(author, viewer)
+	suite.postsRepositoryMock.On("GetProfilePosts", author, false).Return([]modelPost.Post{postEntity}, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{812}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(812)).Return(nil, rest_error.NewNotFoundError("Post with id 812 has no settings")).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 8120}).Return("image812", nil).Once()
+	suite.userGrpcClientMock.On("GetUsername", dtos.GetUsernameRequest{Email: author}).Return("author", nil).Once()
+	suite.likesRepositoryMock.On("GetByUserAndPost", viewer, uint(812)).Return(nil, rest_error.NewNotFoundError("Like not found")).Once()
+	suite.dislikesRepositoryMock.On("GetByUserAndPost", viewer, uint(812)).Return(nil, rest_error.NewNotFoundError("Dislike not found")).Once()
+	suite.userGrpcClientMock.On("CheckPostIsInFavorites", dtos.CheckFavoritesRequest{Email: viewer, PostID: 812}).Return(false, nil).Once()
+	suite.likesRepositoryMock.On("GetNumberOfLikes", uint(812)).Return(int64(3), nil).Once()
+	suite.dislikesRepositoryMock.On("GetNumberOfDislikes", uint(812)).Return(int64(0), nil).Once()
+	suite.commentsRepositoryMock.On("GetComments", uint(812)).Return(comments, nil).Once()
+	suite.commentReviewsRepositoryMock.On("GetByPost", uint(812)).Return([]modelCommentReview.CommentReview{}, nil).Once()
+	for _, user := range []string{author, muted, blocker, friendly} {
+		suite.restrictionsRepositoryMock.On("GetByUser", user).Return(nil, notRestricted(user)).Once()
+	}
+	suite.mutesRepositoryMock.On("GetByUser", viewer).Return([]modelMute.Mute{{UserEmail: viewer, MutedEmail: muted}}, nil).Once()
+	suite.userGrpcClientMock.On("GetBlockedUsers", dtos.GetBlockedUsersRequest{UserEmail: viewer}).Return([]string{blocker}, nil).Once()
+	suite.userGrpcClientMock.On("GetUsername", dtos.GetUsernameRequest{Email: friendly}).Return("friendly", nil).Once()
+	suite.userGrpcClientMock.On("GetUsername", dtos.GetUsernameRequest{Email: viewer}).Return("viewer", nil).Once()
+
+	postsDTOs, getErr := suite.service.GetUsersPosts(author, viewer)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), 1, len(postsDTOs))
+	assert.Equal(suite.T(), 2, len(postsDTOs[0].Comments))
+	assert.Equal(suite.T(), "friendly", postsDTOs[0].Comments[0].Username)
+	assert.Equal(suite.T(), "viewer", postsDTOs[0].Comments[1].Username)
+	suite.userGrpcClientMock.AssertNumberOfCalls(suite.T(), "GetBlockedUsers", 1)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ArchivePost_NotAuthor() {
+	err := rest_error.NewRestError("Only author can archive a post", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", uint(813)).Return(&modelPost.Post{ID: 813, UserEmail: "archiver@mail.com"}, nil).Once()
+
+	archiveErr := suite.service.ArchivePost(813, "other@mail.com")
+
+	assert.Equal(suite.T(), err, archiveErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_ArchivePost() {
+	setting := modelPostSetting.PostSetting{ID: 9, PostID: 814, ContentWarning: modelPostSetting.ContentWarningMedical}
+
+	suite.postsRepositoryMock.On("Get", uint(814)).Return(&modelPost.Post{ID: 814, UserEmail: "archiver@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(814)).Return(&setting, nil).Once()
+	suite.settingsRepositoryMock.On("Update", &modelPostSetting.PostSetting{
+		ID:             9,
+		PostID:         814,
+		ContentWarning: modelPostSetting.ContentWarningMedical,
+		Archived:       true,
+	}, []string{"archived", "pinned_at"}).Return(nil).Once()
+
+	archiveErr := suite.service.ArchivePost(814, "archiver@mail.com")
+
+	assert.Equal(suite.T(), nil, archiveErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetPostMedia_ArchivedNotAuthor() {
+	postEntity := modelPost.Post{ID: 815, UserEmail: "archived-media@mail.com", MediaID: 8150}
+	viewer := "archived-viewer@mail.com"
+	err := rest_error.NewNotFoundError("Error when trying to get post with id 815")
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", postEntity.UserEmail).Return(nil, notRestricted(postEntity.UserEmail)).Once()
+	suite.allowProfile(postEntity.UserEmail, viewer)
+	suite.settingsRepositoryMock.On("GetByPost", postEntity.ID).Return(&modelPostSetting.PostSetting{PostID: 815, Archived: true}, nil).Once()
+
+	media, getErr := suite.service.GetPostMedia(postEntity.ID, viewer)
+
+	assert.Nil(suite.T(), media)
+	assert.Equal(suite.T(), err, getErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_GetArchivedPosts() {
+	owner := "archive-owner@mail.com"
+	posts := []modelPost.Post{
+		{ID: 817, UserEmail: owner, MediaID: 8170, Date: 200},
+	}
+
+	suite.postsRepositoryMock.On("GetProfilePosts", owner, true).Return(posts, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{817}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", owner).Return(nil, notRestricted(owner)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(817)).Return(&modelPostSetting.PostSetting{PostID: 817, Archived: true}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 8170}).Return("image817", nil).Once()
+	suite.userGrpcClientMock.On("GetUsername", dtos.GetUsernameRequest{Email: owner}).Return("owner", nil).Once()
+	suite.likesRepositoryMock.On("GetByUserAndPost", owner, uint(817)).Return(nil, rest_error.NewNotFoundError("Like not found")).Once()
+	suite.dislikesRepositoryMock.On("GetByUserAndPost", owner, uint(817)).Return(nil, rest_error.NewNotFoundError("Dislike not found")).Once()
+	suite.userGrpcClientMock.On("CheckPostIsInFavorites", dtos.CheckFavoritesRequest{Email: owner, PostID: 817}).Return(false, nil).Once()
+	suite.impressionsRepositoryMock.On("CountByPost", uint(817)).Return(int64(4), nil).Once()
+	suite.likesRepositoryMock.On("GetNumberOfLikes", uint(817)).Return(int64(2), nil).Once()
+	suite.dislikesRepositoryMock.On("GetNumberOfDislikes", uint(817)).Return(int64(1), nil).Once()
+	suite.commentsRepositoryMock.On("GetComments", uint(817)).Return([]modelComment.Comment{}, nil).Once()
+	suite.commentReviewsRepositoryMock.On("GetByPost", uint(817)).Return([]modelCommentReview.CommentReview{}, nil).Once()
+
+	archived, getErr := suite.service.GetArchivedPosts(owner)
+
+	assert.Equal(suite.T(), nil, getErr)
+	assert.Equal(suite.T(), 1, len(archived))
+	assert.Equal(suite.T(), uint(817), archived[0].ID)
+	assert.True(suite.T(), archived[0].Archived)
+	assert.Equal(suite.T(), uint(2), archived[0].Likes)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PinPost_LimitReached() {
+	err := rest_error.NewBadRequestError(fmt.Sprintf("Only %d posts can be pinned", maxPinnedPosts))
+
+	suite.postsRepositoryMock.On("Get", uint(818)).Return(&modelPost.Post{ID: 818, UserEmail: "pinner@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(818)).Return(nil, rest_error.NewNotFoundError("Post with id 818 has no settings")).Once()
+	suite.settingsRepositoryMock.On("Pin", mock.AnythingOfType("*post_setting.PostSetting"), "pinner@mail.com", int64(maxPinnedPosts)).Return(err).Once()
+
+	pinErr := suite.service.PinPost(818, "pinner@mail.com")
+
+	assert.Equal(suite.T(), err, pinErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PinPost_Archived() {
+	err := rest_error.NewBadRequestError("Archived post can not be pinned")
+
+	suite.postsRepositoryMock.On("Get", uint(819)).Return(&modelPost.Post{ID: 819, UserEmail: "pinner@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(819)).Return(&modelPostSetting.PostSetting{PostID: 819, Archived: true}, nil).Once()
+
+	pinErr := suite.service.PinPost(819, "pinner@mail.com")
+
+	assert.Equal(suite.T(), err, pinErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PinPost() {
+	suite.postsRepositoryMock.On("Get", uint(820)).Return(&modelPost.Post{ID: 820, UserEmail: "other-pinner@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(820)).Return(nil, rest_error.NewNotFoundError("Post with id 820 has no settings")).Once()
+	suite.settingsRepositoryMock.On("Pin", mock.MatchedBy(func(setting *modelPostSetting.PostSetting) bool {
+		return setting.PostID == 820 && setting.IsPinned()
+	}), "other-pinner@mail.com", int64(maxPinnedPosts)).Return(nil).Once()
+
+	pinErr := suite.service.PinPost(820, "other-pinner@mail.com")
+
+	assert.Equal(suite.T(), nil, pinErr)
+	suite.settingsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UnpinPost_NotAuthor() {
+	err := rest_error.NewRestError("Only author can unpin a post", http.StatusForbidden, "forbidden", nil)
+
+	suite.postsRepositoryMock.On("Get", uint(821)).Return(&modelPost.Post{ID: 821, UserEmail: "pinner@mail.com"}, nil).Once()
+
+	unpinErr := suite.service.UnpinPost(821, "other@mail.com")
+
+	assert.Equal(suite.T(), err, unpinErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_UnpinPost() {
+	suite.postsRepositoryMock.On("Get", uint(822)).Return(&modelPost.Post{ID: 822, UserEmail: "unpinner@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(822)).Return(&modelPostSetting.PostSetting{ID: 12, PostID: 822, PinnedAt: 100}, nil).Once()
+	suite.settingsRepositoryMock.On("Update", &modelPostSetting.PostSetting{ID: 12, PostID: 822}, []string{"pinned_at"}).Return(nil).Once()
+
+	unpinErr := suite.service.UnpinPost(822, "unpinner@mail.com")
+
+	assert.Equal(suite.T(), nil, unpinErr)
+	suite.settingsRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SortProfilePosts() {
+	postsDTOs := []dtos.PostDTO{
+		{ID: 1, Timestamp: 300},
+		{ID: 2, Timestamp: 100, Pinned: true},
+		{ID: 3, Timestamp: 500},
+		{ID: 4, Timestamp: 200, Pinned: true},
+	}
+
+	sortProfilePosts(postsDTOs)
+
+	ids := make([]uint, 0, len(postsDTOs))
+	for _, postDTO := range postsDTOs {
+		ids = append(ids, postDTO.ID)
+	}
+	assert.Equal(suite.T(), []uint{4, 2, 3, 1}, ids)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment_TurnedOff() {
+	commentEntity := modelComment.Comment{
+		PostID:    830,
+		UserEmail: "commenter@mail.com",
+		Text:      "Nice",
+	}
+
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{ID: 830, UserEmail: "quiet@mail.com"}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(nil, notRestricted(commentEntity.UserEmail)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(830)).Return(&modelPostSetting.PostSetting{PostID: 830, CommentPolicy: modelPostSetting.CommentPolicyNobody}, nil).Once()
+
+	commErr := suite.service.PostComment(&commentEntity)
+
+	assert.Equal(suite.T(), http.StatusForbidden, commErr.Status())
+	assert.Equal(suite.T(), "Comments are turned off for this post", commErr.Message())
+	suite.commentsRepositoryMock.AssertNotCalled(suite.T(), "Create", &commentEntity)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment_FollowersOnly() {
+	commentEntity := modelComment.Comment{
+		PostID:    831,
+		UserEmail: "stranger@mail.com",
+		Text:      "Nice",
+	}
+
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{ID: 831, UserEmail: "selective@mail.com"}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(nil, notRestricted(commentEntity.UserEmail)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(831)).Return(&modelPostSetting.PostSetting{PostID: 831, CommentPolicy: modelPostSetting.CommentPolicyFollowers}, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfFollowing", dtos.CheckIfFollowingRequest{User: commentEntity.UserEmail, FollowedUser: "selective@mail.com"}).Return(false, nil).Once()
+
+	commErr := suite.service.PostComment(&commentEntity)
+
+	assert.Equal(suite.T(), http.StatusForbidden, commErr.Status())
+	assert.Equal(suite.T(), "Only followers can comment on this post", commErr.Message())
+	suite.commentsRepositoryMock.AssertNotCalled(suite.T(), "Create", &commentEntity)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment_FollowersOnlyCheckFails() {
+	commentEntity := modelComment.Comment{
+		PostID:    835,
+		UserEmail: "unverified@mail.com",
+		Text:      "Nice",
+	}
+
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{ID: 835, UserEmail: "selective@mail.com"}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(nil, notRestricted(commentEntity.UserEmail)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(835)).Return(&modelPostSetting.PostSetting{PostID: 835, CommentPolicy: modelPostSetting.CommentPolicyFollowers}, nil).Once()
+	suite.userGrpcClientMock.On("CheckIfFollowing", dtos.CheckIfFollowingRequest{User: commentEntity.UserEmail, FollowedUser: "selective@mail.com"}).Return(false, errors.New("unavailable")).Once()
+
+	commErr := suite.service.PostComment(&commentEntity)
+
+	assert.Equal(suite.T(), http.StatusForbidden, commErr.Status())
+	assert.Equal(suite.T(), "Only followers can comment on this post", commErr.Message())
+	suite.commentsRepositoryMock.AssertNotCalled(suite.T(), "Create", &commentEntity)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_PostComment_SettingsError() {
+	commentEntity := modelComment.Comment{
+		PostID:    836,
+		UserEmail: "early-commenter@mail.com",
+		Text:      "Nice",
+	}
+
+	suite.postsRepositoryMock.On("Get", commentEntity.PostID).Return(&modelPost.Post{ID: 836, UserEmail: "selective@mail.com"}, nil).Once()
+	suite.restrictionsRepositoryMock.On("GetByUser", commentEntity.UserEmail).Return(nil, notRestricted(commentEntity.UserEmail)).Once()
+	suite.settingsRepositoryMock.On("GetByPost", uint(836)).Return(nil, rest_error.NewInternalServerError("Error when trying to get post settings", errors.New("timeout"))).Once()
+
+	commErr := suite.service.PostComment(&commentEntity)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, commErr.Status())
+	suite.commentsRepositoryMock.AssertNotCalled(suite.T(), "Create", &commentEntity)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetCommentPolicy_NotAuthor() {
+	commentPolicyRequest := dtos.CommentPolicyRequestDTO{
+		PostID:        832,
+		UserEmail:     "intruder@mail.com",
+		CommentPolicy: modelPostSetting.CommentPolicyNobody,
+	}
+
+	suite.postsRepositoryMock.On("Get", commentPolicyRequest.PostID).Return(&modelPost.Post{ID: 832, UserEmail: "owner@mail.com"}, nil).Once()
+
+	policyErr := suite.service.SetCommentPolicy(&commentPolicyRequest)
+
+	assert.Equal(suite.T(), http.StatusForbidden, policyErr.Status())
+	assert.Equal(suite.T(), "Only author can set comment policy", policyErr.Message())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetCommentPolicy_Invalid() {
+	commentPolicyRequest := dtos.CommentPolicyRequestDTO{
+		PostID:        833,
+		UserEmail:     "owner@mail.com",
+		CommentPolicy: "friends",
+	}
+
+	suite.postsRepositoryMock.On("Get", commentPolicyRequest.PostID).Return(&modelPost.Post{ID: 833, UserEmail: "owner@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", commentPolicyRequest.PostID).Return(nil, rest_error.NewNotFoundError("Post with id 833 has no settings")).Once()
+
+	policyErr := suite.service.SetCommentPolicy(&commentPolicyRequest)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, policyErr.Status())
+	assert.Equal(suite.T(), "Invalid comment policy", policyErr.Message())
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_SetCommentPolicy() {
+	commentPolicyRequest := dtos.CommentPolicyRequestDTO{
+		PostID:        834,
+		UserEmail:     "owner@mail.com",
+		CommentPolicy: modelPostSetting.CommentPolicyFollowers,
+	}
+
+	suite.postsRepositoryMock.On("Get", commentPolicyRequest.PostID).Return(&modelPost.Post{ID: 834, UserEmail: "owner@mail.com"}, nil).Once()
+	suite.settingsRepositoryMock.On("GetByPost", commentPolicyRequest.PostID).Return(nil, rest_error.NewNotFoundError("Post with id 834 has no settings")).Once()
+	suite.settingsRepositoryMock.On("Update", mock.MatchedBy(func(setting *modelPostSetting.PostSetting) bool {
+		return setting.PostID == 834 && setting.CommentPolicy == modelPostSetting.CommentPolicyFollowers
+	}), []string{"comment_policy"}).Return(nil).Once()
+
+	policyErr := suite.service.SetCommentPolicy(&commentPolicyRequest)
+
+	assert.Equal(suite.T(), nil, policyErr)
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_DecideOnContent_BansMedia() {
+	postEntity := modelPost.Post{
+		ID:                    840,
+		MediaID:               841,
+		MarkedAsInappropriate: true,
+	}
+	image := gradientImageBase64()
+	hash, _ := image_hash.Compute(image)
+	bands := modelBannedMedia.NewBands(image_hash.Bands(hash, bannedMediaBands))
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{postEntity.ID}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 841}).Return(image, nil).Once()
+	suite.postsRepositoryMock.On("ApplyModerationDecisions", []modelPost.Post(nil), []modelPost.Post{postEntity}, mock.MatchedBy(func(bannedMedia []modelBannedMedia.BannedMedia) bool {
+		return len(bannedMedia) == 1 && bannedMedia[0].Hash == hash && bannedMedia[0].PostID == postEntity.ID &&
+			assert.ObjectsAreEqual(bands, bannedMedia[0].Bands)
+	})).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 841}).Return(nil).Once()
+
+	decideErr := suite.service.DecideOnContent(postEntity.ID, true)
+
+	assert.Equal(suite.T(), nil, decideErr)
+	suite.postsRepositoryMock.AssertExpectations(suite.T())
+	suite.mediaGrpcClientMock.AssertCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: 841})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_DecideOnContent_DecisionFailsKeepsMedia() {
+	postEntity := modelPost.Post{ID: 842, MediaID: 843, MarkedAsInappropriate: true}
+	err := rest_error.NewInternalServerError("Error when trying to apply moderation decisions", errors.New("deadlock"))
+
+	suite.postsRepositoryMock.On("Get", postEntity.ID).Return(&postEntity, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{postEntity.ID}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 843}).Return(sniffableImageBase64("Image"), nil).Once()
+	suite.postsRepositoryMock.On("ApplyModerationDecisions", []modelPost.Post(nil), []modelPost.Post{postEntity}, []modelBannedMedia.BannedMedia(nil)).Return(err).Once()
+
+	decideErr := suite.service.DecideOnContent(postEntity.ID, true)
+
+	assert.Equal(suite.T(), err, decideErr)
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: 843})
+}
+
+func (suite *PostServiceUnitTestsSuite) TestPostService_DecideOnContentBulk_DeletesMedia() {
+	decisions := []dtos.ModerationDecisionDTO{
+		{PostID: 844, Delete: true},
+		{PostID: 845, Delete: false},
+		{PostID: 846, Delete: true},
+	}
+	removedPost := modelPost.Post{ID: 844, MediaID: 8440, MarkedAsInappropriate: true}
+	keptPost := modelPost.Post{ID: 845, MediaID: 8450, MarkedAsInappropriate: true}
+	carouselPost := modelPost.Post{ID: 846, MediaID: 8461, MarkedAsInappropriate: true}
+
+	suite.postsRepositoryMock.On("Get", uint(844)).Return(&removedPost, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{844}).Return([]modelPostMedia.PostMedia{}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 8440}).Return(sniffableImageBase64("Image"), nil).Once()
+	suite.postsRepositoryMock.On("Get", uint(845)).Return(&keptPost, nil).Once()
+	suite.postsRepositoryMock.On("Get", uint(846)).Return(&carouselPost, nil).Once()
+	suite.postMediaRepositoryMock.On("GetByPosts", []uint{846}).Return([]modelPostMedia.PostMedia{
+		{PostID: 846, MediaID: 8461, Position: 0},
+		{PostID: 846, MediaID: 8462, Position: 1},
+	}, nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 8461}).Return(sniffableImageBase64("First"), nil).Once()
+	suite.mediaGrpcClientMock.On("GetMedia", dtos.GetMediaRequest{ID: 8462}).Return(sniffableImageBase64("Second"), nil).Once()
+	suite.postsRepositoryMock.On("ApplyModerationDecisions", []modelPost.Post{keptPost}, []modelPost.Post{removedPost, carouselPost}, []modelBannedMedia.BannedMedia(nil)).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 8440}).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 8461}).Return(nil).Once()
+	suite.mediaGrpcClientMock.On("DeleteMedia", dtos.DeleteMediaRequest{ID: 8462}).Return(nil).Once()
+
+	results, decideErr := suite.service.DecideOnContentBulk(decisions)
+
+	assert.Equal(suite.T(), nil, decideErr)
+	assert.Equal(suite.T(), []dtos.ModerationDecisionResultDTO{
+		{PostID: 844, Success: true},
+		{PostID: 845, Success: true},
+		{PostID: 846, Success: true},
+	}, results)
+	for _, mediaID := range []uint64{8440, 8461, 8462} {
+		suite.mediaGrpcClientMock.AssertCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: mediaID})
+	}
+	suite.mediaGrpcClientMock.AssertNotCalled(suite.T(), "DeleteMedia", dtos.DeleteMediaRequest{ID: 8450})
+}
@@ -0,0 +1,29 @@
+package post_grpc_service
+
+import (
+	"context"
+	"github.com/Nistagram-Organization/nistagram-posts/src/proto"
+	"github.com/Nistagram-Organization/nistagram-posts/src/services/post"
+)
+
+type postFeedGrpcService struct {
+	proto.PostFeedServiceServer
+	postService post.PostService
+}
+
+func NewPostFeedGrpcService(postService post.PostService) proto.PostFeedServiceServer {
+	return &postFeedGrpcService{
+		proto.UnimplementedPostFeedServiceServer{},
+		postService,
+	}
+}
+
+func (s *postFeedGrpcService) BackfillFeed(ctx context.Context, backfillFeedRequest *proto.BackfillFeedRequest) (*proto.BackfillFeedResponse, error) {
+	if err := s.postService.BackfillFeed(backfillFeedRequest.UserEmail, backfillFeedRequest.FollowedUserEmail); err != nil {
+		return nil, err
+	}
+
+	response := proto.BackfillFeedResponse{Success: true}
+
+	return &response, nil
+}
@@ -0,0 +1,47 @@
+package post_grpc_service
+
+import (
+	"context"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/author_restriction"
+	"github.com/Nistagram-Organization/nistagram-posts/src/proto"
+	"github.com/Nistagram-Organization/nistagram-posts/src/services/post"
+)
+
+type postRestrictionGrpcService struct {
+	proto.PostRestrictionServiceServer
+	postService post.PostService
+}
+
+func NewPostRestrictionGrpcService(postService post.PostService) proto.PostRestrictionServiceServer {
+	return &postRestrictionGrpcService{
+		proto.UnimplementedPostRestrictionServiceServer{},
+		postService,
+	}
+}
+
+func (s *postRestrictionGrpcService) RestrictAuthor(ctx context.Context, restrictAuthorRequest *proto.RestrictAuthorRequest) (*proto.RestrictAuthorResponse, error) {
+	restriction := author_restriction.AuthorRestriction{
+		UserEmail:        restrictAuthorRequest.UserEmail,
+		ShadowBanned:     restrictAuthorRequest.ShadowBanned,
+		CommentsDisabled: restrictAuthorRequest.CommentsDisabled,
+		PostRateLimit:    uint(restrictAuthorRequest.PostRateLimit),
+	}
+
+	if err := s.postService.RestrictAuthor(&restriction); err != nil {
+		return nil, err
+	}
+
+	response := proto.RestrictAuthorResponse{Success: true}
+
+	return &response, nil
+}
+
+func (s *postRestrictionGrpcService) RemoveAuthorRestriction(ctx context.Context, removeAuthorRestrictionRequest *proto.RemoveAuthorRestrictionRequest) (*proto.RemoveAuthorRestrictionResponse, error) {
+	if err := s.postService.RemoveAuthorRestriction(removeAuthorRestrictionRequest.UserEmail); err != nil {
+		return nil, err
+	}
+
+	response := proto.RemoveAuthorRestrictionResponse{Success: true}
+
+	return &response, nil
+}
@@ -0,0 +1,129 @@
+package similarity
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-posts/src/trending"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/like"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"math"
+	"sort"
+)
+
+const (
+	// Window in seconds from which posts are compared
+	Window = 90 * 24 * 3600
+	// Number of latest likes of the window which are compared
+	MaxLikes = 100000
+	// Number of latest likes compared per user, which bounds pairs built per user
+	maxLikesPerUser = 50
+	// Hashtags used on more posts are too common to tell posts apart and are not compared
+	maxHashtagPosts = 500
+	// Number of similar posts kept per post
+	neighbours = 20
+
+	likesWeight    = 0.7
+	hashtagsWeight = 0.3
+)
+
+type pair struct {
+	first  uint
+	second uint
+}
+
+func newPair(first uint, second uint) pair {
+	if first > second {
+		first, second = second, first
+	}
+	return pair{first, second}
+}
+
+// Compute returns the most similar posts of every post from likes given newest first.
+// Likes similarity is cosine similarity of sets of users who liked two posts,
+// hashtags similarity is Jaccard index of their hashtags.
+// Only the latest maxLikesPerUser likes of a user pair posts up.
+func Compute(posts []post.Post, likes []like.Like) []post_similarity.PostSimilarity {
+	included := make(map[uint]bool, len(posts))
+	for _, postEntity := range posts {
+		included[postEntity.ID] = true
+	}
+
+	likers := make(map[uint]map[string]bool)
+	usersLikes := make(map[string][]uint)
+	for _, likeEntity := range likes {
+		if !included[likeEntity.PostID] {
+			continue
+		}
+		if likers[likeEntity.PostID] == nil {
+			likers[likeEntity.PostID] = make(map[string]bool)
+		}
+		if likers[likeEntity.PostID][likeEntity.UserEmail] {
+			continue
+		}
+		likers[likeEntity.PostID][likeEntity.UserEmail] = true
+		if len(usersLikes[likeEntity.UserEmail]) < maxLikesPerUser {
+			usersLikes[likeEntity.UserEmail] = append(usersLikes[likeEntity.UserEmail], likeEntity.PostID)
+		}
+	}
+
+	coLikes := make(map[pair]int)
+	for _, postIDs := range usersLikes {
+		for i := range postIDs {
+			for j := i + 1; j < len(postIDs); j++ {
+				coLikes[newPair(postIDs[i], postIDs[j])]++
+			}
+		}
+	}
+
+	hashtags := make(map[uint]int)
+	hashtagPosts := make(map[string][]uint)
+	for _, postEntity := range posts {
+		postHashtags, _ := trending.Extract(postEntity.Description)
+		hashtags[postEntity.ID] = len(postHashtags)
+		for _, hashtag := range postHashtags {
+			hashtagPosts[hashtag] = append(hashtagPosts[hashtag], postEntity.ID)
+		}
+	}
+
+	sharedHashtags := make(map[pair]int)
+	for _, postIDs := range hashtagPosts {
+		if len(postIDs) > maxHashtagPosts {
+			continue
+		}
+		for i := range postIDs {
+			for j := i + 1; j < len(postIDs); j++ {
+				sharedHashtags[newPair(postIDs[i], postIDs[j])]++
+			}
+		}
+	}
+
+	scores := make(map[pair]float64)
+	for p, count := range coLikes {
+		scores[p] += likesWeight * float64(count) / math.Sqrt(float64(len(likers[p.first])*len(likers[p.second])))
+	}
+	for p, count := range sharedHashtags {
+		scores[p] += hashtagsWeight * float64(count) / float64(hashtags[p.first]+hashtags[p.second]-count)
+	}
+
+	similar := make(map[uint][]post_similarity.PostSimilarity)
+	for p, score := range scores {
+		similar[p.first] = append(similar[p.first], post_similarity.PostSimilarity{PostID: p.first, SimilarPostID: p.second, Score: score})
+		similar[p.second] = append(similar[p.second], post_similarity.PostSimilarity{PostID: p.second, SimilarPostID: p.first, Score: score})
+	}
+
+	var similarities []post_similarity.PostSimilarity
+	for _, postEntity := range posts {
+		collection := similar[postEntity.ID]
+		sort.Slice(collection, func(i, j int) bool {
+			if collection[i].Score != collection[j].Score {
+				return collection[i].Score > collection[j].Score
+			}
+			return collection[i].SimilarPostID < collection[j].SimilarPostID
+		})
+		if len(collection) > neighbours {
+			collection = collection[:neighbours]
+		}
+		similarities = append(similarities, collection...)
+	}
+
+	return similarities
+}
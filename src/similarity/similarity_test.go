@@ -0,0 +1,102 @@
+package similarity
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_similarity"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/like"
+	"github.com/Nistagram-Organization/nistagram-shared/src/model/post"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SimilarityUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestSimilarityUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(SimilarityUnitTestsSuite))
+}
+
+func similarTo(similarities []post_similarity.PostSimilarity, postID uint) []uint {
+	var ids []uint
+	for _, similarity := range similarities {
+		if similarity.PostID == postID {
+			ids = append(ids, similarity.SimilarPostID)
+		}
+	}
+	return ids
+}
+
+func (suite *SimilarityUnitTestsSuite) TestSimilarity_Compute_Likes() {
+	posts := []post.Post{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	likes := []like.Like{
+		{PostID: 1, UserEmail: "a"}, {PostID: 1, UserEmail: "b"},
+		{PostID: 2, UserEmail: "a"}, {PostID: 2, UserEmail: "b"},
+		{PostID: 3, UserEmail: "a"}, {PostID: 3, UserEmail: "c"},
+		{PostID: 4, UserEmail: "d"},
+	}
+
+	similarities := Compute(posts, likes)
+
+	assert.Equal(suite.T(), []uint{2, 3}, similarTo(similarities, 1))
+	assert.Equal(suite.T(), []uint{1, 3}, similarTo(similarities, 2))
+	assert.Nil(suite.T(), similarTo(similarities, 4))
+	assert.InDelta(suite.T(), likesWeight, similarities[0].Score, 1e-9)
+}
+
+func (suite *SimilarityUnitTestsSuite) TestSimilarity_Compute_Hashtags() {
+	posts := []post.Post{
+		{ID: 1, Description: "#sea #sun"},
+		{ID: 2, Description: "#Sea #sun"},
+		{ID: 3, Description: "#sea #mountain"},
+		{ID: 4, Description: "#city"},
+	}
+
+	similarities := Compute(posts, nil)
+
+	assert.Equal(suite.T(), []uint{2, 3}, similarTo(similarities, 1))
+	assert.Equal(suite.T(), []uint{1, 2}, similarTo(similarities, 3))
+	assert.Nil(suite.T(), similarTo(similarities, 4))
+}
+
+func (suite *SimilarityUnitTestsSuite) TestSimilarity_Compute_IgnoresLikesOfOtherPostsAndDuplicates() {
+	posts := []post.Post{{ID: 1}, {ID: 2}}
+	likes := []like.Like{
+		{PostID: 1, UserEmail: "a"}, {PostID: 1, UserEmail: "a"},
+		{PostID: 2, UserEmail: "a"},
+		{PostID: 9, UserEmail: "a"},
+	}
+
+	similarities := Compute(posts, likes)
+
+	assert.Equal(suite.T(), []post_similarity.PostSimilarity{
+		{PostID: 1, SimilarPostID: 2, Score: likesWeight},
+		{PostID: 2, SimilarPostID: 1, Score: likesWeight},
+	}, similarities)
+}
+
+func (suite *SimilarityUnitTestsSuite) TestSimilarity_Compute_BoundsLikesPerUserAndCommonHashtags() {
+	var posts []post.Post
+	var likes []like.Like
+	for id := uint(1); id <= maxHashtagPosts+1; id++ {
+		posts = append(posts, post.Post{ID: id, Description: "#everything"})
+	}
+	posts[0].Description = "#everything #rare"
+	posts[1].Description = "#everything #rare"
+	// Likes are newest first, so the heavy user's oldest like of post 3 is not paired
+	for id := uint(maxLikesPerUser + 3); id > 3; id-- {
+		likes = append(likes, like.Like{PostID: id, UserEmail: "heavy"})
+	}
+	likes = append(likes, like.Like{PostID: 3, UserEmail: "heavy"}, like.Like{PostID: 3, UserEmail: "light"}, like.Like{PostID: 4, UserEmail: "light"})
+
+	similarities := Compute(posts, likes)
+
+	assert.Equal(suite.T(), []uint{2}, similarTo(similarities, 1))
+	assert.Equal(suite.T(), []uint{4}, similarTo(similarities, 3))
+	for _, similarity := range similarities {
+		if similarity.PostID == 3 {
+			// Cosine similarity still counts every liker of both posts
+			assert.InDelta(suite.T(), likesWeight/2, similarity.Score, 1e-9)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package spam_scorer
+
+import (
+	"math"
+	"regexp"
+)
+
+const (
+	// Comments with score at or above threshold are quarantined
+	QuarantineThreshold = 1.0
+
+	// Window in seconds in which identical comments on other posts are counted
+	DuplicateWindow = 24 * 3600
+	// Window in seconds in which user's comments are counted for velocity
+	VelocityWindow = 60
+	// Accounts younger than this many seconds are considered new
+	NewAccountAge = 24 * 3600
+
+	duplicateWeight  = 0.4
+	linkWeight       = 0.25
+	velocityWeight   = 0.15
+	newAccountWeight = 0.3
+
+	allowedLinks    = 1
+	allowedVelocity = 3
+)
+
+var linkRegexp = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+type Signals struct {
+	Text string
+	// Number of identical comments by the same user on other posts within duplicate window
+	Duplicates int64
+	// Number of comments by the same user within velocity window
+	RecentComments int64
+	// Seconds since user's first activity, negative if user has no previous activity
+	AccountAge int64
+}
+
+type Result struct {
+	Score   float64
+	Reasons []string
+}
+
+func (r *Result) Quarantined() bool {
+	return r.Score >= QuarantineThreshold
+}
+
+type SpamScorer interface {
+	Score(Signals) Result
+}
+
+type spamScorer struct {
+}
+
+func NewSpamScorer() SpamScorer {
+	return &spamScorer{}
+}
+
+func CountLinks(text string) int {
+	return len(linkRegexp.FindAllString(text, -1))
+}
+
+func (s *spamScorer) Score(signals Signals) Result {
+	result := Result{
+		Reasons: []string{},
+	}
+
+	if signals.Duplicates > 0 {
+		result.Score += duplicateWeight * float64(signals.Duplicates)
+		result.Reasons = append(result.Reasons, "duplicate")
+	}
+
+	if links := CountLinks(signals.Text); links > allowedLinks {
+		result.Score += linkWeight * float64(links-allowedLinks)
+		result.Reasons = append(result.Reasons, "links")
+	}
+
+	if signals.RecentComments > allowedVelocity {
+		result.Score += velocityWeight * float64(signals.RecentComments-allowedVelocity)
+		result.Reasons = append(result.Reasons, "velocity")
+	}
+
+	if signals.AccountAge < NewAccountAge {
+		// Fresh accounts weigh in only together with other signals
+		if len(result.Reasons) != 0 {
+			result.Score += newAccountWeight
+			result.Reasons = append(result.Reasons, "new_account")
+		}
+	}
+
+	result.Score = math.Round(result.Score*100) / 100
+
+	return result
+}
@@ -0,0 +1,132 @@
+package spam_scorer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const establishedAccount = NewAccountAge
+
+type SpamScorerUnitTestsSuite struct {
+	suite.Suite
+	scorer SpamScorer
+}
+
+func (suite *SpamScorerUnitTestsSuite) SetupSuite() {
+	suite.scorer = NewSpamScorer()
+}
+
+func TestSpamScorerUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(SpamScorerUnitTestsSuite))
+}
+
+func (suite *SpamScorerUnitTestsSuite) TestSpamScorer_CountLinks() {
+	cases := []struct {
+		text     string
+		expected int
+	}{
+		{"no links here", 0},
+		{"visit https://spam.com now", 1},
+		{"HTTP://A.com and www.b.com and http://c.com", 3},
+		{"www. is not a link", 0},
+	}
+
+	for _, c := range cases {
+		assert.Equal(suite.T(), c.expected, CountLinks(c.text), c.text)
+	}
+}
+
+func (suite *SpamScorerUnitTestsSuite) TestSpamScorer_Score() {
+	cases := []struct {
+		name        string
+		signals     Signals
+		score       float64
+		reasons     []string
+		quarantined bool
+	}{
+		{
+			name:    "clean comment",
+			signals: Signals{Text: "Nice photo", AccountAge: establishedAccount},
+			score:   0,
+			reasons: []string{},
+		},
+		{
+			name:    "new account alone is not a signal",
+			signals: Signals{Text: "Nice photo", AccountAge: 60},
+			score:   0,
+			reasons: []string{},
+		},
+		{
+			name:    "account without activity alone is not a signal",
+			signals: Signals{Text: "Nice photo", AccountAge: -1},
+			score:   0,
+			reasons: []string{},
+		},
+		{
+			name:    "one link is allowed",
+			signals: Signals{Text: "see https://a.com", AccountAge: establishedAccount},
+			score:   0,
+			reasons: []string{},
+		},
+		{
+			name:    "links over allowed",
+			signals: Signals{Text: "https://a.com https://b.com www.c.com", AccountAge: establishedAccount},
+			score:   0.5,
+			reasons: []string{"links"},
+		},
+		{
+			name:    "duplicates",
+			signals: Signals{Text: "Nice photo", Duplicates: 2, AccountAge: establishedAccount},
+			score:   0.8,
+			reasons: []string{"duplicate"},
+		},
+		{
+			name:    "velocity at allowed",
+			signals: Signals{Text: "Nice photo", RecentComments: allowedVelocity, AccountAge: establishedAccount},
+			score:   0,
+			reasons: []string{},
+		},
+		{
+			name:    "velocity over allowed",
+			signals: Signals{Text: "Nice photo", RecentComments: allowedVelocity + 2, AccountAge: establishedAccount},
+			score:   0.3,
+			reasons: []string{"velocity"},
+		},
+		{
+			name:        "new account adds to other signals",
+			signals:     Signals{Text: "Nice photo", Duplicates: 2, AccountAge: 60},
+			score:       1.1,
+			reasons:     []string{"duplicate", "new_account"},
+			quarantined: true,
+		},
+		{
+			name:        "duplicates reach threshold",
+			signals:     Signals{Text: "Nice photo", Duplicates: 3, AccountAge: establishedAccount},
+			score:       1.2,
+			reasons:     []string{"duplicate"},
+			quarantined: true,
+		},
+		{
+			name:        "all signals",
+			signals:     Signals{Text: "https://a.com https://b.com", Duplicates: 1, RecentComments: allowedVelocity + 1, AccountAge: 0},
+			score:       1.1,
+			reasons:     []string{"duplicate", "links", "velocity", "new_account"},
+			quarantined: true,
+		},
+		{
+			name:    "just below threshold",
+			signals: Signals{Text: "Nice photo", Duplicates: 1, RecentComments: allowedVelocity + 3, AccountAge: establishedAccount},
+			score:   0.85,
+			reasons: []string{"duplicate", "velocity"},
+		},
+	}
+
+	for _, c := range cases {
+		result := suite.scorer.Score(c.signals)
+
+		assert.Equal(suite.T(), c.score, result.Score, c.name)
+		assert.Equal(suite.T(), c.reasons, result.Reasons, c.name)
+		assert.Equal(suite.T(), c.quarantined, result.Quarantined(), c.name)
+	}
+}
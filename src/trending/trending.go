@@ -0,0 +1,86 @@
+package trending
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// Number of windows preceding the current one which make the baseline
+	BaselinePeriods = 4
+	// Term is spiking when its count is this many times above the baseline
+	spikeFactor = 3.0
+	// Minimum count in current window for a term to be considered spiking
+	spikeMinCount = 5
+	// Number of terms returned per list
+	TopSize = 10
+)
+
+var (
+	hashtagRegexp = regexp.MustCompile(`#[a-z0-9_]+`)
+	// Dots are part of a username only between other characters, so a mention ending a sentence keeps its name
+	mentionRegexp = regexp.MustCompile(`@([a-z0-9_]+(?:\.[a-z0-9_]+)*)`)
+)
+
+// Extract returns distinct lowercased hashtags and mentioned usernames from text
+func Extract(content string) ([]string, []string) {
+	content = strings.ToLower(content)
+
+	var mentions []string
+	for _, match := range mentionRegexp.FindAllStringSubmatch(content, -1) {
+		mentions = append(mentions, match[1])
+	}
+
+	return distinct(hashtagRegexp.FindAllString(content, -1)), distinct(mentions)
+}
+
+// Rank returns top terms by their count in current window.
+// Every term is compared to its average count in BaselinePeriods preceding windows to detect spikes.
+func Rank(counts []post_term.TermCount) []dtos.TrendingTermDTO {
+	terms := make([]dtos.TrendingTermDTO, 0, len(counts))
+	for _, count := range counts {
+		if count.Current == 0 {
+			continue
+		}
+
+		baseline := float64(count.Baseline) / BaselinePeriods
+		reference := baseline
+		if reference < 1 {
+			reference = 1
+		}
+
+		terms = append(terms, dtos.TrendingTermDTO{
+			Term:     count.Term,
+			Count:    count.Current,
+			Baseline: baseline,
+			Spike:    count.Current >= spikeMinCount && float64(count.Current) >= spikeFactor*reference,
+		})
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+
+	if len(terms) > TopSize {
+		terms = terms[:TopSize]
+	}
+	return terms
+}
+
+func distinct(terms []string) []string {
+	seen := make(map[string]bool)
+	var collection []string
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			collection = append(collection, term)
+		}
+	}
+	return collection
+}
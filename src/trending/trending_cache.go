@@ -0,0 +1,34 @@
+package trending
+
+import (
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"sync"
+)
+
+// TrendingCache holds the latest aggregates per window computed by the background job
+type TrendingCache interface {
+	Set(map[dtos.TrendingWindow]dtos.TrendingDTO)
+	Get(dtos.TrendingWindow) (dtos.TrendingDTO, bool)
+}
+
+type trendingCache struct {
+	mutex      sync.RWMutex
+	aggregates map[dtos.TrendingWindow]dtos.TrendingDTO
+}
+
+func NewTrendingCache() TrendingCache {
+	return &trendingCache{}
+}
+
+func (c *trendingCache) Set(aggregates map[dtos.TrendingWindow]dtos.TrendingDTO) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.aggregates = aggregates
+}
+
+func (c *trendingCache) Get(window dtos.TrendingWindow) (dtos.TrendingDTO, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	aggregate, ok := c.aggregates[window]
+	return aggregate, ok
+}
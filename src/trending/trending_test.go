@@ -0,0 +1,88 @@
+package trending
+
+import (
+	"fmt"
+	"github.com/Nistagram-Organization/nistagram-posts/src/dtos"
+	"github.com/Nistagram-Organization/nistagram-posts/src/model/post_term"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TrendingUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestTrendingUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(TrendingUnitTestsSuite))
+}
+
+func terms(collection []dtos.TrendingTermDTO) []string {
+	var result []string
+	for _, term := range collection {
+		result = append(result, term.Term)
+	}
+	return result
+}
+
+func (suite *TrendingUnitTestsSuite) TestTrending_Extract() {
+	hashtags, mentions := Extract("Sunset #Beach #beach #summer with @Marko_M and @ana.a, @marko_m")
+
+	assert.Equal(suite.T(), []string{"#beach", "#summer"}, hashtags)
+	assert.Equal(suite.T(), []string{"marko_m", "ana.a"}, mentions)
+}
+
+func (suite *TrendingUnitTestsSuite) TestTrending_Extract_MentionEndingSentence() {
+	_, mentions := Extract("Thanks @ana. See you soon @marko...")
+
+	assert.Equal(suite.T(), []string{"ana", "marko"}, mentions)
+}
+
+func (suite *TrendingUnitTestsSuite) TestTrending_Rank() {
+	counts := []post_term.TermCount{
+		{Term: "#other", Current: 2},
+		{Term: "#new", Current: 2},
+		{Term: "#old", Current: 0, Baseline: 8},
+		{Term: "#top", Current: 3},
+	}
+
+	hashtags := Rank(counts)
+
+	assert.Equal(suite.T(), []string{"#top", "#new", "#other"}, terms(hashtags))
+	assert.Equal(suite.T(), int64(3), hashtags[0].Count)
+}
+
+func (suite *TrendingUnitTestsSuite) TestTrending_Rank_TopSize() {
+	var counts []post_term.TermCount
+	for i := 0; i < TopSize+5; i++ {
+		counts = append(counts, post_term.TermCount{Term: fmt.Sprintf("#term%02d", i), Current: 1})
+	}
+
+	hashtags := Rank(counts)
+
+	assert.Len(suite.T(), hashtags, TopSize)
+	assert.Equal(suite.T(), "#term00", hashtags[0].Term)
+}
+
+func (suite *TrendingUnitTestsSuite) TestTrending_Rank_Spike() {
+	counts := []post_term.TermCount{
+		// Steady term, 6 uses in each baseline window and in current one
+		{Term: "#steady", Current: 6, Baseline: 6 * BaselinePeriods},
+		// Term which was quiet in baseline windows
+		{Term: "#spiking", Current: 5, Baseline: 1},
+	}
+
+	hashtags := Rank(counts)
+
+	assert.Equal(suite.T(), []string{"#steady", "#spiking"}, terms(hashtags))
+	assert.False(suite.T(), hashtags[0].Spike)
+	assert.Equal(suite.T(), 6.0, hashtags[0].Baseline)
+	assert.True(suite.T(), hashtags[1].Spike)
+	assert.Equal(suite.T(), 0.25, hashtags[1].Baseline)
+}
+
+func (suite *TrendingUnitTestsSuite) TestTrending_Rank_BelowMinimumIsNotSpike() {
+	hashtags := Rank([]post_term.TermCount{{Term: "#rare", Current: spikeMinCount - 1}})
+
+	assert.False(suite.T(), hashtags[0].Spike)
+}